@@ -2,16 +2,19 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 	"testing"
 
+	vmtypes "github.com/netcloth/netcloth-chain/app/v0/vm/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmsm "github.com/tendermint/tendermint/state"
 	tm "github.com/tendermint/tendermint/types"
@@ -20,7 +23,7 @@ import (
 
 var (
 	// the genesis file in unittest/ should be modified with this
-	totalModuleNum = 16
+	totalModuleNum = 19
 )
 
 func TestExport(t *testing.T) {
@@ -143,3 +146,33 @@ func TestExport(t *testing.T) {
 		app.postEndBlocker(testInput)
 	})
 }
+
+func TestBeginBlockVMBlockHash(t *testing.T) {
+	app := NewNCHApp(log.NewNopLogger(), db.NewMemDB(), nil, true, 0)
+
+	genDoc, err := tm.GenesisDocFromFile("./genesis/genesis.json")
+	require.NoError(t, err)
+	genState, err := tmsm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	app.InitChain(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		ConsensusParams: tm.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		Validators:      tm.TM2PB.ValidatorUpdates(genState.Validators),
+		AppStateBytes:   genDoc.AppState,
+	})
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	// the vm begin blocker stores the hash of the previous block for the BLOCKHASH opcode
+	hash := tmhash.Sum([]byte("block 1"))
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2, LastBlockId: abci.BlockID{Hash: hash}}})
+	app.EndBlock(abci.RequestEndBlock{Height: 2})
+	app.Commit()
+
+	res := app.Query(abci.RequestQuery{Path: fmt.Sprintf("/store/%s/key", vmtypes.StoreKey), Data: vmtypes.BlockHashKey(1)})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, hash, res.Value)
+}
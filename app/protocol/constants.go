@@ -19,6 +19,9 @@ const (
 	IpalModuleName         = "ipal"
 	CIpalModuleName        = "cipal"
 	VMModuleName           = "vm"
+	FeegrantModuleName     = "feegrant"
+	SchedulerModuleName    = "scheduler"
+	AuthzModuleName        = "authz"
 )
 
 // all store keys name
@@ -38,6 +41,9 @@ const (
 	IpalStoreKey         = IpalModuleName
 	CIpalStoreKey        = CIpalModuleName
 	VMStoreKey           = VMModuleName
+	FeegrantStoreKey     = FeegrantModuleName
+	SchedulerStoreKey    = SchedulerModuleName
+	AuthzStoreKey        = AuthzModuleName
 
 	ParamsTStoreKey  = "transient_" + ParamsStoreKey
 	StakingTStoreKey = "transient_" + StakingStoreKey
@@ -60,6 +66,9 @@ var (
 		AuthStoreKey,
 		UpgradeStoreKey,
 		GuardianStoreKey,
+		FeegrantStoreKey,
+		SchedulerStoreKey,
+		AuthzStoreKey,
 	)
 
 	TKeys = sdk.NewTransientStoreKeys(
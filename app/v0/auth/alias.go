@@ -20,6 +20,8 @@ const (
 	DefaultSigVerifyCostED25519   = types.DefaultSigVerifyCostED25519
 	DefaultSigVerifyCostSecp256k1 = types.DefaultSigVerifyCostSecp256k1
 	QueryAccount                  = types.QueryAccount
+	RouterKey                     = types.RouterKey
+	TypeMsgMetaTx                 = types.TypeMsgMetaTx
 
 	RefundKey = types.RefundKey
 )
@@ -53,6 +55,8 @@ var (
 	NewTxBuilderFromCLI            = types.NewTxBuilderFromCLI
 	MakeSignature                  = types.MakeSignature
 	NewAccountRetriever            = types.NewAccountRetriever
+	NewMsgMetaTx                   = types.NewMsgMetaTx
+	MetaTxSignBytes                = types.MetaTxSignBytes
 
 	// variable aliases
 	ModuleCdc                 = types.ModuleCdc
@@ -81,4 +85,6 @@ type (
 	StdSignDoc               = types.StdSignDoc
 	StdSignature             = types.StdSignature
 	TxBuilder                = types.TxBuilder
+	MsgMetaTx                = types.MsgMetaTx
+	MetaTxSignDoc            = types.MetaTxSignDoc
 )
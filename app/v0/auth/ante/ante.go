@@ -8,9 +8,9 @@ import (
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
-// signer.
+// signer, or from the fee granter of the tx out of its fee allowance.
 
-func NewAnteHandler(ak auth.AccountKeeper, supplyKeeper types.SupplyKeeper, sigGasConsumer SignatureVerificationGasConsumer) sdk.AnteHandler {
+func NewAnteHandler(ak auth.AccountKeeper, supplyKeeper types.SupplyKeeper, feegrantKeeper types.FeegrantKeeper, sigGasConsumer SignatureVerificationGasConsumer) sdk.AnteHandler {
 	return sdk.ChainAnteDecorators(
 		NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 		NewFeePreprocessDecorator(ak),
@@ -20,9 +20,10 @@ func NewAnteHandler(ak auth.AccountKeeper, supplyKeeper types.SupplyKeeper, sigG
 		NewConsumeGasForTxSizeDecorator(ak),
 		NewSetPubKeyDecorator(ak), // SetPubKeyDecorator must be called before all signature verification decorators
 		NewValidateSigCountDecorator(ak),
-		NewDeductFeeDecorator(ak, supplyKeeper),
+		NewDeductFeeDecorator(ak, supplyKeeper, feegrantKeeper),
 		NewSigGasConsumeDecorator(ak, sigGasConsumer),
 		NewSigVerificationDecorator(ak),
+		NewMetaTxDecorator(ak, sigGasConsumer),
 		NewIncrementSequenceDecorator(ak), // innermost AnteDecorator
 	)
 }
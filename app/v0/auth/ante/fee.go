@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	_ FeeTx = (*types.StdTx)(nil)
+	_ FeeTx        = (*types.StdTx)(nil)
+	_ FeeGranterTx = (*types.StdTx)(nil)
 )
 
 type FeeTx interface {
@@ -21,6 +22,12 @@ type FeeTx interface {
 	FeePayer() sdk.AccAddress
 }
 
+// FeeGranterTx is implemented by the txs whose fee can be paid by a fee granter
+type FeeGranterTx interface {
+	FeeTx
+	GetFeeGranter() sdk.AccAddress
+}
+
 type FeePreprocessDecorator struct {
 	ak auth.AccountKeeper
 }
@@ -102,19 +109,22 @@ func (mfd MempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate b
 	return next(ctx, tx, simulate)
 }
 
-// DeductFeeDecorator deducts fees from the first signer of the tx
+// DeductFeeDecorator deducts fees from the first signer of the tx, or from the fee granter of the tx
+// when it has one, out of the fee allowance it granted to the first signer
 // If the first signer does not have the funds to pay for the fees, return with InsufficientFunds error
 // Call next AnteHandler if fees successfully deducted
 // CONTRACT: Tx must implement FeeTx interface to use DeductFeeDecorator
 type DeductFeeDecorator struct {
-	ak           auth.AccountKeeper
-	supplyKeeper types.SupplyKeeper
+	ak             auth.AccountKeeper
+	supplyKeeper   types.SupplyKeeper
+	feegrantKeeper types.FeegrantKeeper
 }
 
-func NewDeductFeeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper) DeductFeeDecorator {
+func NewDeductFeeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, fk types.FeegrantKeeper) DeductFeeDecorator {
 	return DeductFeeDecorator{
-		ak:           ak,
-		supplyKeeper: sk,
+		ak:             ak,
+		supplyKeeper:   sk,
+		feegrantKeeper: fk,
 	}
 }
 
@@ -129,6 +139,19 @@ func (dfd DeductFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 	}
 
 	feePayer := feeTx.FeePayer()
+	if granterTx, ok := tx.(FeeGranterTx); ok && !granterTx.GetFeeGranter().Empty() {
+		if dfd.feegrantKeeper == nil {
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "fee grants are not enabled")
+		}
+
+		feeGranter := granterTx.GetFeeGranter()
+		err = dfd.feegrantKeeper.UseGrantedFees(ctx, feeGranter, feePayer, feeTx.GetFee(), tx.GetMsgs())
+		if err != nil {
+			return ctx, sdkerrors.Wrapf(err, "%s not allowed to pay fees from %s", feePayer, feeGranter)
+		}
+		feePayer = feeGranter
+	}
+
 	feePayerAcc := dfd.ak.GetAccount(ctx, feePayer)
 
 	if feePayerAcc == nil {
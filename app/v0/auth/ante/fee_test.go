@@ -0,0 +1,102 @@
+package ante
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	supplyexported "github.com/netcloth/netcloth-chain/app/v0/supply/exported"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// testSupplyKeeper moves the fees out of the accounts and keeps the collected fees
+type testSupplyKeeper struct {
+	ak        auth.AccountKeeper
+	collected sdk.Coins
+}
+
+func (sk *testSupplyKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, _ string, amt sdk.Coins) error {
+	acc := sk.ak.GetAccount(ctx, senderAddr)
+	if err := acc.SetCoins(acc.GetCoins().Sub(amt)); err != nil {
+		return err
+	}
+	sk.ak.SetAccount(ctx, acc)
+	sk.collected = sk.collected.Add(amt)
+	return nil
+}
+
+func (sk *testSupplyKeeper) SendCoinsFromModuleToAccount(sdk.Context, string, sdk.AccAddress, sdk.Coins) error {
+	return nil
+}
+
+func (sk *testSupplyKeeper) GetModuleAccount(sdk.Context, string) supplyexported.ModuleAccountI {
+	return nil
+}
+
+func (sk *testSupplyKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(moduleName)))
+}
+
+// testFeegrantKeeper grants an unlimited allowance from granter to grantee
+type testFeegrantKeeper struct {
+	granter, grantee sdk.AccAddress
+}
+
+func (fk testFeegrantKeeper) UseGrantedFees(_ sdk.Context, granter, grantee sdk.AccAddress, _ sdk.Coins, _ []sdk.Msg) error {
+	if !granter.Equals(fk.granter) || !grantee.Equals(fk.grantee) {
+		return sdkerrors.ErrUnauthorized
+	}
+	return nil
+}
+
+func TestDeductFeeDecoratorFeeGranter(t *testing.T) {
+	ctx, ak := setupAccountKeeper()
+	priv, _, payer := types.KeyTestPubAddr()
+	_, _, granter := types.KeyTestPubAddr()
+	for _, addr := range []sdk.AccAddress{payer, granter} {
+		acc := ak.NewAccountWithAddress(ctx, addr)
+		require.NoError(t, acc.SetCoins(types.NewTestCoins()))
+		ak.SetAccount(ctx, acc)
+	}
+
+	sk := &testSupplyKeeper{ak: ak}
+	fee := types.NewTestStdFee()
+	newTx := func(feeGranter sdk.AccAddress) sdk.Tx {
+		tx := types.NewTestTx(ctx, []sdk.Msg{types.NewTestMsg(payer)}, []crypto.PrivKey{priv}, []uint64{0}, []uint64{0}, fee).(types.StdTx)
+		tx.FeeGranter = feeGranter
+		return tx
+	}
+
+	var feePayer sdk.AccAddress
+	terminator := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		feePayer = auth.GetFeePayers(ctx).GetAddress()
+		return ctx, nil
+	}
+
+	// the fee granter pays the fee and gets the refund
+	deductFee := NewDeductFeeDecorator(ak, sk, testFeegrantKeeper{granter: granter, grantee: payer})
+	_, err := deductFee.AnteHandle(ctx, newTx(granter), false, terminator)
+	require.NoError(t, err)
+	require.Equal(t, granter, feePayer)
+	require.Equal(t, types.NewTestCoins().Sub(fee.Amount), ak.GetAccount(ctx, granter).GetCoins())
+	require.Equal(t, types.NewTestCoins(), ak.GetAccount(ctx, payer).GetCoins())
+
+	// without a grant
+	_, _, other := types.KeyTestPubAddr()
+	_, err = deductFee.AnteHandle(ctx, newTx(other), false, terminator)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// without a fee granter the fee payer pays
+	_, err = deductFee.AnteHandle(ctx, newTx(nil), false, terminator)
+	require.NoError(t, err)
+	require.Equal(t, payer, feePayer)
+	require.Equal(t, types.NewTestCoins().Sub(fee.Amount), ak.GetAccount(ctx, payer).GetCoins())
+
+	// fee grants are disabled without a feegrant keeper
+	_, err = NewDeductFeeDecorator(ak, sk, nil).AnteHandle(ctx, newTx(granter), false, terminator)
+	require.True(t, sdkerrors.ErrInvalidRequest.Is(err))
+}
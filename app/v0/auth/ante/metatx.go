@@ -0,0 +1,64 @@
+package ante
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// MetaTxDecorator charges the signature verification of the MsgMetaTxs of a tx. The relayer signs the
+// tx and pays its fee, the signer of each relayed msg signs it together with its own sequence, an
+// expiry height and the max fee it accepts the relayer to pay. The meta txs are authorised by the auth
+// handler when they are delivered, and by this decorator on CheckTx.
+// CONTRACT: Tx must implement FeeTx interface to use MetaTxDecorator
+type MetaTxDecorator struct {
+	ak             auth.AccountKeeper
+	sigGasConsumer SignatureVerificationGasConsumer
+}
+
+func NewMetaTxDecorator(ak auth.AccountKeeper, sigGasConsumer SignatureVerificationGasConsumer) MetaTxDecorator {
+	return MetaTxDecorator{
+		ak:             ak,
+		sigGasConsumer: sigGasConsumer,
+	}
+}
+
+func (mtd MetaTxDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(FeeTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	params := mtd.ak.GetParams(ctx)
+	for _, msg := range tx.GetMsgs() {
+		metaTx, ok := msg.(types.MsgMetaTx)
+		if !ok {
+			continue
+		}
+
+		pubKey := metaTx.Signature.PubKey
+		if acc := mtd.ak.GetAccount(ctx, metaTx.Signer()); acc != nil && acc.GetPubKey() != nil {
+			pubKey = acc.GetPubKey()
+		}
+		if simulate && pubKey == nil {
+			pubKey = simSecp256k1Pubkey
+		}
+		if pubKey == nil {
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "pubkey of the meta tx signer is not set")
+		}
+		if err := mtd.sigGasConsumer(ctx.GasMeter(), metaTx.Signature.Signature, pubKey, params); err != nil {
+			return ctx, err
+		}
+
+		// the relayed msgs are not run by CheckTx, the meta tx is verified and its sequence consumed
+		// here to keep it out of the mempool once included
+		if ctx.IsCheckTx() && !simulate {
+			if err := auth.VerifyMetaTx(ctx, mtd.ak, metaTx, feeTx.GetFee(), simulate); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
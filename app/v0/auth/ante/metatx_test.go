@@ -0,0 +1,101 @@
+package ante
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func setupAccountKeeper() (sdk.Context, auth.AccountKeeper) {
+	db := dbm.NewMemDB()
+	cdc := types.ModuleCdc
+
+	authCapKey := sdk.NewKVStoreKey("auth")
+	keyParams := sdk.NewKVStoreKey("params")
+	tKeyParams := sdk.NewTransientStoreKey("transient_params")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authCapKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyParams, sdk.StoreTypeTransient, db)
+	ms.LoadLatestVersion()
+
+	pk := params.NewKeeper(cdc, keyParams, tKeyParams)
+	ak := auth.NewAccountKeeper(cdc, authCapKey, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "test-chain-id", Height: 1}, false, log.NewNopLogger())
+	ak.SetParams(ctx, types.DefaultParams())
+
+	return ctx, ak
+}
+
+func TestMetaTxDecorator(t *testing.T) {
+	ctx, ak := setupAccountKeeper()
+	ctx = ctx.WithIsCheckTx(true)
+	anteHandler := sdk.ChainAnteDecorators(NewMetaTxDecorator(ak, DefaultSigVerificationGasConsumer))
+
+	relayerPriv, _, relayer := types.KeyTestPubAddr()
+	priv, _, signer := types.KeyTestPubAddr()
+	ak.SetAccount(ctx, ak.NewAccountWithAddress(ctx, relayer))
+	acc := ak.NewAccountWithAddress(ctx, signer)
+	ak.SetAccount(ctx, acc)
+
+	fee := types.NewTestStdFee()
+	newTx := func(metaTx types.MsgMetaTx) sdk.Tx {
+		return types.NewTestTx(ctx, []sdk.Msg{metaTx}, []crypto.PrivKey{relayerPriv}, []uint64{0}, []uint64{0}, fee)
+	}
+
+	// the signer authorises the msg with its own sequence
+	metaTx := types.NewTestMetaTx(ctx, relayer, types.NewTestMsg(signer), priv, acc.GetAccountNumber(), 0, 10, fee.Amount)
+	_, err := anteHandler(ctx, newTx(metaTx), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), ak.GetAccount(ctx, signer).GetSequence())
+	require.NotNil(t, ak.GetAccount(ctx, signer).GetPubKey())
+
+	// the same meta tx can not be replayed
+	_, err = anteHandler(ctx, newTx(metaTx), false)
+	require.True(t, sdkerrors.ErrInvalidSequence.Is(err))
+
+	// the meta tx expires
+	metaTx = types.NewTestMetaTx(ctx, relayer, types.NewTestMsg(signer), priv, acc.GetAccountNumber(), 1, 10, fee.Amount)
+	_, err = anteHandler(ctx.WithBlockHeight(11), newTx(metaTx), false)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// the fee of the relayer is bounded
+	lowFee := types.NewTestMetaTx(ctx, relayer, types.NewTestMsg(signer), priv, acc.GetAccountNumber(), 1, 10, sdk.NewCoins(sdk.NewInt64Coin("nch", 1)))
+	_, err = anteHandler(ctx, newTx(lowFee), false)
+	require.True(t, sdkerrors.ErrInsufficientFee.Is(err))
+
+	// the signature must cover the relayed fields
+	forged := metaTx
+	forged.Expiry = 20
+	_, err = anteHandler(ctx, newTx(forged), false)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// signed by another key
+	otherPriv, _, _ := types.KeyTestPubAddr()
+	forged = types.NewTestMetaTx(ctx, relayer, types.NewTestMsg(signer), otherPriv, acc.GetAccountNumber(), 1, 10, fee.Amount)
+	_, err = anteHandler(ctx, newTx(forged), false)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.Equal(t, uint64(1), ak.GetAccount(ctx, signer).GetSequence())
+
+	_, err = anteHandler(ctx, newTx(metaTx), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ak.GetAccount(ctx, signer).GetSequence())
+
+	// on DeliverTx the meta txs are authorised by the auth handler
+	metaTx = types.NewTestMetaTx(ctx, relayer, types.NewTestMsg(signer), priv, acc.GetAccountNumber(), 2, 10, fee.Amount)
+	_, err = anteHandler(ctx.WithIsCheckTx(false), newTx(metaTx), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ak.GetAccount(ctx, signer).GetSequence())
+}
@@ -100,9 +100,9 @@ func makeMultiSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string)
 			}
 
 			// Validate each signature
-			sigBytes := types.StdSignBytes(
+			sigBytes := types.StdSignBytesWithFeeGranter(
 				txBldr.ChainID(), txBldr.AccountNumber(), txBldr.Sequence(),
-				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
+				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(), stdTx.FeeGranter,
 			)
 			if ok := stdSig.PubKey.VerifyBytes(sigBytes, stdSig.Signature); !ok {
 				return fmt.Errorf("couldn't verify signature")
@@ -114,6 +114,7 @@ func makeMultiSignCmd(cdc *codec.Codec) func(cmd *cobra.Command, args []string)
 
 		newStdSig := types.StdSignature{Signature: cdc.MustMarshalBinaryBare(multisigSig), PubKey: multisigPub}
 		newTx := types.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, []types.StdSignature{newStdSig}, stdTx.GetMemo())
+		newTx.FeeGranter = stdTx.FeeGranter
 
 		sigOnly := viper.GetBool(flagSigOnly)
 		var json []byte
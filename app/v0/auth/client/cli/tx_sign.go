@@ -228,9 +228,9 @@ func printAndValidateSigs(
 				return false
 			}
 
-			sigBytes := types.StdSignBytes(
+			sigBytes := types.StdSignBytesWithFeeGranter(
 				chainID, acc.GetAccountNumber(), acc.GetSequence(),
-				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(),
+				stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo(), stdTx.FeeGranter,
 			)
 
 			if ok := sig.VerifyBytes(sigBytes, sig.Signature); !ok {
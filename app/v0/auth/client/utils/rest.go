@@ -29,6 +29,14 @@ func WriteGenerateStdTxResponse(w http.ResponseWriter, cliCtx context.CLIContext
 		br.Simulate, br.ChainID, br.Memo, br.Fees, br.GasPrices,
 	)
 
+	if len(br.FeeGranter) != 0 {
+		if _, err := sdk.AccAddressFromBech32(br.FeeGranter); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		txBldr = txBldr.WithFeeGranter(br.FeeGranter)
+	}
+
 	if br.Simulate || simAndExec {
 		if gasAdj < 0 {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, errInvalidGasAdjustment.Error())
@@ -53,7 +61,9 @@ func WriteGenerateStdTxResponse(w http.ResponseWriter, cliCtx context.CLIContext
 		return
 	}
 
-	output, err := cliCtx.Codec.MarshalJSON(types.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo))
+	stdTx := types.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo)
+	stdTx.FeeGranter = stdMsg.FeeGranter
+	output, err := cliCtx.Codec.MarshalJSON(stdTx)
 	if err != nil {
 		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -344,7 +344,9 @@ func buildUnsignedStdTxOffline(txBldr authtypes.TxBuilder, cliCtx context.CLICon
 		return stdTx, nil
 	}
 
-	return authtypes.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo), nil
+	stdTx = authtypes.NewStdTx(stdSignMsg.Msgs, stdSignMsg.Fee, nil, stdSignMsg.Memo)
+	stdTx.FeeGranter = stdSignMsg.FeeGranter
+	return stdTx, nil
 }
 
 func isTxSigner(user sdk.AccAddress, signers []sdk.AccAddress) bool {
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NewHandler returns a handler for "auth" type messages, the msgs relayed by meta txs are
+// dispatched through the given router. The tx of a meta tx is decoded by txDecoder to read its fee.
+func NewHandler(ak AccountKeeper, router sdk.Router, txDecoder sdk.TxDecoder) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		switch msg := msg.(type) {
+		case types.MsgMetaTx:
+			return handleMsgMetaTx(ctx, ak, router, txDecoder, msg)
+
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+	}
+}
+
+// handleMsgMetaTx authorises the relayed msg against the fee of the tx including the meta tx, then
+// runs it. The sequence of the signer is consumed even when the relayed msg fails, so that the meta tx
+// can not be replayed later on, the error of the msg is returned in the result log.
+func handleMsgMetaTx(ctx sdk.Context, ak AccountKeeper, router sdk.Router, txDecoder sdk.TxDecoder, msg types.MsgMetaTx) (*sdk.Result, error) {
+	tx, err := txDecoder(ctx.TxBytes())
+	if err != nil {
+		return nil, err
+	}
+	feeTx, ok := tx.(types.StdTx)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "meta txs must be included in a StdTx")
+	}
+
+	handler := router.Route(ctx, msg.Msg.Route())
+	if handler == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized relayed message route: %s", msg.Msg.Route())
+	}
+
+	if err := VerifyMetaTx(ctx, ak, msg, feeTx.Fee.Amount, ctx.Simulate); err != nil {
+		return nil, err
+	}
+
+	// the writes of the relayed msg are dropped when it fails, not the sequence increment
+	cacheCtx, write := ctx.CacheContext()
+	res, err := handler(cacheCtx, msg.Msg)
+
+	event := sdk.NewEvent(
+		types.EventTypeMetaTx,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(types.AttributeKeyRelayer, msg.Relayer.String()),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Signer().String()),
+		sdk.NewAttribute(types.AttributeKeySuccess, fmt.Sprintf("%t", err == nil)),
+	)
+	if err != nil {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+		ctx.EventManager().EmitEvent(event)
+		return &sdk.Result{Log: fmt.Sprintf("relayed msg failed: %s", err), Events: ctx.EventManager().Events()}, nil
+	}
+
+	write()
+	ctx.EventManager().EmitEvent(event)
+	res.Events = append(res.Events, ctx.EventManager().Events()...)
+	return res, nil
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func TestHandleMsgMetaTx(t *testing.T) {
+	input := setupTestInput()
+	relayerPriv, _, relayer := types.KeyTestPubAddr()
+	priv, _, signer := types.KeyTestPubAddr()
+	input.ak.SetAccount(input.ctx, input.ak.NewAccountWithAddress(input.ctx, signer))
+
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterConcrete(&sdk.TestMsg{}, "nch/TestMsg", nil)
+
+	var handled []sdk.Msg
+	var failure error
+	router := protocol.NewRouter()
+	router.AddRoute("TestMsg", func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		if failure != nil {
+			return nil, failure
+		}
+		handled = append(handled, msg)
+		return &sdk.Result{}, nil
+	})
+	handler := NewHandler(input.ak, router, types.DefaultTxDecoder(cdc))
+
+	// the meta tx is delivered in a tx paying fee
+	fee := types.NewTestStdFee()
+	deliverAt := func(height int64, h sdk.Handler, metaTx types.MsgMetaTx) (*sdk.Result, error) {
+		tx := types.NewTestTx(input.ctx, []sdk.Msg{metaTx}, []crypto.PrivKey{relayerPriv}, []uint64{0}, []uint64{0}, fee)
+		return h(input.ctx.WithBlockHeight(height).WithTxBytes(cdc.MustMarshalBinaryLengthPrefixed(tx)), metaTx)
+	}
+	deliver := func(h sdk.Handler, metaTx types.MsgMetaTx) (*sdk.Result, error) {
+		return deliverAt(1, h, metaTx)
+	}
+
+	msg := types.NewTestMsg(signer)
+	metaTx := types.NewTestMetaTx(input.ctx, relayer, msg, priv, 0, 0, 10, fee.Amount)
+	res, err := deliver(handler, metaTx)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{msg}, handled)
+	require.Len(t, res.Events, 1)
+	require.Equal(t, types.EventTypeMetaTx, res.Events[0].Type)
+	require.Equal(t, uint64(1), input.ak.GetAccount(input.ctx, signer).GetSequence())
+
+	// the same meta tx can not be replayed
+	_, err = deliver(handler, metaTx)
+	require.True(t, sdkerrors.ErrInvalidSequence.Is(err))
+
+	// the meta tx expires
+	metaTx = types.NewTestMetaTx(input.ctx, relayer, msg, priv, 0, 1, 10, fee.Amount)
+	_, err = deliverAt(11, handler, metaTx)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+
+	// the fee of the relayer is bounded
+	lowFee := types.NewTestMetaTx(input.ctx, relayer, msg, priv, 0, 1, 10, sdk.NewCoins(sdk.NewInt64Coin("nch", 1)))
+	_, err = deliver(handler, lowFee)
+	require.True(t, sdkerrors.ErrInsufficientFee.Is(err))
+
+	// signed by another key
+	otherPriv, _, _ := types.KeyTestPubAddr()
+	forged := types.NewTestMetaTx(input.ctx, relayer, msg, otherPriv, 0, 1, 10, fee.Amount)
+	_, err = deliver(handler, forged)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.Len(t, handled, 1)
+
+	// the relayed msg must be routable
+	metaTx.Msg = sdk.NewTestMsg(signer)
+	_, err = deliver(NewHandler(input.ak, protocol.NewRouter(), types.DefaultTxDecoder(cdc)), metaTx)
+	require.True(t, sdkerrors.ErrUnknownRequest.Is(err))
+	require.Equal(t, uint64(1), input.ak.GetAccount(input.ctx, signer).GetSequence())
+
+	// the sequence is consumed when the relayed msg fails, the meta tx can not be replayed once the
+	// msg could succeed
+	failure = sdkerrors.ErrInsufficientFunds
+	metaTx = types.NewTestMetaTx(input.ctx, relayer, msg, priv, 0, 1, 10, fee.Amount)
+	res, err = deliver(handler, metaTx)
+	require.NoError(t, err)
+	require.Contains(t, res.Log, sdkerrors.ErrInsufficientFunds.Error())
+	require.Equal(t, uint64(2), input.ak.GetAccount(input.ctx, signer).GetSequence())
+
+	failure = nil
+	_, err = deliver(handler, metaTx)
+	require.True(t, sdkerrors.ErrInvalidSequence.Is(err))
+	require.Len(t, handled, 1)
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"bytes"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// VerifyMetaTx authorises the msg relayed by a meta tx included in a tx paying fee: the meta tx must
+// not be expired, fee must not exceed its max fee, and it must be signed by the signer of the relayed
+// msg with its current sequence. The pubkey of the signer is set from the signature when unknown, and
+// its sequence is incremented so that the meta tx can not be replayed. The signature is not verified
+// on simulation and recheck.
+func VerifyMetaTx(ctx sdk.Context, ak AccountKeeper, metaTx types.MsgMetaTx, fee sdk.Coins, simulate bool) error {
+	if ctx.BlockHeight() > metaTx.Expiry {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "meta tx expired at height %d", metaTx.Expiry)
+	}
+	if !metaTx.MaxFee.IsAllGTE(fee) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFee, "tx fee %s exceeds the max fee %s of the meta tx", fee, metaTx.MaxFee)
+	}
+
+	signer := metaTx.Signer()
+	acc := ak.GetAccount(ctx, signer)
+	if acc == nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "account %s does not exist", signer)
+	}
+
+	pubKey := acc.GetPubKey()
+	if pubKey == nil && metaTx.Signature.PubKey != nil {
+		pubKey = metaTx.Signature.PubKey
+		if !simulate && !bytes.Equal(pubKey.Address(), signer) {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "pubKey does not match meta tx signer address %s", signer)
+		}
+		if err := acc.SetPubKey(pubKey); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, err.Error())
+		}
+	}
+	if pubKey == nil && !simulate {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidPubKey, "pubkey of the meta tx signer is not set")
+	}
+
+	if metaTx.Sequence != acc.GetSequence() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidSequence, "meta tx sequence %d, expected %d", metaTx.Sequence, acc.GetSequence())
+	}
+
+	// no need to verify signatures on recheck tx
+	if !simulate && !ctx.IsReCheckTx() {
+		signBytes := types.MetaTxSignBytes(ctx.ChainID(), acc.GetAccountNumber(), metaTx.Sequence, metaTx.Expiry, metaTx.MaxFee, metaTx.Msg)
+		if !pubKey.VerifyBytes(signBytes, metaTx.Signature.Signature) {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "meta tx signature verification failed; verify correct account sequence and chain-id")
+		}
+	}
+
+	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+		panic(err)
+	}
+	ak.SetAccount(ctx, acc)
+	return nil
+}
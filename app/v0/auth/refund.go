@@ -19,6 +19,14 @@ func NewRefundKeeper(cdc *codec.Codec, key sdk.StoreKey) RefundKeeper {
 	}
 }
 
+// feeTx is implemented by all txs paying a fee, StdTx as well as txs of other modules
+type feeTx interface {
+	sdk.Tx
+	GetFee() sdk.Coins
+}
+
+// NewFeeRefundHandler refunds the fee of the unused gas to the fee payer of the tx, for the
+// MsgMetaTxs this is the relayer who signed the tx and not the signer of the relayed msg
 func NewFeeRefundHandler(am AccountKeeper, supplyKeeper auth.SupplyKeeper, rk RefundKeeper) sdk.FeeRefundHandler {
 	return func(ctx sdk.Context, tx sdk.Tx, txResult sdk.Result) (actualCostFee sdk.Coin, err error) {
 		txAccount := GetFeePayers(ctx)
@@ -26,13 +34,13 @@ func NewFeeRefundHandler(am AccountKeeper, supplyKeeper auth.SupplyKeeper, rk Re
 			return sdk.Coin{}, nil
 		}
 
-		stdTx, ok := tx.(StdTx)
+		feeTx, ok := tx.(feeTx)
 		if !ok {
 			return sdk.Coin{}, nil
 		}
 		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 
-		fee := getFee(stdTx.Fee.Amount)
+		fee := getFee(feeTx.GetFee())
 
 		// if all gas has been consumed, then there is no need to run the fee refund process
 		if txResult.GasWanted <= txResult.GasUsed {
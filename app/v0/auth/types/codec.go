@@ -14,6 +14,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&ContinuousVestingAccount{}, "nch/ContinuousVestingAccount", nil)
 	cdc.RegisterConcrete(&DelayedVestingAccount{}, "nch/DelayedVestingAccount", nil)
 	cdc.RegisterConcrete(StdTx{}, "nch/StdTx", nil)
+	cdc.RegisterConcrete(MsgMetaTx{}, "nch/MsgMetaTx", nil)
 }
 
 // ModuleCdc - generic sealed codec to be used throughout module
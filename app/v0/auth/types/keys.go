@@ -18,6 +18,9 @@ const (
 	// QuerierRoute is the querier route for acc
 	QuerierRoute = ModuleName
 
+	// RouterKey is the message route for the meta txs
+	RouterKey = ModuleName
+
 	RefundKey = "refund_fee"
 )
 
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	TypeMsgMetaTx = "meta_tx"
+
+	EventTypeMetaTx        = "meta_tx"
+	AttributeKeyRelayer    = "relayer"
+	AttributeKeySigner     = "signer"
+	AttributeKeySuccess    = "success"
+	AttributeKeyError      = "error"
+	AttributeValueCategory = ModuleName
+)
+
+// MsgMetaTx relays a msg authorised by the signature and the sequence of its signer, the relayer
+// signs the tx including it and pays its fee and gas. The msg can only be included up to the
+// Expiry block height, in a tx whose fee does not exceed MaxFee.
+type MsgMetaTx struct {
+	Relayer   sdk.AccAddress `json:"relayer" yaml:"relayer"`
+	Msg       sdk.Msg        `json:"msg" yaml:"msg"`
+	Sequence  uint64         `json:"sequence" yaml:"sequence"`
+	Expiry    int64          `json:"expiry" yaml:"expiry"`
+	MaxFee    sdk.Coins      `json:"max_fee" yaml:"max_fee"`
+	Signature StdSignature   `json:"signature" yaml:"signature"`
+}
+
+var _ sdk.Msg = MsgMetaTx{}
+
+// NewMsgMetaTx creates a new MsgMetaTx instance
+func NewMsgMetaTx(relayer sdk.AccAddress, msg sdk.Msg, sequence uint64, expiry int64, maxFee sdk.Coins, sig StdSignature) MsgMetaTx {
+	return MsgMetaTx{
+		Relayer:   relayer,
+		Msg:       msg,
+		Sequence:  sequence,
+		Expiry:    expiry,
+		MaxFee:    maxFee,
+		Signature: sig,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgMetaTx) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgMetaTx) Type() string { return TypeMsgMetaTx }
+
+// ValidateBasic Implements Msg.
+func (msg MsgMetaTx) ValidateBasic() error {
+	if msg.Relayer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing relayer address")
+	}
+	if msg.Msg == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "missing relayed msg")
+	}
+	if _, ok := msg.Msg.(MsgMetaTx); ok {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "meta txs can not be nested")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return err
+	}
+	if len(msg.Msg.GetSigners()) != 1 {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "relayed msg must have exactly one signer, got %d", len(msg.Msg.GetSigners()))
+	}
+	if msg.Signer().Equals(msg.Relayer) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "relayer can not be the signer of the relayed msg")
+	}
+	if msg.Expiry <= 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "expiry must be positive: %d", msg.Expiry)
+	}
+	if !msg.MaxFee.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.MaxFee.String())
+	}
+	if len(msg.Signature.Signature) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrNoSignatures, "missing signature of the relayed msg")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgMetaTx) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(metaTxSignDoc{
+		Relayer:   msg.Relayer,
+		Msg:       msg.Msg.GetSignBytes(),
+		Sequence:  msg.Sequence,
+		Expiry:    msg.Expiry,
+		MaxFee:    msg.MaxFee,
+		Signature: msg.Signature,
+	})
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg, the relayer signs the tx
+func (msg MsgMetaTx) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Relayer}
+}
+
+// Signer returns the signer of the relayed msg
+func (msg MsgMetaTx) Signer() sdk.AccAddress {
+	return msg.Msg.GetSigners()[0]
+}
+
+type metaTxSignDoc struct {
+	Relayer   sdk.AccAddress  `json:"relayer"`
+	Msg       json.RawMessage `json:"msg"`
+	Sequence  uint64          `json:"sequence"`
+	Expiry    int64           `json:"expiry"`
+	MaxFee    sdk.Coins       `json:"max_fee"`
+	Signature StdSignature    `json:"signature"`
+}
+
+// MetaTxSignDoc is the doc signed by the signer of the msg relayed by a meta tx
+type MetaTxSignDoc struct {
+	AccountNumber uint64          `json:"account_number" yaml:"account_number"`
+	ChainID       string          `json:"chain_id" yaml:"chain_id"`
+	Expiry        int64           `json:"expiry" yaml:"expiry"`
+	MaxFee        sdk.Coins       `json:"max_fee" yaml:"max_fee"`
+	Msg           json.RawMessage `json:"msg" yaml:"msg"`
+	Sequence      uint64          `json:"sequence" yaml:"sequence"`
+}
+
+// MetaTxSignBytes returns the bytes signed by the signer of the msg relayed by a meta tx
+func MetaTxSignBytes(chainID string, accnum uint64, sequence uint64, expiry int64, maxFee sdk.Coins, msg sdk.Msg) []byte {
+	bz, err := ModuleCdc.MarshalJSON(MetaTxSignDoc{
+		AccountNumber: accnum,
+		ChainID:       chainID,
+		Expiry:        expiry,
+		MaxFee:        maxFee,
+		Msg:           msg.GetSignBytes(),
+		Sequence:      sequence,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return sdk.MustSortJSON(bz)
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMsgMetaTxValidateBasic(t *testing.T) {
+	_, _, relayer := KeyTestPubAddr()
+	priv, _, signer := KeyTestPubAddr()
+	ctx := sdk.Context{}.WithChainID("test-chain-id")
+	maxFee := NewTestStdFee().Amount
+
+	valid := NewTestMetaTx(ctx, relayer, NewTestMsg(signer), priv, 0, 0, 10, maxFee)
+	require.NoError(t, valid.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{relayer}, valid.GetSigners())
+	require.Equal(t, signer, valid.Signer())
+
+	cases := []struct {
+		name     string
+		malleate func(msg *MsgMetaTx)
+	}{
+		{"missing relayer", func(msg *MsgMetaTx) { msg.Relayer = nil }},
+		{"missing msg", func(msg *MsgMetaTx) { msg.Msg = nil }},
+		{"nested meta tx", func(msg *MsgMetaTx) { msg.Msg = valid }},
+		{"no signer", func(msg *MsgMetaTx) { msg.Msg = NewTestMsg() }},
+		{"multiple signers", func(msg *MsgMetaTx) { msg.Msg = NewTestMsg(signer, relayer) }},
+		{"relayer signs the msg", func(msg *MsgMetaTx) { msg.Msg = NewTestMsg(relayer) }},
+		{"no expiry", func(msg *MsgMetaTx) { msg.Expiry = 0 }},
+		{"invalid max fee", func(msg *MsgMetaTx) { msg.MaxFee = sdk.Coins{sdk.Coin{Denom: "nch", Amount: sdk.NewInt(-1)}} }},
+		{"missing signature", func(msg *MsgMetaTx) { msg.Signature = StdSignature{} }},
+	}
+	for _, tc := range cases {
+		msg := valid
+		tc.malleate(&msg)
+		require.Error(t, msg.ValidateBasic(), tc.name)
+	}
+}
+
+func TestMetaTxSignBytes(t *testing.T) {
+	_, _, signer := KeyTestPubAddr()
+	msg := NewTestMsg(signer)
+	maxFee := NewTestStdFee().Amount
+
+	bz := MetaTxSignBytes("test-chain-id", 1, 2, 10, maxFee, msg)
+	require.Equal(t, bz, MetaTxSignBytes("test-chain-id", 1, 2, 10, maxFee, msg))
+
+	// each signed field changes the sign bytes
+	require.NotEqual(t, bz, MetaTxSignBytes("other-chain-id", 1, 2, 10, maxFee, msg))
+	require.NotEqual(t, bz, MetaTxSignBytes("test-chain-id", 2, 2, 10, maxFee, msg))
+	require.NotEqual(t, bz, MetaTxSignBytes("test-chain-id", 1, 3, 10, maxFee, msg))
+	require.NotEqual(t, bz, MetaTxSignBytes("test-chain-id", 1, 2, 11, maxFee, msg))
+	require.NotEqual(t, bz, MetaTxSignBytes("test-chain-id", 1, 2, 10, NewTestCoins(), msg))
+}
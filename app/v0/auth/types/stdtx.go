@@ -23,11 +23,14 @@ var (
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
 // NOTE: the first signature is the fee payer (Signatures must not be nil).
+// When FeeGranter is set the fee is paid by the granter out of the fee allowance
+// it granted to the fee payer.
 type StdTx struct {
 	Msgs       []sdk.Msg      `json:"msg" yaml:"msg"`
 	Fee        StdFee         `json:"fee" yaml:"fee"`
 	Signatures []StdSignature `json:"signatures" yaml:"signatures"`
 	Memo       string         `json:"memo" yaml:"memo"`
+	FeeGranter sdk.AccAddress `json:"fee_granter,omitempty" yaml:"fee_granter"`
 }
 
 func NewStdTx(msgs []sdk.Msg, fee StdFee, sigs []StdSignature, memo string) StdTx {
@@ -144,8 +147,8 @@ func (tx StdTx) GetSignBytes(ctx sdk.Context, acc exported.Account) []byte {
 		accNum = acc.GetAccountNumber()
 	}
 
-	return StdSignBytes(
-		chainID, accNum, acc.GetSequence(), tx.Fee, tx.Msgs, tx.Memo,
+	return StdSignBytesWithFeeGranter(
+		chainID, accNum, acc.GetSequence(), tx.Fee, tx.Msgs, tx.Memo, tx.FeeGranter,
 	)
 }
 
@@ -165,6 +168,9 @@ func (tx StdTx) FeePayer() sdk.AccAddress {
 	return sdk.AccAddress{}
 }
 
+// GetFeeGranter returns the address paying the fee on behalf of the fee payer, if any
+func (tx StdTx) GetFeeGranter() sdk.AccAddress { return tx.FeeGranter }
+
 //__________________________________________________________
 
 // StdFee includes the amount of coins paid in fees and the maximum
@@ -222,10 +228,17 @@ type StdSignDoc struct {
 	Memo          string            `json:"memo" yaml:"memo"`
 	Msgs          []json.RawMessage `json:"msgs" yaml:"msgs"`
 	Sequence      uint64            `json:"sequence" yaml:"sequence"`
+	FeeGranter    sdk.AccAddress    `json:"fee_granter,omitempty" yaml:"fee_granter"`
 }
 
 // StdSignBytes returns the bytes to sign for a transaction.
 func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, msgs []sdk.Msg, memo string) []byte {
+	return StdSignBytesWithFeeGranter(chainID, accnum, sequence, fee, msgs, memo, nil)
+}
+
+// StdSignBytesWithFeeGranter returns the bytes to sign for a transaction whose fee is paid by a
+// fee granter, they are the same as StdSignBytes when there is no fee granter.
+func StdSignBytesWithFeeGranter(chainID string, accnum uint64, sequence uint64, fee StdFee, msgs []sdk.Msg, memo string, feeGranter sdk.AccAddress) []byte {
 	msgsBytes := make([]json.RawMessage, 0, len(msgs))
 
 	for _, msg := range msgs {
@@ -239,6 +252,7 @@ func StdSignBytes(chainID string, accnum uint64, sequence uint64, fee StdFee, ms
 		Memo:          memo,
 		Msgs:          msgsBytes,
 		Sequence:      sequence,
+		FeeGranter:    feeGranter,
 	})
 
 	if err != nil {
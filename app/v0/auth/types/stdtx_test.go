@@ -0,0 +1,34 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestStdSignBytesFeeGranter(t *testing.T) {
+	_, _, addr := KeyTestPubAddr()
+	_, _, granter := KeyTestPubAddr()
+	msgs := []sdk.Msg{NewTestMsg(addr)}
+	fee := NewTestStdFee()
+
+	// the sign bytes of the txs without fee granter are unchanged
+	bz := StdSignBytes("test-chain-id", 1, 2, fee, msgs, "memo")
+	require.Equal(t, bz, StdSignBytesWithFeeGranter("test-chain-id", 1, 2, fee, msgs, "memo", nil))
+	require.False(t, strings.Contains(string(bz), "fee_granter"))
+
+	withGranter := StdSignBytesWithFeeGranter("test-chain-id", 1, 2, fee, msgs, "memo", granter)
+	require.NotEqual(t, bz, withGranter)
+	require.True(t, strings.Contains(string(withGranter), granter.String()))
+
+	tx := NewStdTx(msgs, fee, nil, "memo")
+	tx.FeeGranter = granter
+	require.Equal(t, granter, tx.GetFeeGranter())
+	require.Equal(t, addr, tx.FeePayer())
+
+	signMsg := StdSignMsg{ChainID: "test-chain-id", AccountNumber: 1, Sequence: 2, Fee: fee, Msgs: msgs, Memo: "memo", FeeGranter: granter}
+	require.Equal(t, withGranter, signMsg.Bytes())
+}
@@ -80,3 +80,14 @@ func NewTestTxWithSignBytes(msgs []sdk.Msg, privs []crypto.PrivKey, accNums []ui
 	tx := NewStdTx(msgs, fee, sigs, memo)
 	return tx
 }
+
+func NewTestMetaTx(ctx sdk.Context, relayer sdk.AccAddress, msg sdk.Msg, priv crypto.PrivKey, accNum, seq uint64, expiry int64, maxFee sdk.Coins) MsgMetaTx {
+	signBytes := MetaTxSignBytes(ctx.ChainID(), accNum, seq, expiry, maxFee, msg)
+
+	sig, err := priv.Sign(signBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewMsgMetaTx(relayer, msg, seq, expiry, maxFee, StdSignature{PubKey: priv.PubKey(), Signature: sig})
+}
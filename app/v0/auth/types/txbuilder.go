@@ -26,6 +26,7 @@ type TxBuilder struct {
 	memo               string
 	fees               sdk.Coins
 	gasPrices          sdk.DecCoins
+	feeGranter         sdk.AccAddress
 }
 
 // NewTxBuilder returns a new initialized TxBuilder.
@@ -69,6 +70,7 @@ func NewTxBuilderFromCLI() TxBuilder {
 
 	txbldr = txbldr.WithFees(viper.GetString(flags.FlagFees))
 	txbldr = txbldr.WithGasPrices(viper.GetString(flags.FlagGasPrices))
+	txbldr = txbldr.WithFeeGranter(viper.GetString(flags.FlagFeeGranter))
 
 	return txbldr
 }
@@ -107,6 +109,9 @@ func (bldr TxBuilder) Fees() sdk.Coins { return bldr.fees }
 // GasPrices returns the gas prices set for the transaction, if any.
 func (bldr TxBuilder) GasPrices() sdk.DecCoins { return bldr.gasPrices }
 
+// FeeGranter returns the address paying the fee of the transaction, if any.
+func (bldr TxBuilder) FeeGranter() sdk.AccAddress { return bldr.feeGranter }
+
 // WithTxEncoder returns a copy of the context with an updated codec.
 func (bldr TxBuilder) WithTxEncoder(txEncoder sdk.TxEncoder) TxBuilder {
 	bldr.txEncoder = txEncoder
@@ -147,6 +152,22 @@ func (bldr TxBuilder) WithGasPrices(gasPrices string) TxBuilder {
 	return bldr
 }
 
+// WithFeeGranter returns a copy of the context with an updated fee granter.
+func (bldr TxBuilder) WithFeeGranter(feeGranter string) TxBuilder {
+	if feeGranter == "" {
+		bldr.feeGranter = nil
+		return bldr
+	}
+
+	granter, err := sdk.AccAddressFromBech32(feeGranter)
+	if err != nil {
+		panic(err)
+	}
+
+	bldr.feeGranter = granter
+	return bldr
+}
+
 // WithKeybase returns a copy of the context with updated keybase.
 func (bldr TxBuilder) WithKeybase(keybase crkeys.Keybase) TxBuilder {
 	bldr.keybase = keybase
@@ -203,6 +224,7 @@ func (bldr TxBuilder) BuildSignMsg(msgs []sdk.Msg) (StdSignMsg, error) {
 		Memo:          bldr.memo,
 		Msgs:          msgs,
 		Fee:           NewStdFee(bldr.gas, fees),
+		FeeGranter:    bldr.feeGranter,
 	}, nil
 }
 
@@ -214,7 +236,9 @@ func (bldr TxBuilder) Sign(name, passphrase string, msg StdSignMsg) ([]byte, err
 		return nil, err
 	}
 
-	return bldr.txEncoder(NewStdTx(msg.Msgs, msg.Fee, []StdSignature{sig}, msg.Memo))
+	stdTx := NewStdTx(msg.Msgs, msg.Fee, []StdSignature{sig}, msg.Memo)
+	stdTx.FeeGranter = msg.FeeGranter
+	return bldr.txEncoder(stdTx)
 }
 
 // BuildAndSign builds a single message to be signed, and signs a transaction
@@ -238,7 +262,9 @@ func (bldr TxBuilder) BuildTxForSim(msgs []sdk.Msg) ([]byte, error) {
 
 	// the ante handler will populate with a sentinel pubkey
 	sigs := []StdSignature{{}}
-	return bldr.txEncoder(NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo))
+	stdTx := NewStdTx(signMsg.Msgs, signMsg.Fee, sigs, signMsg.Memo)
+	stdTx.FeeGranter = signMsg.FeeGranter
+	return bldr.txEncoder(stdTx)
 }
 
 // SignStdTx appends a signature to a StdTx and returns a copy of it. If append
@@ -255,6 +281,7 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx StdTx, appendSig
 		Fee:           stdTx.Fee,
 		Msgs:          stdTx.GetMsgs(),
 		Memo:          stdTx.GetMemo(),
+		FeeGranter:    stdTx.FeeGranter,
 	})
 	if err != nil {
 		return
@@ -267,6 +294,7 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx StdTx, appendSig
 		sigs = append(sigs, stdSignature)
 	}
 	signedStdTx = NewStdTx(stdTx.GetMsgs(), stdTx.Fee, sigs, stdTx.GetMemo())
+	signedStdTx.FeeGranter = stdTx.FeeGranter
 	return
 }
 
@@ -0,0 +1,55 @@
+package authz
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/authz/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+)
+
+const (
+	ModuleName   = types.ModuleName
+	StoreKey     = types.StoreKey
+	RouterKey    = types.RouterKey
+	QuerierRoute = types.QuerierRoute
+)
+
+var (
+	RegisterCodec            = types.RegisterCodec
+	NewKeeper                = keeper.NewKeeper
+	NewQuerier               = keeper.NewQuerier
+	NewGenericAuthorization  = types.NewGenericAuthorization
+	NewSendAuthorization     = types.NewSendAuthorization
+	NewContractAuthorization = types.NewContractAuthorization
+	NewGrant                 = types.NewGrant
+	NewGrantAuthorization    = types.NewGrantAuthorization
+	NewMsgGrant              = types.NewMsgGrant
+	NewMsgRevoke             = types.NewMsgRevoke
+	NewMsgExec               = types.NewMsgExec
+	NewGenesisState          = types.NewGenesisState
+	DefaultGenesisState      = types.DefaultGenesisState
+	ValidateGenesis          = types.ValidateGenesis
+	MsgTypeURL               = types.MsgTypeURL
+	ValidateExecutable       = types.ValidateExecutable
+	ModuleCdc                = types.ModuleCdc
+	AttributeValueCategory   = types.AttributeValueCategory
+	ErrNoAuthorization       = types.ErrNoAuthorization
+	ErrAuthorizationExpired  = types.ErrAuthorizationExpired
+	ErrInvalidAuthorization  = types.ErrInvalidAuthorization
+	ErrMsgNotAuthorized      = types.ErrMsgNotAuthorized
+	ErrSpendLimitExceeded    = types.ErrSpendLimitExceeded
+	ErrInvalidExpiration     = types.ErrInvalidExpiration
+)
+
+type (
+	Keeper                = keeper.Keeper
+	GenesisState          = types.GenesisState
+	Authorization         = types.Authorization
+	GenericAuthorization  = types.GenericAuthorization
+	SendAuthorization     = types.SendAuthorization
+	ContractAuthorization = types.ContractAuthorization
+	Grant                 = types.Grant
+	GrantAuthorization    = types.GrantAuthorization
+	GrantAuthorizations   = types.GrantAuthorizations
+	MsgGrant              = types.MsgGrant
+	MsgRevoke             = types.MsgRevoke
+	MsgExec               = types.MsgExec
+)
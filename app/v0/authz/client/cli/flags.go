@@ -0,0 +1,8 @@
+package cli
+
+const (
+	flagSpendLimit = "spend-limit"
+	flagExpiration = "expiration"
+	flagContracts  = "contracts"
+	flagMsgType    = "msg-type"
+)
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+// GetQueryCmd returns the root query command for the authz module.
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	authzQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for authorizations",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	authzQueryCmd.AddCommand(client.GetCommands(
+		GetCmdQueryGrants(cdc),
+		GetCmdQueryGranterGrants(cdc),
+	)...)
+
+	return authzQueryCmd
+}
+
+// GetCmdQueryGrants returns the command querying the authorizations granted by a granter to a grantee
+func GetCmdQueryGrants(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants [granter] [grantee]",
+		Short: "Query the authorizations granted by a granter to a grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the authorizations granted by a granter to a grantee, restricted to a msg type with --msg-type.
+Example:
+$ %s query authz grants nch1... nch1...
+$ %s query authz grants nch1... nch1... --msg-type=bank/send
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryGrantsParams(granter, grantee, viper.GetString(flagMsgType)))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryGrants), bz)
+			if err != nil {
+				return err
+			}
+
+			var grants types.GrantAuthorizations
+			cdc.MustUnmarshalJSON(res, &grants)
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+
+	cmd.Flags().String(flagMsgType, "", "Msg type of the authorization, as <route>/<type>")
+
+	return cmd
+}
+
+// GetCmdQueryGranterGrants returns the command querying all the authorizations granted by a granter
+func GetCmdQueryGranterGrants(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "granter-grants [granter]",
+		Short: "Query all the authorizations granted by a granter",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the authorizations granted by a granter.
+Example:
+$ %s query authz granter-grants nch1...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granter, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryGranterGrantsParams(granter))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryGranterGrants), bz)
+			if err != nil {
+				return err
+			}
+
+			var grants types.GrantAuthorizations
+			cdc.MustUnmarshalJSON(res, &grants)
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
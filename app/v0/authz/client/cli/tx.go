@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+const (
+	authorizationGeneric  = "generic"
+	authorizationSend     = "send"
+	authorizationContract = "contract"
+)
+
+// GetTxCmd returns the transaction commands for the authz module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Authorization transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdGrant(cdc),
+		GetCmdRevoke(cdc),
+		GetCmdExec(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdGrant returns the command granting an authorization
+func GetCmdGrant(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [generic|send|contract]",
+		Short: "Grant an authorization to execute msgs on behalf of the sender",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Grant an authorization to the grantee to execute msgs on behalf of the sender:
+  generic:  any msg of --msg-type, as <route>/<type>
+  send:     bank sends of up to --spend-limit in total
+  contract: calls of the --contracts, sending up to --spend-limit in total to them
+The authorization expires at --expiration, it never expires if empty.
+
+Example:
+$ %s tx authz grant nch1... generic --msg-type=ipal/ipalNodeClaim --from=<key-name>
+$ %s tx authz grant nch1... send --spend-limit=1000000pnch --expiration=2021-01-01T00:00:00Z --from=<key-name>
+$ %s tx authz grant nch1... contract --contracts=nch1...,nch1... --spend-limit=1000pnch --from=<key-name>
+`,
+				version.ClientName, version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			authorization, err := authorizationFromFlags(args[1])
+			if err != nil {
+				return err
+			}
+
+			var expiration time.Time
+			if s := viper.GetString(flagExpiration); s != "" {
+				expiration, err = time.Parse(time.RFC3339, s)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgGrant(cliCtx.GetFromAddress(), grantee, authorization, expiration)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagMsgType, "", "Msg type of a generic authorization, as <route>/<type>, e.g. distribution/withdraw_delegator_reward")
+	cmd.Flags().String(flagSpendLimit, "", "Total amount the grantee can spend")
+	cmd.Flags().StringSlice(flagContracts, nil, "Contracts the grantee can call")
+	cmd.Flags().String(flagExpiration, "", "RFC3339 time the authorization expires at, never if empty")
+
+	return cmd
+}
+
+func authorizationFromFlags(kind string) (types.Authorization, error) {
+	spendLimit, err := sdk.ParseCoins(viper.GetString(flagSpendLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case authorizationGeneric:
+		return types.NewGenericAuthorization(viper.GetString(flagMsgType)), nil
+	case authorizationSend:
+		return types.NewSendAuthorization(spendLimit), nil
+	case authorizationContract:
+		var contracts []sdk.AccAddress
+		for _, s := range viper.GetStringSlice(flagContracts) {
+			contract, err := sdk.AccAddressFromBech32(s)
+			if err != nil {
+				return nil, err
+			}
+			contracts = append(contracts, contract)
+		}
+		return types.NewContractAuthorization(contracts, spendLimit), nil
+	default:
+		return nil, fmt.Errorf("unknown authorization %q, expected %s, %s or %s",
+			kind, authorizationGeneric, authorizationSend, authorizationContract)
+	}
+}
+
+// GetCmdRevoke returns the command revoking an authorization
+func GetCmdRevoke(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke [grantee] [msg-type]",
+		Short:   "Revoke the authorization of a msg type granted to an account",
+		Example: fmt.Sprintf("%s tx authz revoke nch1... bank/send --from=<key-name>", version.ClientName),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevoke(cliCtx.GetFromAddress(), grantee, args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdExec returns the command executing msgs on behalf of their signers
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "exec [tx-file]",
+		Short: "Execute the msgs of a tx on behalf of their signers",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Execute the msgs of an unsigned tx on behalf of their signers, using the
+authorizations they granted to the sender. The tx is generated with --generate-only.
+
+Example:
+$ %s tx send <granter> nch1... 1000pnch --generate-only > tx.json
+$ %s tx authz exec tx.json --from=<key-name>
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgExec(cliCtx.GetFromAddress(), stdTx.Msgs)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
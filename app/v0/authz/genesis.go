@@ -0,0 +1,22 @@
+package authz
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// InitGenesis stores the authorizations of the genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, grant := range data.Authorizations {
+		keeper.SaveGrant(ctx, grant.Granter, grant.Grantee, NewGrant(grant.Authorization, grant.Expiration))
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	grants := GrantAuthorizations{}
+	keeper.IterateAllGrants(ctx, func(grant GrantAuthorization) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return NewGenesisState(grants)
+}
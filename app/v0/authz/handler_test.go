@@ -0,0 +1,62 @@
+package authz
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	h := NewHandler(Keeper{})
+
+	res, err := h(sdk.NewContext(nil, abci.Header{}, false, nil), sdk.NewTestMsg())
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.True(t, strings.Contains(err.Error(), "unrecognized authz message type"))
+}
+
+func TestHandleGrantExecAndRevoke(t *testing.T) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(StoreKey)
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	router := protocol.NewRouter()
+	router.AddRoute(bank.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		return &sdk.Result{}, nil
+	})
+	k := NewKeeper(key, ModuleCdc, router)
+	h := NewHandler(k)
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	send := bank.NewMsgSend(granter, grantee, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 1)))
+
+	_, err := h(ctx, NewMsgGrant(granter, grantee, NewGenericAuthorization("bank/send"), ctx.BlockHeader().Time))
+	require.True(t, ErrInvalidExpiration.Is(err))
+
+	_, err = h(ctx, NewMsgGrant(granter, grantee, NewGenericAuthorization("bank/send"), ctx.BlockHeader().Time.Add(time.Hour)))
+	require.NoError(t, err)
+	require.Len(t, ExportGenesis(ctx, k).Authorizations, 1)
+
+	_, err = h(ctx, NewMsgExec(grantee, []sdk.Msg{send}))
+	require.NoError(t, err)
+
+	_, err = h(ctx, NewMsgRevoke(granter, grantee, "bank/send"))
+	require.NoError(t, err)
+	_, err = h(ctx, NewMsgRevoke(granter, grantee, "bank/send"))
+	require.True(t, ErrNoAuthorization.Is(err))
+
+	_, err = h(ctx, NewMsgExec(grantee, []sdk.Msg{send}))
+	require.True(t, ErrNoAuthorization.Is(err))
+}
@@ -0,0 +1,161 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// Keeper defines the authz store, the authorized msgs are executed through the router
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	router   sdk.Router
+}
+
+// NewKeeper creates a new authz Keeper instance
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, router sdk.Router) Keeper {
+	return Keeper{
+		storeKey: storeKey,
+		cdc:      cdc,
+		router:   router,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("modules/%s", types.ModuleName))
+}
+
+// SaveGrant stores the grant of an authorization, it replaces any authorization of the same msg
+// type granted by the granter to the grantee
+func (k Keeper) SaveGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, grant types.Grant) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryBare(grant)
+	store.Set(types.GrantKey(granter, grantee, grant.Authorization.MsgTypeURL()), bz)
+}
+
+// DeleteGrant deletes the authorization of msgType granted by the granter to the grantee
+func (k Keeper) DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GrantKey(granter, grantee, msgType)
+	if !store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+
+	store.Delete(key)
+	return nil
+}
+
+// GetGrant returns the authorization of msgType granted by the granter to the grantee
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) (grant types.Grant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GrantKey(granter, grantee, msgType))
+	if bz == nil {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+	return grant, true
+}
+
+// IterateGrants iterates over the authorizations granted by the granter to the grantee until cb returns true
+func (k Keeper) IterateGrants(ctx sdk.Context, granter, grantee sdk.AccAddress, cb func(grant types.GrantAuthorization) (stop bool)) {
+	k.iterateGrants(ctx, types.GrantsPrefix(granter, grantee), cb)
+}
+
+// IterateGranterGrants iterates over the authorizations granted by the granter until cb returns true
+func (k Keeper) IterateGranterGrants(ctx sdk.Context, granter sdk.AccAddress, cb func(grant types.GrantAuthorization) (stop bool)) {
+	k.iterateGrants(ctx, types.GrantsByGranterPrefix(granter), cb)
+}
+
+// IterateAllGrants iterates over all the authorizations until cb returns true
+func (k Keeper) IterateAllGrants(ctx sdk.Context, cb func(grant types.GrantAuthorization) (stop bool)) {
+	k.iterateGrants(ctx, types.GrantKeyPrefix, cb)
+}
+
+func (k Keeper) iterateGrants(ctx sdk.Context, prefix []byte, cb func(grant types.GrantAuthorization) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &grant)
+		granter, grantee, _ := types.SplitGrantKey(iterator.Key())
+		if cb(types.NewGrantAuthorization(granter, grantee, grant)) {
+			break
+		}
+	}
+}
+
+// DispatchActions executes the msgs on behalf of their signers, the msgs not signed by the grantee
+// must be authorized by their signer. The authorizations are updated, or deleted once used up,
+// before each msg is routed.
+func (k Keeper) DispatchActions(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) (sdk.Events, error) {
+	events := sdk.EmptyEvents()
+	for _, msg := range msgs {
+		if err := types.ValidateExecutable(msg); err != nil {
+			return nil, err
+		}
+
+		granter := msg.GetSigners()[0]
+		if !granter.Equals(grantee) {
+			if err := k.useGrant(ctx, granter, grantee, msg); err != nil {
+				return nil, err
+			}
+		}
+
+		handler := k.router.Route(ctx, msg.Route())
+		if handler == nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized authorized message route: %s", msg.Route())
+		}
+
+		res, err := handler(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+		events = events.AppendEvents(res.Events)
+	}
+
+	return events, nil
+}
+
+func (k Keeper) useGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msg sdk.Msg) error {
+	msgType := types.MsgTypeURL(msg)
+	grant, found := k.GetGrant(ctx, granter, grantee, msgType)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+
+	if grant.IsExpired(ctx.BlockHeader().Time) {
+		return sdkerrors.Wrapf(types.ErrAuthorizationExpired, "expired at %s", grant.Expiration)
+	}
+
+	remove, err := grant.Authorization.Accept(msg)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		ctx.KVStore(k.storeKey).Delete(types.GrantKey(granter, grantee, msgType))
+	} else {
+		k.SaveGrant(ctx, granter, grantee, grant)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeExec,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgTypeURL, msgType),
+		),
+	)
+
+	return nil
+}
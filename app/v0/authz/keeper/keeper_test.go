@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+var (
+	granter = sdk.AccAddress("granter_____________")
+	grantee = sdk.AccAddress("grantee_____________")
+)
+
+func setupKeeper() (sdk.Context, Keeper, *[]sdk.Msg) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(types.StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.LoadLatestVersion()
+
+	// the routed msgs are recorded instead of being executed
+	var routed []sdk.Msg
+	router := protocol.NewRouter()
+	record := func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		routed = append(routed, msg)
+		return &sdk.Result{Events: sdk.Events{sdk.NewEvent("routed")}}, nil
+	}
+	router.AddRoute(bank.RouterKey, record)
+	router.AddRoute(auth.RouterKey, record)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "test-chain-id", Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	return ctx, NewKeeper(key, types.ModuleCdc, router), &routed
+}
+
+func send(from sdk.AccAddress, amount int64) bank.MsgSend {
+	return bank.NewMsgSend(from, sdk.AccAddress("recipient___________"), sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, amount)))
+}
+
+func TestKeeperGrants(t *testing.T) {
+	ctx, k, _ := setupKeeper()
+
+	k.SaveGrant(ctx, granter, grantee, types.NewGrant(types.NewGenericAuthorization("ipal/ipalNodeClaim"), time.Time{}))
+	k.SaveGrant(ctx, granter, grantee, types.NewGrant(types.NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10))), time.Time{}))
+	k.SaveGrant(ctx, granter, granter, types.NewGrant(types.NewGenericAuthorization("bank/send"), time.Time{}))
+
+	grant, found := k.GetGrant(ctx, granter, grantee, "ipal/ipalNodeClaim")
+	require.True(t, found)
+	require.Equal(t, "ipal/ipalNodeClaim", grant.Authorization.MsgTypeURL())
+
+	var grants types.GrantAuthorizations
+	k.IterateGrants(ctx, granter, grantee, func(grant types.GrantAuthorization) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 2)
+	require.Equal(t, granter, grants[0].Granter)
+	require.Equal(t, grantee, grants[0].Grantee)
+
+	grants = nil
+	k.IterateGranterGrants(ctx, granter, func(grant types.GrantAuthorization) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 3)
+
+	require.NoError(t, k.DeleteGrant(ctx, granter, grantee, "ipal/ipalNodeClaim"))
+	require.True(t, types.ErrNoAuthorization.Is(k.DeleteGrant(ctx, granter, grantee, "ipal/ipalNodeClaim")))
+	_, found = k.GetGrant(ctx, granter, grantee, "ipal/ipalNodeClaim")
+	require.False(t, found)
+}
+
+func TestKeeperDispatchActions(t *testing.T) {
+	ctx, k, routed := setupKeeper()
+
+	// the msgs of the grantee need no authorization
+	events, err := k.DispatchActions(ctx, grantee, []sdk.Msg{send(grantee, 1)})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{send(granter, 1)})
+	require.True(t, types.ErrNoAuthorization.Is(err))
+
+	k.SaveGrant(ctx, granter, grantee, types.NewGrant(types.NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10))), time.Time{}))
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{send(granter, 4)})
+	require.NoError(t, err)
+	grant, found := k.GetGrant(ctx, granter, grantee, "bank/send")
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 6)), grant.Authorization.(*types.SendAuthorization).SpendLimit)
+
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{send(granter, 7)})
+	require.True(t, types.ErrSpendLimitExceeded.Is(err))
+
+	// a used up authorization is removed
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{send(granter, 6)})
+	require.NoError(t, err)
+	_, found = k.GetGrant(ctx, granter, grantee, "bank/send")
+	require.False(t, found)
+	require.Len(t, *routed, 3)
+
+	// an expired authorization is rejected
+	k.SaveGrant(ctx, granter, grantee, types.NewGrant(types.NewGenericAuthorization("bank/send"), ctx.BlockHeader().Time))
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{send(granter, 1)})
+	require.True(t, types.ErrAuthorizationExpired.Is(err))
+
+	// msgs without a route
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{sdk.NewTestMsg(grantee)})
+	require.Error(t, err)
+
+	// a meta tx relayed by the grantee is signed by the grantee but relays a msg of the granter
+	metaTx := auth.NewMsgMetaTx(grantee, send(granter, 1), 0, 100, nil, auth.StdSignature{Signature: []byte("sig")})
+	_, err = k.DispatchActions(ctx, grantee, []sdk.Msg{metaTx})
+	require.True(t, types.ErrMsgNotAuthorized.Is(err))
+	require.Len(t, *routed, 3)
+}
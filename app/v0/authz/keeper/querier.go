@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/authz/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case types.QueryGrants:
+			return queryGrants(ctx, req, k)
+		case types.QueryGranterGrants:
+			return queryGranterGrants(ctx, req, k)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
+		}
+	}
+}
+
+func queryGrants(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryGrantsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	grants := types.GrantAuthorizations{}
+	if params.MsgTypeURL != "" {
+		grant, found := k.GetGrant(ctx, params.Granter, params.Grantee, params.MsgTypeURL)
+		if !found {
+			return nil, sdkerrors.Wrapf(types.ErrNoAuthorization, "granter %s, grantee %s, msg type %s",
+				params.Granter, params.Grantee, params.MsgTypeURL)
+		}
+		grants = append(grants, types.NewGrantAuthorization(params.Granter, params.Grantee, grant))
+	} else {
+		k.IterateGrants(ctx, params.Granter, params.Grantee, func(grant types.GrantAuthorization) bool {
+			grants = append(grants, grant)
+			return false
+		})
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, grants)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryGranterGrants(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryGranterGrantsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	grants := types.GrantAuthorizations{}
+	k.IterateGranterGrants(ctx, params.Granter, func(grant types.GrantAuthorization) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, grants)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
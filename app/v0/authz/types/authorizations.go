@@ -0,0 +1,186 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	vmtypes "github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	_ Authorization = (*GenericAuthorization)(nil)
+	_ Authorization = (*SendAuthorization)(nil)
+	_ Authorization = (*ContractAuthorization)(nil)
+)
+
+// MsgTypeURL returns the type of a msg as <route>/<type>, authorizations are granted per msg type
+func MsgTypeURL(msg sdk.Msg) string {
+	return fmt.Sprintf("%s/%s", msg.Route(), msg.Type())
+}
+
+// Authorization is granted by a granter to let a grantee execute the msgs of MsgTypeURL signed by the granter
+type Authorization interface {
+	// MsgTypeURL returns the type of the msgs the authorization applies to
+	MsgTypeURL() string
+
+	// Accept checks the msg is authorized and deducts it from the authorization. It returns
+	// remove=true when the authorization is used up and must be deleted.
+	Accept(msg sdk.Msg) (remove bool, err error)
+
+	// ValidateBasic does a stateless validation of the authorization
+	ValidateBasic() error
+}
+
+// GenericAuthorization authorizes any msg of type Msg, given as <route>/<type>
+type GenericAuthorization struct {
+	Msg string `json:"msg" yaml:"msg"`
+}
+
+// NewGenericAuthorization creates a new GenericAuthorization instance
+func NewGenericAuthorization(msgTypeURL string) *GenericAuthorization {
+	return &GenericAuthorization{
+		Msg: msgTypeURL,
+	}
+}
+
+func (a GenericAuthorization) MsgTypeURL() string {
+	return a.Msg
+}
+
+func (a *GenericAuthorization) Accept(sdk.Msg) (bool, error) {
+	return false, nil
+}
+
+func (a GenericAuthorization) ValidateBasic() error {
+	parts := strings.Split(a.Msg, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "msg type %q is not <route>/<type>", a.Msg)
+	}
+	if parts[0] == RouterKey {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "authz msgs can not be authorized")
+	}
+	return nil
+}
+
+func (a GenericAuthorization) String() string {
+	return fmt.Sprintf(`GenericAuthorization:
+  Msg: %s`, a.Msg)
+}
+
+// SendAuthorization authorizes bank sends of up to SpendLimit in total
+type SendAuthorization struct {
+	SpendLimit sdk.Coins `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// NewSendAuthorization creates a new SendAuthorization instance
+func NewSendAuthorization(spendLimit sdk.Coins) *SendAuthorization {
+	return &SendAuthorization{
+		SpendLimit: spendLimit,
+	}
+}
+
+func (a SendAuthorization) MsgTypeURL() string {
+	return MsgTypeURL(bank.MsgSend{})
+}
+
+func (a *SendAuthorization) Accept(msg sdk.Msg) (bool, error) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return false, sdkerrors.Wrapf(ErrMsgNotAuthorized, "expected %s, got %T", a.MsgTypeURL(), msg)
+	}
+
+	left, hasNeg := a.SpendLimit.SafeSub(send.Amount)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrSpendLimitExceeded, "amount %s exceeds the spend limit %s", send.Amount, a.SpendLimit)
+	}
+	a.SpendLimit = left
+
+	return left.IsZero(), nil
+}
+
+func (a SendAuthorization) ValidateBasic() error {
+	if a.SpendLimit.Empty() || !a.SpendLimit.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "invalid spend limit: %s", a.SpendLimit)
+	}
+	return nil
+}
+
+func (a SendAuthorization) String() string {
+	return fmt.Sprintf(`SendAuthorization:
+  SpendLimit: %s`, a.SpendLimit)
+}
+
+// ContractAuthorization authorizes calls of the Contracts, sending up to SpendLimit to them in
+// total, calls can not send any amount with an empty SpendLimit
+type ContractAuthorization struct {
+	Contracts  []sdk.AccAddress `json:"contracts" yaml:"contracts"`
+	SpendLimit sdk.Coins        `json:"spend_limit" yaml:"spend_limit"`
+}
+
+// NewContractAuthorization creates a new ContractAuthorization instance
+func NewContractAuthorization(contracts []sdk.AccAddress, spendLimit sdk.Coins) *ContractAuthorization {
+	return &ContractAuthorization{
+		Contracts:  contracts,
+		SpendLimit: spendLimit,
+	}
+}
+
+func (a ContractAuthorization) MsgTypeURL() string {
+	return fmt.Sprintf("%s/%s", vmtypes.RouterKey, vmtypes.TypeMsgContractCall)
+}
+
+func (a *ContractAuthorization) Accept(msg sdk.Msg) (bool, error) {
+	call, ok := msg.(vmtypes.MsgContract)
+	if !ok || call.To.Empty() {
+		return false, sdkerrors.Wrapf(ErrMsgNotAuthorized, "expected %s, got %s", a.MsgTypeURL(), MsgTypeURL(msg))
+	}
+
+	allowed := false
+	for _, contract := range a.Contracts {
+		if contract.Equals(call.To) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false, sdkerrors.Wrapf(ErrMsgNotAuthorized, "contract %s is not allowed", call.To)
+	}
+
+	if call.Amount.IsPositive() {
+		left, hasNeg := a.SpendLimit.SafeSub(sdk.NewCoins(call.Amount))
+		if hasNeg {
+			return false, sdkerrors.Wrapf(ErrSpendLimitExceeded, "amount %s exceeds the spend limit %s", call.Amount, a.SpendLimit)
+		}
+		a.SpendLimit = left
+	}
+
+	return false, nil
+}
+
+func (a ContractAuthorization) ValidateBasic() error {
+	if len(a.Contracts) == 0 {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "no contract allowed")
+	}
+	for _, contract := range a.Contracts {
+		if contract.Empty() {
+			return sdkerrors.Wrap(ErrInvalidAuthorization, "empty contract address")
+		}
+	}
+	if !a.SpendLimit.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "invalid spend limit: %s", a.SpendLimit)
+	}
+	return nil
+}
+
+func (a ContractAuthorization) String() string {
+	contracts := make([]string, 0, len(a.Contracts))
+	for _, contract := range a.Contracts {
+		contracts = append(contracts, contract.String())
+	}
+	return fmt.Sprintf(`ContractAuthorization:
+  Contracts:  %s
+  SpendLimit: %s`, strings.Join(contracts, ", "), a.SpendLimit)
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	vmtypes "github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestGenericAuthorization(t *testing.T) {
+	require.NoError(t, NewGenericAuthorization("ipal/ipalNodeClaim").ValidateBasic())
+	require.Error(t, NewGenericAuthorization("ipalNodeClaim").ValidateBasic())
+	require.Error(t, NewGenericAuthorization("authz/exec").ValidateBasic())
+
+	remove, err := NewGenericAuthorization("bank/send").Accept(bank.MsgSend{})
+	require.NoError(t, err)
+	require.False(t, remove)
+}
+
+func TestSendAuthorization(t *testing.T) {
+	from, to := sdk.AccAddress("from________________"), sdk.AccAddress("to__________________")
+	send := func(amount int64) bank.MsgSend {
+		return bank.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, amount)))
+	}
+
+	auth := NewSendAuthorization(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100)))
+	require.NoError(t, auth.ValidateBasic())
+	require.Equal(t, MsgTypeURL(send(1)), auth.MsgTypeURL())
+	require.Error(t, NewSendAuthorization(nil).ValidateBasic())
+
+	remove, err := auth.Accept(send(40))
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 60)), auth.SpendLimit)
+
+	_, err = auth.Accept(send(61))
+	require.True(t, ErrSpendLimitExceeded.Is(err))
+
+	remove, err = auth.Accept(send(60))
+	require.NoError(t, err)
+	require.True(t, remove)
+}
+
+func TestContractAuthorization(t *testing.T) {
+	from := sdk.AccAddress("from________________")
+	contract, other := sdk.AccAddress("contract____________"), sdk.AccAddress("other_______________")
+	call := func(to sdk.AccAddress, amount int64) vmtypes.MsgContract {
+		return vmtypes.NewMsgContract(from, to, nil, sdk.NewInt64Coin(sdk.NativeTokenName, amount))
+	}
+
+	auth := NewContractAuthorization([]sdk.AccAddress{contract}, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100)))
+	require.NoError(t, auth.ValidateBasic())
+	require.Equal(t, MsgTypeURL(call(contract, 0)), auth.MsgTypeURL())
+	require.Error(t, NewContractAuthorization(nil, nil).ValidateBasic())
+
+	remove, err := auth.Accept(call(contract, 0))
+	require.NoError(t, err)
+	require.False(t, remove)
+
+	_, err = auth.Accept(call(other, 0))
+	require.True(t, ErrMsgNotAuthorized.Is(err))
+	_, err = auth.Accept(call(nil, 0))
+	require.True(t, ErrMsgNotAuthorized.Is(err))
+
+	remove, err = auth.Accept(call(contract, 100))
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.True(t, auth.SpendLimit.IsZero())
+
+	_, err = auth.Accept(call(contract, 1))
+	require.True(t, ErrSpendLimitExceeded.Is(err))
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/netcloth/netcloth-chain/codec"
+)
+
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(&GenericAuthorization{}, "nch/GenericAuthorization", nil)
+	cdc.RegisterConcrete(&SendAuthorization{}, "nch/SendAuthorization", nil)
+	cdc.RegisterConcrete(&ContractAuthorization{}, "nch/ContractAuthorization", nil)
+
+	cdc.RegisterConcrete(MsgGrant{}, "nch/MsgGrant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "nch/MsgRevoke", nil)
+	cdc.RegisterConcrete(MsgExec{}, "nch/MsgExec", nil)
+}
+
+// ModuleCdc generic sealed codec to be used throughout module
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
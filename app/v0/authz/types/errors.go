@@ -0,0 +1,14 @@
+package types
+
+import (
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	ErrNoAuthorization      = sdkerrors.New(ModuleName, 1, "authorization not found")
+	ErrAuthorizationExpired = sdkerrors.New(ModuleName, 2, "authorization expired")
+	ErrInvalidAuthorization = sdkerrors.New(ModuleName, 3, "invalid authorization")
+	ErrMsgNotAuthorized     = sdkerrors.New(ModuleName, 4, "msg not authorized")
+	ErrSpendLimitExceeded   = sdkerrors.New(ModuleName, 5, "spend limit exceeded")
+	ErrInvalidExpiration    = sdkerrors.New(ModuleName, 6, "invalid expiration")
+)
@@ -0,0 +1,15 @@
+package types
+
+const (
+	EventTypeGrant  = "grant_authorization"
+	EventTypeRevoke = "revoke_authorization"
+	EventTypeExec   = "exec_authorized"
+
+	AttributeKeyGranter    = "granter"
+	AttributeKeyGrantee    = "grantee"
+	AttributeKeyMsgTypeURL = "msg_type_url"
+)
+
+var (
+	AttributeValueCategory = ModuleName
+)
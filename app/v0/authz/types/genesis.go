@@ -0,0 +1,28 @@
+package types
+
+// GenesisState is the authz state that must be provided at genesis.
+type GenesisState struct {
+	Authorizations GrantAuthorizations `json:"authorizations" yaml:"authorizations"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(authorizations GrantAuthorizations) GenesisState {
+	return GenesisState{
+		Authorizations: authorizations,
+	}
+}
+
+// DefaultGenesisState returns a default genesis state
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis performs basic validation of the authz genesis data
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.Authorizations {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
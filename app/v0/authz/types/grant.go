@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// Grant is an authorization stored for a granter and a grantee, a zero Expiration never expires
+type Grant struct {
+	Authorization Authorization `json:"authorization" yaml:"authorization"`
+	Expiration    time.Time     `json:"expiration" yaml:"expiration"`
+}
+
+// NewGrant creates a new Grant instance
+func NewGrant(authorization Authorization, expiration time.Time) Grant {
+	return Grant{
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// IsExpired returns whether the grant is expired at the given block time
+func (g Grant) IsExpired(blockTime time.Time) bool {
+	return !g.Expiration.IsZero() && !blockTime.Before(g.Expiration)
+}
+
+func (g Grant) String() string {
+	return fmt.Sprintf(`Grant:
+  Authorization: %v
+  Expiration:    %s`, g.Authorization, g.Expiration)
+}
+
+// GrantAuthorization is an authorization granted by Granter to Grantee, as exported and queried
+type GrantAuthorization struct {
+	Granter       sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	Expiration    time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewGrantAuthorization creates a new GrantAuthorization instance
+func NewGrantAuthorization(granter, grantee sdk.AccAddress, grant Grant) GrantAuthorization {
+	return GrantAuthorization{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: grant.Authorization,
+		Expiration:    grant.Expiration,
+	}
+}
+
+// ValidateBasic does a stateless validation of the granted authorization
+func (g GrantAuthorization) ValidateBasic() error {
+	if g.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot self-grant authorization")
+	}
+	if g.Authorization == nil {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "missing authorization")
+	}
+
+	return g.Authorization.ValidateBasic()
+}
+
+func (g GrantAuthorization) String() string {
+	return fmt.Sprintf(`GrantAuthorization:
+  Granter:       %s
+  Grantee:       %s
+  Authorization: %v
+  Expiration:    %s`, g.Granter, g.Grantee, g.Authorization, g.Expiration)
+}
+
+// GrantAuthorizations is a slice of GrantAuthorization
+type GrantAuthorizations []GrantAuthorization
+
+func (g GrantAuthorizations) String() string {
+	out := make([]string, len(g))
+	for i, grant := range g {
+		out[i] = grant.String()
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	ModuleName   = protocol.AuthzModuleName
+	StoreKey     = ModuleName
+	RouterKey    = ModuleName
+	QuerierRoute = ModuleName
+)
+
+var (
+	GrantKeyPrefix = []byte{0x01}
+)
+
+// GrantKey returns the key of the authorization of msgType granted by granter to grantee
+func GrantKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(GrantsPrefix(granter, grantee), []byte(msgType)...)
+}
+
+// GrantsPrefix returns the prefix of the keys of the authorizations granted by granter to grantee
+func GrantsPrefix(granter, grantee sdk.AccAddress) []byte {
+	return append(GrantsByGranterPrefix(granter), grantee.Bytes()...)
+}
+
+// GrantsByGranterPrefix returns the prefix of the keys of the authorizations granted by granter
+func GrantsByGranterPrefix(granter sdk.AccAddress) []byte {
+	return append(GrantKeyPrefix, granter.Bytes()...)
+}
+
+// SplitGrantKey returns the granter, grantee and msg type of a grant key
+func SplitGrantKey(key []byte) (granter, grantee sdk.AccAddress, msgType string) {
+	key = key[len(GrantKeyPrefix):]
+	return key[:sdk.AddrLen], key[sdk.AddrLen : 2*sdk.AddrLen], string(key[2*sdk.AddrLen:])
+}
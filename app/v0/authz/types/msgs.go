@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	TypeMsgGrant  = "grant"
+	TypeMsgRevoke = "revoke"
+	TypeMsgExec   = "exec"
+)
+
+var (
+	_ sdk.Msg = MsgGrant{}
+	_ sdk.Msg = MsgRevoke{}
+	_ sdk.Msg = MsgExec{}
+)
+
+// MsgGrant grants an authorization to the grantee until Expiration, it replaces any existing
+// authorization of the same msg type granted by the granter to the grantee
+type MsgGrant struct {
+	Granter       sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Authorization Authorization  `json:"authorization" yaml:"authorization"`
+	Expiration    time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// NewMsgGrant creates a new MsgGrant instance
+func NewMsgGrant(granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time) MsgGrant {
+	return MsgGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrant) Type() string { return TypeMsgGrant }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrant) ValidateBasic() error {
+	return NewGrantAuthorization(msg.Granter, msg.Grantee, NewGrant(msg.Authorization, msg.Expiration)).ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrant) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevoke revokes the authorization of MsgTypeURL granted by the granter to the grantee
+type MsgRevoke struct {
+	Granter    sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgTypeURL string         `json:"msg_type_url" yaml:"msg_type_url"`
+}
+
+// NewMsgRevoke creates a new MsgRevoke instance
+func NewMsgRevoke(granter, grantee sdk.AccAddress, msgTypeURL string) MsgRevoke {
+	return MsgRevoke{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeURL: msgTypeURL,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevoke) Type() string { return TypeMsgRevoke }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevoke) ValidateBasic() error {
+	if msg.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if msg.MsgTypeURL == "" {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "missing msg type")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgExec executes Msgs on behalf of their signers, using the authorizations they granted to the
+// grantee. Msgs signed by the grantee itself need no authorization.
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs" yaml:"msgs"`
+}
+
+// NewMsgExec creates a new MsgExec instance
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgExec) Type() string { return TypeMsgExec }
+
+// ValidateBasic Implements Msg.
+func (msg MsgExec) ValidateBasic() error {
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if len(msg.Msgs) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no msg to execute")
+	}
+	for _, m := range msg.Msgs {
+		if m == nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing msg to execute")
+		}
+		if err := ValidateExecutable(m); err != nil {
+			return err
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+		if len(m.GetSigners()) != 1 {
+			return sdkerrors.Wrapf(ErrMsgNotAuthorized, "%s must have a single signer", MsgTypeURL(m))
+		}
+	}
+	return nil
+}
+
+// ValidateExecutable rejects the msgs which can not be executed on behalf of another account: the
+// authz msgs, and the meta txs, whose relayed msg is authorised by the auth handler through the
+// signature of its own signer, so that the grant of the meta tx would not cover the relayed msg
+func ValidateExecutable(m sdk.Msg) error {
+	switch m.Route() {
+	case RouterKey:
+		return sdkerrors.Wrap(ErrMsgNotAuthorized, "authz msgs can not be executed on behalf of another account")
+	case auth.RouterKey:
+		return sdkerrors.Wrapf(ErrMsgNotAuthorized, "%s relays a msg authorised by the signature of its signer and can not be executed on behalf of another account", MsgTypeURL(m))
+	}
+	return nil
+}
+
+type execSignDoc struct {
+	Grantee sdk.AccAddress    `json:"grantee"`
+	Msgs    []json.RawMessage `json:"msgs"`
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	msgs := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgs[i] = m.GetSignBytes()
+	}
+	bz := ModuleCdc.MustMarshalJSON(execSignDoc{
+		Grantee: msg.Grantee,
+		Msgs:    msgs,
+	})
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
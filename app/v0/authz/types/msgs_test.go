@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMsgGrantValidateBasic(t *testing.T) {
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	generic := NewGenericAuthorization("ipal/ipalNodeClaim")
+
+	require.NoError(t, NewMsgGrant(granter, grantee, generic, time.Time{}).ValidateBasic())
+	require.Error(t, NewMsgGrant(nil, grantee, generic, time.Time{}).ValidateBasic())
+	require.Error(t, NewMsgGrant(granter, nil, generic, time.Time{}).ValidateBasic())
+	require.Error(t, NewMsgGrant(granter, granter, generic, time.Time{}).ValidateBasic())
+	require.Error(t, NewMsgGrant(granter, grantee, nil, time.Time{}).ValidateBasic())
+	require.Error(t, NewMsgGrant(granter, grantee, NewGenericAuthorization(""), time.Time{}).ValidateBasic())
+}
+
+func TestMsgRevokeValidateBasic(t *testing.T) {
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+
+	require.NoError(t, NewMsgRevoke(granter, grantee, "bank/send").ValidateBasic())
+	require.Error(t, NewMsgRevoke(nil, grantee, "bank/send").ValidateBasic())
+	require.Error(t, NewMsgRevoke(granter, nil, "bank/send").ValidateBasic())
+	require.Error(t, NewMsgRevoke(granter, grantee, "").ValidateBasic())
+}
+
+func TestMsgExecValidateBasic(t *testing.T) {
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	send := bank.NewMsgSend(granter, grantee, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 1)))
+
+	msg := NewMsgExec(grantee, []sdk.Msg{send})
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{grantee}, msg.GetSigners())
+	require.NotPanics(t, func() { msg.GetSignBytes() })
+
+	require.Error(t, NewMsgExec(nil, []sdk.Msg{send}).ValidateBasic())
+	require.Error(t, NewMsgExec(grantee, nil).ValidateBasic())
+	require.Error(t, NewMsgExec(grantee, []sdk.Msg{bank.NewMsgSend(granter, grantee, nil)}).ValidateBasic())
+	require.Error(t, NewMsgExec(grantee, []sdk.Msg{NewMsgRevoke(granter, grantee, "bank/send")}).ValidateBasic())
+
+	// a meta tx relayed by the grantee would move the funds of the granter without any grant
+	metaTx := auth.NewMsgMetaTx(grantee, send, 0, 100, nil, auth.StdSignature{Signature: []byte("sig")})
+	require.NoError(t, metaTx.ValidateBasic())
+	require.True(t, ErrMsgNotAuthorized.Is(NewMsgExec(grantee, []sdk.Msg{metaTx}).ValidateBasic()))
+}
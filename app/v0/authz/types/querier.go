@@ -0,0 +1,37 @@
+package types
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	QueryGrants        = "grants"
+	QueryGranterGrants = "granter_grants"
+)
+
+// QueryGrantsParams are the params of the query of the authorizations granted by Granter to Grantee,
+// restricted to MsgTypeURL when it is set
+type QueryGrantsParams struct {
+	Granter    sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	MsgTypeURL string         `json:"msg_type_url" yaml:"msg_type_url"`
+}
+
+func NewQueryGrantsParams(granter, grantee sdk.AccAddress, msgTypeURL string) QueryGrantsParams {
+	return QueryGrantsParams{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeURL: msgTypeURL,
+	}
+}
+
+// QueryGranterGrantsParams are the params of the query of all the authorizations granted by Granter
+type QueryGranterGrantsParams struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+}
+
+func NewQueryGranterGrantsParams(granter sdk.AccAddress) QueryGranterGrantsParams {
+	return QueryGranterGrantsParams{
+		Granter: granter,
+	}
+}
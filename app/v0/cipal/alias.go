@@ -20,21 +20,41 @@ var (
 	NewADParam                        = types.NewADParam
 	NewIPALUserRequest                = types.NewCIPALUserRequest
 	NewMsgIPALClaim                   = types.NewMsgCIPALClaim
+	NewRevokeParam                    = types.NewRevokeParam
+	NewMsgCIPALRevoke                 = types.NewMsgCIPALRevoke
+	NewKeysParam                      = types.NewKeysParam
+	NewMsgCIPALPublishKeys            = types.NewMsgCIPALPublishKeys
 	NewGenesisState                   = types.NewGenesisState
 	NewKeeper                         = keeper.NewKeeper
+	NewParams                         = types.NewParams
+	DefaultParams                     = types.DefaultParams
+	DefaultGenesisState               = types.DefaultGenesisState
+	ErrInvalidIPALServiceAddress      = types.ErrInvalidIPALServiceAddress
+	ErrInvalidServiceInfo             = types.ErrInvalidServiceInfo
 	ErrEmptyInputs                    = types.ErrEmptyInputs
 	ErrStringTooLong                  = types.ErrStringTooLong
 	ErrInvalidSignature               = types.ErrInvalidSignature
 	ErrIPALClaimUserRequestExpired    = types.ErrIPALClaimUserRequestExpired
 	ErrCIPALClaimUserRequestSigVerify = types.ErrCIPALClaimUserRequestSigVerify
+	ErrInvalidNonce                   = types.ErrInvalidNonce
+	ErrUserAddressMismatch            = types.ErrUserAddressMismatch
+	ErrCIPALObjectNotFound            = types.ErrCIPALObjectNotFound
+	ErrServiceTypeNotFound            = types.ErrServiceTypeNotFound
 	ModuleCdc                         = types.ModuleCdc
 	AttributeValueCategory            = types.AttributeValueCategory
 )
 
 type (
-	Keeper          = keeper.Keeper
-	GenesisState    = types.GenesisState
-	MsgIPALClaim    = types.MsgCIPALClaim
-	IPALUserRequest = types.CIPALUserRequest
-	ADParam         = types.ADParam
+	Keeper              = keeper.Keeper
+	GenesisState        = types.GenesisState
+	MsgIPALClaim        = types.MsgCIPALClaim
+	IPALUserRequest     = types.CIPALUserRequest
+	ADParam             = types.ADParam
+	MsgCIPALRevoke      = types.MsgCIPALRevoke
+	RevokeParam         = types.RevokeParam
+	MsgCIPALPublishKeys = types.MsgCIPALPublishKeys
+	KeysParam           = types.KeysParam
+	UserNonce           = types.UserNonce
+	Params              = types.Params
+	ServiceInfo         = types.ServiceInfo
 )
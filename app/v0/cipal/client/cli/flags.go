@@ -5,4 +5,10 @@ const (
 	flagProxy          = "proxy"
 	flagServiceAddress = "service_address"
 	flagServiceType    = "service_type"
+	flagNonce          = "nonce"
+	flagOffset         = "offset"
+	flagLimit          = "limit"
+	flagAfter          = "after"
+	flagFromHeight     = "from_height"
+	flagPublicKeys     = "public_keys"
 )
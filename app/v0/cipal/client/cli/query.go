@@ -2,14 +2,18 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
 	"github.com/netcloth/netcloth-chain/version"
 )
 
@@ -26,6 +30,12 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cipalQueryCmd.AddCommand(client.GetCommands(
 		GetCmdQueryCIPAL(queryRoute, cdc),
 		GetCmdCountCIPAL(queryRoute, cdc),
+		GetCmdQueryNonce(cdc),
+		GetCmdQueryBindings(cdc),
+		GetCmdQueryParams(cdc),
+		GetCmdQueryByService(cdc),
+		GetCmdQueryChanges(cdc),
+		GetCmdQueryKeys(cdc),
 	)...)
 
 	return cipalQueryCmd
@@ -90,3 +100,258 @@ func GetCmdQueryCIPAL(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdQueryNonce returns the command handler for query the next nonce of a user.
+func GetCmdQueryNonce(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "nonce",
+		Short: "Querying the next nonce of the requests of a user",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the nonce the next claim or revoke request of a user must carry.
+	Example:
+	$ %s query cipal nonce <user-address>
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			nonce, err := queryNonce(cliCtx, cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(nonce)
+		},
+	}
+}
+
+func queryNonce(cliCtx context.CLIContext, cdc *codec.Codec, userAddress string) (nonce types.UserNonce, err error) {
+	bz, err := cdc.MarshalJSON(types.NewQueryCIPALParams(userAddress))
+	if err != nil {
+		return nonce, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryNonce), bz)
+	if err != nil {
+		return nonce, err
+	}
+
+	err = cdc.UnmarshalJSON(res, &nonce)
+	return nonce, err
+}
+
+// GetCmdQueryBindings returns the command handler for query the users bound to an ipal node.
+func GetCmdQueryBindings(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bindings",
+		Short: "Querying the users bound to an ipal node",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the users whose ipal service types are bound to an ipal node.
+	Example:
+	$ %s query cipal bindings <operator-address>
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryBindingsParams(operator))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryBindings), bz)
+			if err != nil {
+				return err
+			}
+
+			var users types.BoundUsers
+			cdc.MustUnmarshalJSON(res, &users)
+			return cliCtx.PrintOutput(users)
+		},
+	}
+}
+
+// GetCmdQueryParams returns the command handler for query the cipal params.
+func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Querying the cipal params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryParams), nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			cdc.MustUnmarshalJSON(res, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
+// GetCmdQueryByService returns the command handler for query the cipal objects bound to a service.
+func GetCmdQueryByService(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "by-service",
+		Short: "Querying the cipal objects bound to a service",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`List the cipal objects bound to a service address, with a service type if it is set, or
+bound to a service type, ordered by user address. A page resumes after the user of --after, usually the
+last user of the previous page, and/or skips --offset objects.
+	Example:
+	$ %s query cipal by-service --service_address=<service address> --limit=100
+	$ %s query cipal by-service --service_type=1 --limit=100 --after=<user address>
+	`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := types.NewQueryByServiceParams(viper.GetString(flagServiceAddress), viper.GetUint64(flagServiceType),
+				viper.GetInt(flagOffset), viper.GetInt(flagLimit), viper.GetString(flagAfter))
+			if err := params.Validate(); err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryByService), bz)
+			if err != nil {
+				return err
+			}
+
+			var objs types.CIPALObjects
+			cdc.MustUnmarshalJSON(res, &objs)
+			return cliCtx.PrintOutput(objs)
+		},
+	}
+
+	cmd.Flags().String(flagServiceAddress, "", "service address the objects are bound to")
+	cmd.Flags().Uint64(flagServiceType, 0, "service type the objects are bound to")
+	cmd.Flags().Int(flagOffset, 0, "number of objects to skip")
+	cmd.Flags().Int(flagLimit, 0, "max number of objects to list, 0 lists all of them")
+	cmd.Flags().String(flagAfter, "", "user address to resume the listing after")
+
+	return cmd
+}
+
+// GetCmdQueryChanges returns the command handler for export the changes of the cipal objects.
+func GetCmdQueryChanges(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Exporting the changes of the cipal objects",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Export the last change of each cipal object changed from --from_height, one JSON change per
+line, fetched by pages of --limit changes out of the state of a single height. The height the state
+is synced up to is printed to stderr, the next export starts from the height after it.
+	Example:
+	$ %s query cipal changes --from_height=1000 --limit=1000
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := types.NewQueryChangesParams(viper.GetInt64(flagFromHeight), "", viper.GetInt(flagLimit))
+			if err := params.Validate(); err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryChanges)
+			for {
+				bz, err := cdc.MarshalJSON(params)
+				if err != nil {
+					return err
+				}
+
+				res, height, err := cliCtx.QueryWithData(route, bz)
+				if err != nil {
+					return err
+				}
+				// the next pages are read from the same state
+				cliCtx = cliCtx.WithHeight(height)
+
+				var changes types.CIPALChanges
+				cdc.MustUnmarshalJSON(res, &changes)
+				for _, change := range changes.Changes {
+					fmt.Println(string(cdc.MustMarshalJSON(change)))
+				}
+
+				if params.Limit == 0 || len(changes.Changes) < params.Limit {
+					fmt.Fprintf(os.Stderr, "synced up to height %d\n", changes.Height)
+					return nil
+				}
+
+				last := changes.Changes[len(changes.Changes)-1]
+				params.FromHeight, params.After = last.Height, last.UserAddress
+			}
+		},
+	}
+
+	cmd.Flags().Int64(flagFromHeight, 0, "height to export the changes from")
+	cmd.Flags().Int(flagLimit, 1000, "number of changes fetched by query, 0 fetches all of them at once")
+
+	return cmd
+}
+
+// GetCmdQueryKeys returns the command handler for query the versions of the public keys of a user.
+func GetCmdQueryKeys(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "keys",
+		Short: "Querying the current and historical messaging public keys of a user",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the versions of the messaging public keys published by a user, oldest first.
+	Example:
+	$ %s query cipal keys <user-address>
+	`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := cdc.MarshalJSON(types.NewQueryCIPALParams(args[0]))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPublicKeys), bz)
+			if err != nil {
+				return err
+			}
+
+			var sets ipaltypes.KeySets
+			if err := cdc.UnmarshalJSON(res, &sets); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(sets)
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -9,6 +10,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/client/keys"
@@ -23,6 +25,8 @@ func CIPALCmd(cdc *codec.Codec) *cobra.Command {
 	}
 	txCmd.AddCommand(
 		CIPALClaimCmd(cdc),
+		CIPALRevokeCmd(cdc),
+		CIPALPublishKeysCmd(cdc),
 	)
 	return txCmd
 }
@@ -42,29 +46,24 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 			}
 			userAddress := info.GetAddress().String()
 
+			nonce, err := getNonce(cliCtxUser, cdc, userAddress)
+			if err != nil {
+				return err
+			}
+
 			serviceAddress := viper.GetString(flagServiceAddress)
 			serviceType := viper.GetUint64(flagServiceType)
 			expiration := time.Now().UTC().AddDate(0, 0, 1)
-			adMsg := types.NewADParam(userAddress, serviceAddress, serviceType, expiration)
+			adMsg := types.NewADParam(userAddress, serviceAddress, serviceType, expiration, nonce)
 
-			// build msg
-			passphrase, err := keys.GetPassphrase(cliCtxUser.GetFromName())
-			if err != nil {
-				return err
-			}
-			// sign
-			sigBytes, pubkey, err := txBldr.Keybase().Sign(info.GetName(), passphrase, adMsg.GetSignBytes())
+			stdSig, err := signUserRequest(txBldr, info.GetName(), adMsg.GetSignBytes())
 			if err != nil {
 				return err
 			}
-			stdSig := auth.StdSignature{
-				PubKey:    pubkey,
-				Signature: sigBytes,
-			}
 
 			// build and sign the transaction, then broadcast to Tendermint
 			cliCtxProxy := context.NewCLIContextWithFrom(viper.GetString(flagProxy)).WithCodec(cdc)
-			msg := types.NewMsgCIPALClaim(cliCtxProxy.GetFromAddress(), userAddress, serviceAddress, serviceType, expiration, stdSig)
+			msg := types.NewMsgCIPALClaim(cliCtxProxy.GetFromAddress(), userAddress, serviceAddress, serviceType, expiration, nonce, stdSig)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -76,6 +75,7 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagProxy, "", "proxy account")
 	cmd.Flags().String(flagServiceAddress, "", "service address")
 	cmd.Flags().String(flagServiceType, "", "service type. 1:chatting, 2:storage...")
+	cmd.Flags().String(flagNonce, "", "nonce of the user request, queried from the chain when empty")
 
 	cmd.MarkFlagRequired(flagUser)
 	cmd.MarkFlagRequired(flagProxy)
@@ -86,3 +86,140 @@ func CIPALClaimCmd(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func CIPALRevokeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "revoke",
+		Short:   "Create and sign a CIPALRevoke tx, removing the binding of a service type, or all of them with service type 0",
+		Example: "nchcli cipal revoke --user=<user key name> --proxy=<proxy key name> --service_type=<service type>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtxUser := context.NewCLIContextWithFrom(viper.GetString(flagUser)).WithCodec(cdc)
+
+			info, err := txBldr.Keybase().Get(cliCtxUser.GetFromName())
+			if err != nil {
+				return err
+			}
+			userAddress := info.GetAddress().String()
+
+			nonce, err := getNonce(cliCtxUser, cdc, userAddress)
+			if err != nil {
+				return err
+			}
+
+			serviceType := viper.GetUint64(flagServiceType)
+			expiration := time.Now().UTC().AddDate(0, 0, 1)
+			revokeParam := types.NewRevokeParam(userAddress, serviceType, expiration, nonce)
+
+			stdSig, err := signUserRequest(txBldr, info.GetName(), revokeParam.GetSignBytes())
+			if err != nil {
+				return err
+			}
+
+			cliCtxProxy := context.NewCLIContextWithFrom(viper.GetString(flagProxy)).WithCodec(cdc)
+			msg := types.NewMsgCIPALRevoke(cliCtxProxy.GetFromAddress(), userAddress, serviceType, expiration, nonce, stdSig)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtxProxy, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagUser, "", "user account")
+	cmd.Flags().String(flagProxy, "", "proxy account")
+	cmd.Flags().String(flagServiceType, "0", "service type to revoke, 0 revokes all of them")
+	cmd.Flags().String(flagNonce, "", "nonce of the user request, queried from the chain when empty")
+
+	cmd.MarkFlagRequired(flagUser)
+	cmd.MarkFlagRequired(flagProxy)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func CIPALPublishKeysCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "publish-keys",
+		Short:   "Create and sign a CIPALPublishKeys tx, publishing the messaging public keys of a user, or unpublishing them when empty",
+		Example: "nchcli cipal publish-keys --user=<user key name> --proxy=<proxy key name> --public_keys=<type=hexKey[:hexSignature],...>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtxUser := context.NewCLIContextWithFrom(viper.GetString(flagUser)).WithCodec(cdc)
+
+			publicKeys, err := ipaltypes.PublicKeysFromString(viper.GetString(flagPublicKeys))
+			if err != nil {
+				return err
+			}
+
+			info, err := txBldr.Keybase().Get(cliCtxUser.GetFromName())
+			if err != nil {
+				return err
+			}
+			userAddress := info.GetAddress().String()
+
+			nonce, err := getNonce(cliCtxUser, cdc, userAddress)
+			if err != nil {
+				return err
+			}
+
+			expiration := time.Now().UTC().AddDate(0, 0, 1)
+			keysParam := types.NewKeysParam(userAddress, publicKeys, expiration, nonce)
+
+			stdSig, err := signUserRequest(txBldr, info.GetName(), keysParam.GetSignBytes())
+			if err != nil {
+				return err
+			}
+
+			cliCtxProxy := context.NewCLIContextWithFrom(viper.GetString(flagProxy)).WithCodec(cdc)
+			msg := types.NewMsgCIPALPublishKeys(cliCtxProxy.GetFromAddress(), userAddress, publicKeys, expiration, nonce, stdSig)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtxProxy, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagUser, "", "user account")
+	cmd.Flags().String(flagProxy, "", "proxy account")
+	cmd.Flags().String(flagPublicKeys, "", "messaging public keys to publish, in format: type=hexKey[:hexSignature],type=hexKey[:hexSignature], empty unpublishes them")
+	cmd.Flags().String(flagNonce, "", "nonce of the user request, queried from the chain when empty")
+
+	cmd.MarkFlagRequired(flagUser)
+	cmd.MarkFlagRequired(flagProxy)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// getNonce returns the nonce of the --nonce flag, or the next nonce of the user queried from the chain
+func getNonce(cliCtx context.CLIContext, cdc *codec.Codec, userAddress string) (uint64, error) {
+	if s := viper.GetString(flagNonce); s != "" {
+		return strconv.ParseUint(s, 10, 64)
+	}
+
+	nonce, err := queryNonce(cliCtx, cdc, userAddress)
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Nonce, nil
+}
+
+// signUserRequest signs the user request with the key of the user
+func signUserRequest(txBldr auth.TxBuilder, name string, signBytes []byte) (auth.StdSignature, error) {
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return auth.StdSignature{}, err
+	}
+
+	sigBytes, pubkey, err := txBldr.Keybase().Sign(name, passphrase, signBytes)
+	if err != nil {
+		return auth.StdSignature{}, err
+	}
+
+	return auth.StdSignature{
+		PubKey:    pubkey,
+		Signature: sigBytes,
+	}, nil
+}
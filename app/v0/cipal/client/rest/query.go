@@ -3,12 +3,14 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
 	"github.com/netcloth/netcloth-chain/types/rest"
 )
 
@@ -23,6 +25,36 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		CIPALCountFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/cipal/nonce/{accAddress}",
+		NonceFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/bindings/{operator}",
+		BindingsFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/params",
+		ParamsFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/by_service",
+		ByServiceFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/changes",
+		ChangesFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/cipal/keys/{accAddress}",
+		KeysFn(cliCtx),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/cipal/batch_query",
 		CIPALsFn(cliCtx),
@@ -101,6 +133,113 @@ func queryCIPALs(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	}
 }
 
+func queryBindings(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		operator, err := sdk.AccAddressFromBech32(mux.Vars(r)["operator"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryBindingsParams(operator))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+// queryWithParams returns a handler of the queries whose params are parsed out of the query args
+func queryWithParams(cliCtx context.CLIContext, endpoint string, parse func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := parse(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func parseByServiceParams(r *http.Request) (interface{}, error) {
+	var err error
+	query := r.URL.Query()
+	params := types.QueryByServiceParams{
+		ServiceAddress: query.Get("service_address"),
+		After:          query.Get("after"),
+	}
+	if s := query.Get("service_type"); s != "" {
+		if params.ServiceType, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if s := query.Get("offset"); s != "" {
+		if params.Offset, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if params.Limit, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, params.Validate()
+}
+
+func parseChangesParams(r *http.Request) (interface{}, error) {
+	var err error
+	query := r.URL.Query()
+	params := types.QueryChangesParams{
+		After: query.Get("after"),
+	}
+	if s := query.Get("from_height"); s != "" {
+		if params.FromHeight, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if params.Limit, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, params.Validate()
+}
+
 func CIPALFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryCIPAL(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCIPAL))
 }
@@ -112,3 +251,27 @@ func CIPALCountFn(cliCtx context.CLIContext) http.HandlerFunc {
 func CIPALsFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryCIPALs(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryCIPALs))
 }
+
+func NonceFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryCIPAL(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryNonce))
+}
+
+func KeysFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryCIPAL(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPublicKeys))
+}
+
+func BindingsFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryBindings(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryBindings))
+}
+
+func ParamsFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryCIPALCount(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryParams))
+}
+
+func ByServiceFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryWithParams(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryByService), parseByServiceParams)
+}
+
+func ChangesFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryWithParams(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryChanges), parseChangesParams)
+}
@@ -3,20 +3,43 @@ package cipal
 import (
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/keeper"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 // InitGenesis new cipal genesis
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState) {
+	// the params are set first so that the ipal bindings of the objects are indexed
+	keeper.SetParams(ctx, data.Params)
+
 	for _, obj := range data.CIPALObjs {
 		keeper.SetCIPALObject(ctx, obj)
 	}
+
+	for _, nonce := range data.Nonces {
+		keeper.SetNonce(ctx, nonce.UserAddress, nonce.Nonce)
+	}
+
+	for _, set := range data.KeySets {
+		keeper.SetKeySet(ctx, set)
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
 	cipals := keeper.GetAllCIPALObjects(ctx)
-	return types.GenesisState{
-		CIPALObjs: cipals,
-	}
+
+	var nonces []types.UserNonce
+	keeper.IterateNonces(ctx, func(nonce types.UserNonce) bool {
+		nonces = append(nonces, nonce)
+		return false
+	})
+
+	var keySets ipaltypes.KeySets
+	keeper.IterateAllKeySets(ctx, func(set ipaltypes.KeySet) bool {
+		keySets = append(keySets, set)
+		return false
+	})
+
+	return types.NewGenesisState(keeper.GetParams(ctx), cipals, nonces, keySets)
 }
@@ -1,9 +1,12 @@
 package cipal
 
 import (
+	"time"
+
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/keeper"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
@@ -16,20 +19,45 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgIPALClaim:
 			return handleMsgIPALClaim(ctx, k, msg)
+		case MsgCIPALRevoke:
+			return handleMsgCIPALRevoke(ctx, k, msg)
+		case MsgCIPALPublishKeys:
+			return handleMsgCIPALPublishKeys(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
 	}
 }
 
-func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Result, error) {
-	if ctx.BlockHeader().Time.After(msg.UserRequest.Params.Expiration) {
-		return nil, sdkerrors.Wrap(ErrIPALClaimUserRequestExpired, "user request expired")
+// verifyUserRequest checks that a user request is not expired, is signed by the key of the user
+// and carries the next nonce of the user, which is then used up
+func verifyUserRequest(ctx sdk.Context, k Keeper, userAddress string, expiration time.Time, nonce uint64,
+	signBytes []byte, sig auth.StdSignature) error {
+	if ctx.BlockHeader().Time.After(expiration) {
+		return sdkerrors.Wrap(ErrIPALClaimUserRequestExpired, "user request expired")
 	}
 
-	sigVerifyPass := msg.UserRequest.Sig.VerifyBytes(msg.UserRequest.Params.GetSignBytes(), msg.UserRequest.Sig.Signature)
+	sigVerifyPass := sig.VerifyBytes(signBytes, sig.Signature)
 	if !sigVerifyPass {
-		return nil, sdkerrors.Wrap(ErrCIPALClaimUserRequestSigVerify, "user signature verify failed")
+		return sdkerrors.Wrap(ErrCIPALClaimUserRequestSigVerify, "user signature verify failed")
+	}
+
+	if signer := sdk.AccAddress(sig.PubKey.Address()).String(); signer != userAddress {
+		return sdkerrors.Wrapf(ErrUserAddressMismatch, "user address: %s, signer: %s", userAddress, signer)
+	}
+
+	return k.UseNonce(ctx, userAddress, nonce)
+}
+
+func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Result, error) {
+	params := msg.UserRequest.Params
+	err := verifyUserRequest(ctx, k, params.UserAddress, params.Expiration, params.Nonce, params.GetSignBytes(), msg.UserRequest.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := k.ValidateServiceInfo(ctx, params.ServiceInfo); err != nil {
+		return nil, err
 	}
 
 	obj, found := k.GetCIPALObject(ctx, msg.UserRequest.Params.UserAddress)
@@ -68,6 +96,82 @@ func handleMsgIPALClaim(ctx sdk.Context, k Keeper, msg MsgIPALClaim) (*sdk.Resul
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgCIPALRevoke(ctx sdk.Context, k Keeper, msg MsgCIPALRevoke) (*sdk.Result, error) {
+	params := msg.RevokeRequest.Params
+	err := verifyUserRequest(ctx, k, params.UserAddress, params.Expiration, params.Nonce, params.GetSignBytes(), msg.RevokeRequest.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, found := k.GetCIPALObject(ctx, params.UserAddress)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrCIPALObjectNotFound, "user address: %s", params.UserAddress)
+	}
+
+	if params.ServiceType == 0 {
+		k.PublishKeys(ctx, &obj, nil)
+		k.DeleteCIPALObject(ctx, params.UserAddress)
+	} else {
+		serviceInfos := make([]types.ServiceInfo, 0, len(obj.ServiceInfos))
+		for _, v := range obj.ServiceInfos {
+			if v.Type != params.ServiceType {
+				serviceInfos = append(serviceInfos, v)
+			}
+		}
+		if len(serviceInfos) == len(obj.ServiceInfos) {
+			return nil, sdkerrors.Wrapf(ErrServiceTypeNotFound, "service type: %d", params.ServiceType)
+		}
+
+		if len(serviceInfos) == 0 && len(obj.PublicKeys) == 0 {
+			k.DeleteCIPALObject(ctx, params.UserAddress)
+		} else {
+			obj.ServiceInfos = serviceInfos
+			k.SetCIPALObject(ctx, obj)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCIPALPublishKeys(ctx sdk.Context, k Keeper, msg MsgCIPALPublishKeys) (*sdk.Result, error) {
+	params := msg.KeysRequest.Params
+	err := verifyUserRequest(ctx, k, params.UserAddress, params.Expiration, params.Nonce, params.GetSignBytes(), msg.KeysRequest.Sig)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, found := k.GetCIPALObject(ctx, params.UserAddress)
+	if !found {
+		if len(params.PublicKeys) == 0 {
+			return nil, sdkerrors.Wrapf(ErrCIPALObjectNotFound, "user address: %s", params.UserAddress)
+		}
+		obj = types.CIPALObject{UserAddress: params.UserAddress, ServiceInfos: []types.ServiceInfo{}}
+	}
+
+	k.PublishKeys(ctx, &obj, params.PublicKeys)
+	if len(obj.ServiceInfos) == 0 && len(obj.PublicKeys) == 0 {
+		k.DeleteCIPALObject(ctx, params.UserAddress)
+	} else {
+		k.SetCIPALObject(ctx, obj)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
 	return []abci.ValidatorUpdate{}
 }
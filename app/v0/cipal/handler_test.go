@@ -3,10 +3,21 @@ package cipal
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -19,3 +30,310 @@ func TestInvalidMsg(t *testing.T) {
 	require.NotNil(t, err)
 	require.True(t, strings.Contains(err.Error(), "unrecognized cipal message type"))
 }
+
+type mockIPALKeeper map[string]ipal.IPALNode
+
+func (m mockIPALKeeper) GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (ipal.IPALNode, bool) {
+	node, found := m[operator.String()]
+	return node, found
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	ctx, k, _ := createTestInputWithIPAL(t)
+	return ctx, k
+}
+
+func createTestInputWithIPAL(t *testing.T) (sdk.Context, Keeper, mockIPALKeeper) {
+	key := sdk.NewKVStoreKey(StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	ipalKeeper := mockIPALKeeper{}
+	k := NewKeeper(key, cdc, ipalKeeper, pk.Subspace(DefaultParamspace))
+	k.SetParams(ctx, DefaultParams())
+	return ctx, k, ipalKeeper
+}
+
+// deliver runs msg in a cached context written back on success only, as the
+// baseapp does for the msgs of a tx.
+func deliver(ctx sdk.Context, h sdk.Handler, msg sdk.Msg) error {
+	cacheCtx, write := ctx.CacheContext()
+	_, err := h(cacheCtx, msg)
+	if err == nil {
+		write()
+	}
+	return err
+}
+
+func signRequest(t *testing.T, privKey secp256k1.PrivKeySecp256k1, signBytes []byte) auth.StdSignature {
+	sig, err := privKey.Sign(signBytes)
+	require.NoError(t, err)
+	return auth.StdSignature{PubKey: privKey.PubKey(), Signature: sig}
+}
+
+func TestUserRequestReplay(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+
+	privKey := secp256k1.GenPrivKey()
+	user := sdk.AccAddress(privKey.PubKey().Address()).String()
+	relayer := sdk.AccAddress([]byte("relayer"))
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	claim := func(serviceAddress string, nonce uint64) MsgIPALClaim {
+		sig := signRequest(t, privKey, NewADParam(user, serviceAddress, 1, expiration, nonce).GetSignBytes())
+		return NewMsgIPALClaim(relayer, user, serviceAddress, 1, expiration, nonce, sig)
+	}
+
+	first := claim("service-1", 0)
+	err := deliver(ctx, h, first)
+	require.NoError(t, err)
+	err = deliver(ctx, h, claim("service-2", 1))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), k.GetNonce(ctx, user))
+
+	// the first request can not be replayed to roll the service address back
+	err = deliver(ctx, h, first)
+	require.True(t, ErrInvalidNonce.Is(err))
+	obj, _ := k.GetCIPALObject(ctx, user)
+	require.Equal(t, "service-2", obj.ServiceInfos[0].Address)
+
+	// a request signed by another key is rejected
+	otherKey := secp256k1.GenPrivKey()
+	sig := signRequest(t, otherKey, NewADParam(user, "service-3", 1, expiration, 2).GetSignBytes())
+	err = deliver(ctx, h, NewMsgIPALClaim(relayer, user, "service-3", 1, expiration, 2, sig))
+	require.True(t, ErrUserAddressMismatch.Is(err))
+}
+
+func TestRevoke(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+
+	privKey := secp256k1.GenPrivKey()
+	user := sdk.AccAddress(privKey.PubKey().Address()).String()
+	relayer := sdk.AccAddress([]byte("relayer"))
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	revoke := func(serviceType, nonce uint64) MsgCIPALRevoke {
+		sig := signRequest(t, privKey, NewRevokeParam(user, serviceType, expiration, nonce).GetSignBytes())
+		return NewMsgCIPALRevoke(relayer, user, serviceType, expiration, nonce, sig)
+	}
+
+	err := deliver(ctx, h, revoke(0, 0))
+	require.True(t, ErrCIPALObjectNotFound.Is(err))
+
+	for nonce, serviceType := range []uint64{1, 2} {
+		sig := signRequest(t, privKey, NewADParam(user, "service", serviceType, expiration, uint64(nonce)).GetSignBytes())
+		err = deliver(ctx, h, NewMsgIPALClaim(relayer, user, "service", serviceType, expiration, uint64(nonce), sig))
+		require.NoError(t, err)
+	}
+
+	err = deliver(ctx, h, revoke(3, 2))
+	require.True(t, ErrServiceTypeNotFound.Is(err))
+
+	err = deliver(ctx, h, revoke(1, 2))
+	require.NoError(t, err)
+	obj, found := k.GetCIPALObject(ctx, user)
+	require.True(t, found)
+	require.Equal(t, []types.ServiceInfo{{Type: 2, Address: "service"}}, obj.ServiceInfos)
+
+	err = deliver(ctx, h, revoke(0, 3))
+	require.NoError(t, err)
+	_, found = k.GetCIPALObject(ctx, user)
+	require.False(t, found)
+
+	// the nonce is kept once the object is deleted
+	require.Equal(t, uint64(4), k.GetNonce(ctx, user))
+}
+
+func TestIPALBinding(t *testing.T) {
+	ctx, k, ipalKeeper := createTestInputWithIPAL(t)
+	h := NewHandler(k)
+	k.SetParams(ctx, NewParams([]uint64{1}, nil))
+
+	privKey := secp256k1.GenPrivKey()
+	user := sdk.AccAddress(privKey.PubKey().Address()).String()
+	relayer := sdk.AccAddress([]byte("relayer"))
+	operator := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	claim := func(serviceAddress string, serviceType, nonce uint64) MsgIPALClaim {
+		sig := signRequest(t, privKey, NewADParam(user, serviceAddress, serviceType, expiration, nonce).GetSignBytes())
+		return NewMsgIPALClaim(relayer, user, serviceAddress, serviceType, expiration, nonce, sig)
+	}
+
+	// an ipal service type must be bound to an existing ipal node
+	err := deliver(ctx, h, claim("chat.example.com", 1, 0))
+	require.True(t, ErrInvalidIPALServiceAddress.Is(err))
+	err = deliver(ctx, h, claim(operator.String(), 1, 0))
+	require.True(t, ErrInvalidIPALServiceAddress.Is(err))
+
+	ipalKeeper[operator.String()] = ipal.IPALNode{OperatorAddress: operator}
+	require.NoError(t, deliver(ctx, h, claim(operator.String(), 1, 0)))
+	require.NoError(t, deliver(ctx, h, claim("chat.example.com", 2, 1)))
+	require.Equal(t, []string{user}, k.GetBoundUsers(ctx, operator))
+
+	// the services bound to a removed node are unbound
+	k.Hooks().AfterIPALNodeRemoved(ctx, operator)
+	obj, found := k.GetCIPALObject(ctx, user)
+	require.True(t, found)
+	require.Equal(t, []ServiceInfo{{Type: 2, Address: "chat.example.com"}}, obj.ServiceInfos)
+	require.Empty(t, k.GetBoundUsers(ctx, operator))
+
+	// rebinding to another node moves the binding
+	other := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	ipalKeeper[other.String()] = ipal.IPALNode{OperatorAddress: other}
+	require.NoError(t, deliver(ctx, h, claim(operator.String(), 1, 2)))
+	require.NoError(t, deliver(ctx, h, claim(other.String(), 1, 3)))
+	require.Empty(t, k.GetBoundUsers(ctx, operator))
+	require.Equal(t, []string{user}, k.GetBoundUsers(ctx, other))
+}
+
+func TestListByServiceAndChanges(t *testing.T) {
+	ctx, k := createTestInput(t)
+
+	ctx = ctx.WithBlockHeight(1)
+	k.SetCIPALObject(ctx, NewIPALObject("user-a", "server-1", 1))
+	k.SetCIPALObject(ctx, NewIPALObject("user-b", "server-1", 2))
+	k.SetCIPALObject(ctx, NewIPALObject("user-c", "server-10", 1))
+
+	users := func(objs types.CIPALObjects) (users []string) {
+		for _, obj := range objs {
+			users = append(users, obj.UserAddress)
+		}
+		return users
+	}
+
+	require.Equal(t, []string{"user-a", "user-b"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("server-1", 0, 0, 0, ""))))
+	require.Equal(t, []string{"user-a"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("server-1", 1, 0, 0, ""))))
+	require.Equal(t, []string{"user-a", "user-c"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("", 1, 0, 0, ""))))
+	require.Equal(t, []string{"user-c"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("", 1, 0, 1, "user-a"))))
+	require.Equal(t, []string{"user-b"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("server-1", 0, 1, 0, ""))))
+
+	// user-a moves to another server and user-b is deleted
+	ctx = ctx.WithBlockHeight(2)
+	obj, _ := k.GetCIPALObject(ctx, "user-a")
+	obj.ServiceInfos[0].Address = "server-2"
+	k.SetCIPALObject(ctx, obj)
+	k.DeleteCIPALObject(ctx, "user-b")
+
+	require.Empty(t, k.ListByService(ctx, types.NewQueryByServiceParams("server-1", 0, 0, 0, "")))
+	require.Equal(t, []string{"user-a"}, users(k.ListByService(ctx, types.NewQueryByServiceParams("server-2", 0, 0, 0, ""))))
+
+	changes := k.ListChanges(ctx, types.NewQueryChangesParams(0, "", 0))
+	require.Equal(t, int64(2), changes.Height)
+	require.Equal(t, []types.CIPALChange{
+		{Height: 1, UserAddress: "user-c", ServiceInfos: []types.ServiceInfo{{Type: 1, Address: "server-10"}}},
+		{Height: 2, UserAddress: "user-a", ServiceInfos: []types.ServiceInfo{{Type: 1, Address: "server-2"}}},
+		{Height: 2, UserAddress: "user-b", Deleted: true},
+	}, changes.Changes)
+
+	page := k.ListChanges(ctx, types.NewQueryChangesParams(2, "user-a", 1)).Changes
+	require.Len(t, page, 1)
+	require.Equal(t, "user-b", page[0].UserAddress)
+}
+
+func TestServiceTypeRegistry(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+	k.SetParams(ctx, NewParams(nil, ipal.TypeSpecs{ipal.NewTypeSpec(1, "chat", ipaltypes.AddressFormatHostPort)}))
+
+	privKey := secp256k1.GenPrivKey()
+	user := sdk.AccAddress(privKey.PubKey().Address()).String()
+	relayer := sdk.AccAddress([]byte("relayer"))
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	claim := func(serviceAddress string, serviceType, nonce uint64) MsgIPALClaim {
+		sig := signRequest(t, privKey, NewADParam(user, serviceAddress, serviceType, expiration, nonce).GetSignBytes())
+		return NewMsgIPALClaim(relayer, user, serviceAddress, serviceType, expiration, nonce, sig)
+	}
+
+	err := deliver(ctx, h, claim("chat.example.com:443", 2, 0))
+	require.True(t, ErrInvalidServiceInfo.Is(err))
+	err = deliver(ctx, h, claim("chat.example.com", 1, 0))
+	require.True(t, ErrInvalidServiceInfo.Is(err))
+	require.NoError(t, deliver(ctx, h, claim("chat.example.com:443", 1, 0)))
+
+	// the service type 0 is reserved to the revocation of all the services
+	require.True(t, ErrInvalidServiceInfo.Is(claim("chat.example.com:443", 0, 1).ValidateBasic()))
+}
+
+func TestPublishKeys(t *testing.T) {
+	ctx, k := createTestInput(t)
+	h := NewHandler(k)
+
+	privKey := secp256k1.GenPrivKey()
+	user := sdk.AccAddress(privKey.PubKey().Address()).String()
+	relayer := sdk.AccAddress([]byte("relayer"))
+	expiration := ctx.BlockHeader().Time.Add(time.Hour)
+
+	publish := func(keys ipaltypes.PublicKeys, nonce uint64) MsgCIPALPublishKeys {
+		sig := signRequest(t, privKey, NewKeysParam(user, keys, expiration, nonce).GetSignBytes())
+		return NewMsgCIPALPublishKeys(relayer, user, keys, expiration, nonce, sig)
+	}
+	identity := func(b byte) ipaltypes.PublicKeys {
+		return ipaltypes.PublicKeys{
+			ipaltypes.NewPublicKey(ipaltypes.PublicKeyTypeX25519Identity, make([]byte, 32), nil),
+			ipaltypes.NewPublicKey(ipaltypes.PublicKeyTypeX25519SignedPrekey, append(make([]byte, 31), b), []byte("sig")),
+		}
+	}
+
+	// nothing to unpublish
+	err := deliver(ctx, h, publish(nil, 0))
+	require.True(t, ErrCIPALObjectNotFound.Is(err))
+
+	// a user may publish keys without any service
+	err = deliver(ctx, h, publish(identity(1), 0))
+	require.NoError(t, err)
+	obj, found := k.GetCIPALObject(ctx, user)
+	require.True(t, found)
+	require.Empty(t, obj.ServiceInfos)
+	require.Equal(t, identity(1), obj.PublicKeys)
+
+	// rotating the prekey records a new version, publishing the same keys does not
+	err = deliver(ctx, h, publish(identity(2), 1))
+	require.NoError(t, err)
+	err = deliver(ctx, h, publish(identity(2), 2))
+	require.NoError(t, err)
+	sets := k.GetKeySets(ctx, user)
+	require.Len(t, sets, 2)
+	require.Equal(t, uint64(2), sets[1].Version)
+	require.Equal(t, identity(1), sets[0].Keys)
+	require.Equal(t, identity(2), sets[1].Keys)
+
+	tooMany := make(ipaltypes.PublicKeys, ipaltypes.MaxPublicKeys+1)
+	for i := range tooMany {
+		tooMany[i] = ipaltypes.NewPublicKey(strings.Repeat("k", i+1), []byte("key"), nil)
+	}
+	require.True(t, ipaltypes.ErrInvalidPublicKeys.Is(publish(tooMany, 3).ValidateBasic()))
+
+	// the keys survive the revocation of a service type, revoking all of them unpublishes the keys
+	sig := signRequest(t, privKey, NewADParam(user, "service", 1, expiration, 3).GetSignBytes())
+	err = deliver(ctx, h, NewMsgIPALClaim(relayer, user, "service", 1, expiration, 3, sig))
+	require.NoError(t, err)
+	sig = signRequest(t, privKey, NewRevokeParam(user, 1, expiration, 4).GetSignBytes())
+	err = deliver(ctx, h, NewMsgCIPALRevoke(relayer, user, 1, expiration, 4, sig))
+	require.NoError(t, err)
+	obj, found = k.GetCIPALObject(ctx, user)
+	require.True(t, found)
+	require.Equal(t, identity(2), obj.PublicKeys)
+
+	sig = signRequest(t, privKey, NewRevokeParam(user, 0, expiration, 5).GetSignBytes())
+	err = deliver(ctx, h, NewMsgCIPALRevoke(relayer, user, 0, expiration, 5, sig))
+	require.NoError(t, err)
+	_, found = k.GetCIPALObject(ctx, user)
+	require.False(t, found)
+	sets = k.GetKeySets(ctx, user)
+	require.Len(t, sets, 3)
+	require.Empty(t, sets[2].Keys)
+}
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// ValidateServiceInfo checks the service against the registered service types, and that the
+// address of an ipal service type is the operator address of an existing ipal node
+func (k Keeper) ValidateServiceInfo(ctx sdk.Context, info types.ServiceInfo) error {
+	params := k.GetParams(ctx)
+	if err := params.ServiceTypes.ValidateAddress(info.Type, info.Address); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidServiceInfo, err.Error())
+	}
+
+	if !params.IsIPALServiceType(info.Type) {
+		return nil
+	}
+
+	operator, err := sdk.AccAddressFromBech32(info.Address)
+	if err != nil {
+		return sdkerrors.Wrapf(types.ErrInvalidIPALServiceAddress, "service type: %d, address: %s", info.Type, info.Address)
+	}
+
+	if _, found := k.ipalKeeper.GetIPALNode(ctx, operator); !found {
+		return sdkerrors.Wrapf(types.ErrInvalidIPALServiceAddress, "ipal node %s not found", operator)
+	}
+
+	return nil
+}
+
+// ipalBindings returns the operators of the ipal nodes the ipal services of obj are bound to
+func (k Keeper) ipalBindings(ctx sdk.Context, obj types.CIPALObject) (operators []sdk.AccAddress) {
+	params := k.GetParams(ctx)
+	for _, info := range obj.ServiceInfos {
+		if !params.IsIPALServiceType(info.Type) {
+			continue
+		}
+
+		if operator, err := sdk.AccAddressFromBech32(info.Address); err == nil {
+			operators = append(operators, operator)
+		}
+	}
+	return operators
+}
+
+func (k Keeper) setIPALBindings(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, operator := range k.ipalBindings(ctx, obj) {
+		store.Set(types.GetIPALBindingKey(operator, obj.UserAddress), []byte{})
+	}
+}
+
+func (k Keeper) delIPALBindings(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, operator := range k.ipalBindings(ctx, obj) {
+		store.Delete(types.GetIPALBindingKey(operator, obj.UserAddress))
+	}
+}
+
+// GetBoundUsers returns the addresses of the users bound to the ipal node of operator
+func (k Keeper) GetBoundUsers(ctx sdk.Context, operator sdk.AccAddress) (users []string) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetIPALBindingsKey(operator)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		users = append(users, string(iterator.Key()[len(prefix):]))
+	}
+	return users
+}
+
+// unbindIPALNode removes the services bound to the ipal node of operator from the cipal objects,
+// the objects left without services are deleted
+func (k Keeper) unbindIPALNode(ctx sdk.Context, operator sdk.AccAddress) {
+	address := operator.String()
+	for _, user := range k.GetBoundUsers(ctx, operator) {
+		obj, found := k.GetCIPALObject(ctx, user)
+		if !found {
+			continue
+		}
+
+		serviceInfos := make([]types.ServiceInfo, 0, len(obj.ServiceInfos))
+		for _, info := range obj.ServiceInfos {
+			if info.Address != address {
+				serviceInfos = append(serviceInfos, info)
+				continue
+			}
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeIPALUnbind,
+					sdk.NewAttribute(types.AttributeKeyUserAddress, user),
+					sdk.NewAttribute(types.AttributeKeyServiceType, fmt.Sprintf("%d", info.Type)),
+					sdk.NewAttribute(types.AttributeKeyServiceAddress, address),
+				),
+			)
+		}
+
+		// a user whose messaging keys remain published stays without services
+		if len(serviceInfos) == 0 && len(obj.PublicKeys) == 0 {
+			k.DeleteCIPALObject(ctx, user)
+		} else {
+			obj.ServiceInfos = serviceInfos
+			k.SetCIPALObject(ctx, obj)
+		}
+
+		// the binding of a service type no longer bound to the ipal nodes is left in the index
+		ctx.KVStore(k.storeKey).Delete(types.GetIPALBindingKey(operator, user))
+	}
+}
+
+// Hooks wraps the keeper to implement the ipal hooks
+type Hooks struct {
+	k Keeper
+}
+
+var _ ipaltypes.IPALHooks = Hooks{}
+
+// Hooks returns the ipal hooks of the cipal keeper
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterIPALNodeRemoved removes the services bound to the removed node
+func (h Hooks) AfterIPALNodeRemoved(ctx sdk.Context, operator sdk.AccAddress) {
+	h.k.unbindIPALNode(ctx, operator)
+}
@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func (k Keeper) setIndexes(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, info := range obj.ServiceInfos {
+		store.Set(types.GetByServiceAddressKey(info.Address, obj.UserAddress), []byte{})
+		store.Set(types.GetByServiceTypeKey(info.Type, obj.UserAddress), []byte{})
+	}
+	k.setIPALBindings(ctx, obj)
+}
+
+func (k Keeper) delIndexes(ctx sdk.Context, obj types.CIPALObject) {
+	store := ctx.KVStore(k.storeKey)
+	for _, info := range obj.ServiceInfos {
+		store.Delete(types.GetByServiceAddressKey(info.Address, obj.UserAddress))
+		store.Delete(types.GetByServiceTypeKey(info.Type, obj.UserAddress))
+	}
+	k.delIPALBindings(ctx, obj)
+}
+
+// recordChange records the change of the cipal object of the user at the current height, only the
+// last change of each user is kept so that the change log does not outgrow the users
+func (k Keeper) recordChange(ctx sdk.Context, userAddress string) {
+	store := ctx.KVStore(k.storeKey)
+	lastKey := types.GetLastChangeKey(userAddress)
+	if bz := store.Get(lastKey); bz != nil {
+		var height int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &height)
+		store.Delete(types.GetChangeKey(height, userAddress))
+	}
+
+	height := ctx.BlockHeight()
+	store.Set(types.GetChangeKey(height, userAddress), []byte{})
+	store.Set(lastKey, k.cdc.MustMarshalBinaryLengthPrefixed(height))
+}
+
+// ListByService lists the cipal objects bound to the service of params, ordered by user address
+func (k Keeper) ListByService(ctx sdk.Context, params types.QueryByServiceParams) types.CIPALObjects {
+	var prefix []byte
+	if params.ServiceAddress != "" {
+		prefix = types.GetByServiceAddressPrefix(params.ServiceAddress)
+	} else {
+		prefix = types.GetByServiceTypePrefix(params.ServiceType)
+	}
+
+	start, end := prefix, sdk.PrefixEndBytes(prefix)
+	if params.After != "" {
+		start = append(append(sdk.CopyBytes(prefix), params.After...), 0x00)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	objs := types.CIPALObjects{}
+	skipped := 0
+	for ; iterator.Valid(); iterator.Next() {
+		obj, found := k.GetCIPALObject(ctx, string(iterator.Key()[len(prefix):]))
+		if !found || !params.Matches(obj) {
+			continue
+		}
+		if skipped < params.Offset {
+			skipped++
+			continue
+		}
+
+		objs = append(objs, obj)
+		if params.Limit > 0 && len(objs) == params.Limit {
+			break
+		}
+	}
+	return objs
+}
+
+// ListChanges lists the last changes of the cipal objects from the height of params, ordered by
+// height and user address, along with the current height
+func (k Keeper) ListChanges(ctx sdk.Context, params types.QueryChangesParams) types.CIPALChanges {
+	start := types.GetChangesPrefix(params.FromHeight)
+	if params.After != "" {
+		start = append(types.GetChangeKey(params.FromHeight, params.After), 0x00)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(start, sdk.PrefixEndBytes(types.ChangeKey))
+	defer iterator.Close()
+
+	changes := types.CIPALChanges{Height: ctx.BlockHeight(), Changes: []types.CIPALChange{}}
+	for ; iterator.Valid(); iterator.Next() {
+		height, user := types.SplitChangeKey(iterator.Key())
+		change := types.CIPALChange{Height: height, UserAddress: user}
+		if obj, found := k.GetCIPALObject(ctx, user); found {
+			change.ServiceInfos = obj.ServiceInfos
+		} else {
+			change.Deleted = true
+		}
+
+		changes.Changes = append(changes.Changes, change)
+		if params.Limit > 0 && len(changes.Changes) == params.Limit {
+			break
+		}
+	}
+	return changes
+}
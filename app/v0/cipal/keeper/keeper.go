@@ -9,18 +9,21 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/params"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 type Keeper struct {
 	storeKey   sdk.StoreKey
 	cdc        *codec.Codec
+	ipalKeeper types.IPALKeeper
 	paramstore params.Subspace
 }
 
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramstore params.Subspace) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, ipalKeeper types.IPALKeeper, paramstore params.Subspace) Keeper {
 	return Keeper{
 		storeKey:   storeKey,
 		cdc:        cdc,
+		ipalKeeper: ipalKeeper,
 		paramstore: paramstore.WithKeyTable(ParamKeyTable()),
 	}
 }
@@ -66,9 +69,75 @@ func (k Keeper) GetCIPALObjectCount(ctx sdk.Context) (count int) {
 	return count
 }
 
+// SetCIPALObject stores the cipal object, indexes it by service and records its change
 func (k Keeper) SetCIPALObject(ctx sdk.Context, obj types.CIPALObject) {
+	if old, found := k.GetCIPALObject(ctx, obj.UserAddress); found {
+		k.delIndexes(ctx, old)
+	}
+
 	store := ctx.KVStore(k.storeKey)
 	bz := types.MustMarshalCIPALObject(k.cdc, obj)
 	store.Set(types.GetCIPALObjectKey(obj.UserAddress), bz)
 	//ctx.Logger().Info(string(types.GetCIPALObjectKey(obj.UserAddress)))
+
+	k.setIndexes(ctx, obj)
+	k.recordChange(ctx, obj.UserAddress)
+}
+
+// DeleteCIPALObject deletes the cipal object of the user and records its deletion
+func (k Keeper) DeleteCIPALObject(ctx sdk.Context, userAddress string) {
+	old, found := k.GetCIPALObject(ctx, userAddress)
+	if !found {
+		return
+	}
+	k.delIndexes(ctx, old)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetCIPALObjectKey(userAddress))
+
+	k.recordChange(ctx, userAddress)
+}
+
+// GetNonce returns the nonce the next request of the user must carry, it is kept when the cipal
+// object of the user is deleted so that the revoked requests can not be replayed
+func (k Keeper) GetNonce(ctx sdk.Context, userAddress string) (nonce uint64) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetNonceKey(userAddress))
+	if value == nil {
+		return 0
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(value, &nonce)
+	return nonce
+}
+
+func (k Keeper) SetNonce(ctx sdk.Context, userAddress string, nonce uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetNonceKey(userAddress), k.cdc.MustMarshalBinaryLengthPrefixed(nonce))
+}
+
+// IterateNonces iterates over the next nonces of all the users until cb returns true
+func (k Keeper) IterateNonces(ctx sdk.Context, cb func(nonce types.UserNonce) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NonceKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var nonce uint64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &nonce)
+		if cb(types.UserNonce{UserAddress: string(iterator.Key()[len(types.NonceKey):]), Nonce: nonce}) {
+			break
+		}
+	}
+}
+
+// UseNonce checks that nonce is the next nonce of the user and increments it
+func (k Keeper) UseNonce(ctx sdk.Context, userAddress string, nonce uint64) error {
+	expected := k.GetNonce(ctx, userAddress)
+	if nonce != expected {
+		return sdkerrors.Wrapf(types.ErrInvalidNonce, "expected nonce %d, got %d", expected, nonce)
+	}
+
+	k.SetNonce(ctx, userAddress, nonce+1)
+	return nil
 }
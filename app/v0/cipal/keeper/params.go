@@ -2,13 +2,37 @@ package keeper
 
 import (
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
 	DefaultParamspace = types.ModuleName
 )
 
-func ParamKeyTable() params.KeyTable { //useless if module has no params
-	return params.NewKeyTable()
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&types.Params{})
+}
+
+// GetIPALServiceTypes returns the service types bound to the ipal nodes, none on the chains started
+// before the param was added
+func (k Keeper) GetIPALServiceTypes(ctx sdk.Context) (res []uint64) {
+	k.paramstore.GetIfExists(ctx, types.KeyIPALServiceTypes, &res)
+	return
+}
+
+// GetServiceTypes returns the registered service types, none on the chains started before the param
+// was added
+func (k Keeper) GetServiceTypes(ctx sdk.Context) (res ipaltypes.TypeSpecs) {
+	k.paramstore.GetIfExists(ctx, types.KeyServiceTypes, &res)
+	return
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	return types.NewParams(k.GetIPALServiceTypes(ctx), k.GetServiceTypes(ctx))
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramstore.SetParamSet(ctx, &params)
 }
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// GetKeySets returns the versions of the public keys published by the user, oldest first
+func (k Keeper) GetKeySets(ctx sdk.Context, userAddress string) (sets ipaltypes.KeySets) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetKeySetsKey(userAddress))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		sets = append(sets, ipaltypes.MustUnmarshalKeySet(k.cdc, iterator.Value()))
+	}
+	return sets
+}
+
+// SetKeySet stores a version of the public keys of a user
+func (k Keeper) SetKeySet(ctx sdk.Context, set ipaltypes.KeySet) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetKeySetKey(set.Owner, set.Version), ipaltypes.MustMarshalKeySet(k.cdc, set))
+}
+
+// IterateAllKeySets iterates over the versions of the public keys of all the users until cb returns true
+func (k Keeper) IterateAllKeySets(ctx sdk.Context, cb func(set ipaltypes.KeySet) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.KeySetKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(ipaltypes.MustUnmarshalKeySet(k.cdc, iterator.Value())) {
+			break
+		}
+	}
+}
+
+// lastKeyVersion returns the last version of the public keys of the user, 0 if none
+func (k Keeper) lastKeyVersion(ctx sdk.Context, userAddress string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.GetKeySetsKey(userAddress))
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return 0
+	}
+	return ipaltypes.MustUnmarshalKeySet(k.cdc, iterator.Value()).Version
+}
+
+// PublishKeys makes keys the current public keys of the user as a new version, nothing is recorded
+// when the keys are unchanged. Empty keys unpublish the current ones and are recorded as an empty
+// version, so that the history tells when the user stopped accepting encrypted messages.
+// The caller stores obj.
+func (k Keeper) PublishKeys(ctx sdk.Context, obj *types.CIPALObject, keys ipaltypes.PublicKeys) {
+	if keys.Equal(obj.PublicKeys) {
+		return
+	}
+
+	version := k.lastKeyVersion(ctx, obj.UserAddress) + 1
+	k.SetKeySet(ctx, ipaltypes.NewKeySet(obj.UserAddress, version, ctx.BlockHeight(), keys))
+	obj.PublicKeys = keys
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePublishKeys,
+			sdk.NewAttribute(types.AttributeKeyUserAddress, obj.UserAddress),
+			sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprintf("%d", version)),
+		),
+	)
+}
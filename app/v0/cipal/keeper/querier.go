@@ -4,6 +4,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/codec"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
@@ -18,6 +19,18 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryCIPALs(ctx, req, k)
 		case types.QueryCIPALCount:
 			return queryCIPALCount(ctx, req, k)
+		case types.QueryNonce:
+			return queryNonce(ctx, req, k)
+		case types.QueryBindings:
+			return queryBindings(ctx, req, k)
+		case types.QueryParams:
+			return queryParams(ctx, k)
+		case types.QueryByService:
+			return queryByService(ctx, req, k)
+		case types.QueryChanges:
+			return queryChanges(ctx, req, k)
+		case types.QueryPublicKeys:
+			return queryPublicKeys(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
 		}
@@ -88,3 +101,105 @@ func queryCIPALs(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, erro
 	}
 	return bz, nil
 }
+
+func queryNonce(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCIPALParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	nonce := types.UserNonce{UserAddress: params.AccAddr, Nonce: k.GetNonce(ctx, params.AccAddr)}
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, nonce)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryBindings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryBindingsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	users := types.BoundUsers(k.GetBoundUsers(ctx, params.Operator))
+	if users == nil {
+		users = types.BoundUsers{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, users)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, k.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryByService(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryByServiceParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, k.ListByService(ctx, params))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryChanges(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryChangesParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, k.ListChanges(ctx, params))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryPublicKeys(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryCIPALParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	sets := k.GetKeySets(ctx, params.AccAddr)
+	if sets == nil {
+		sets = ipaltypes.KeySets{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, sets)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -40,7 +40,7 @@ func (am AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
 }
 
 func (am AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return ModuleCdc.MustMarshalJSON("")
+	return ModuleCdc.MustMarshalJSON(DefaultGenesisState())
 }
 
 func (am AppModuleBasic) ValidateGenesis(value json.RawMessage) (err error) {
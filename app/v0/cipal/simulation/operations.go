@@ -36,11 +36,12 @@ func SimulateMsgCreateCIpal(ak keeper.AccountKeeper, k keeper.Keeper) simtypes.O
 		accountObj := ak.GetAccount(ctx, acc.Address)
 
 		expiration := ctx.BlockHeader().Time.AddDate(0, 0, 1)
-		adMsg := types.NewADParam(acc.Address.String(), acc.Address.String(), 1, expiration)
+		nonce := k.GetNonce(ctx, acc.Address.String())
+		adMsg := types.NewADParam(acc.Address.String(), acc.Address.String(), 1, expiration, nonce)
 		sig, _ := acc.PrivKey.Sign(adMsg.GetSignBytes())
 
 		stdSig := auth.StdSignature{PubKey: acc.PubKey, Signature: sig}
-		msg := types.NewMsgCIPALClaim(acc.Address, acc.Address.String(), acc.Address.String(), 1, expiration, stdSig)
+		msg := types.NewMsgCIPALClaim(acc.Address, acc.Address.String(), acc.Address.String(), 1, expiration, nonce, stdSig)
 
 		tx := helpers.GenTx(
 			[]sdk.Msg{msg},
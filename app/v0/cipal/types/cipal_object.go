@@ -2,16 +2,19 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/codec"
 )
 
 // CIPALObject defines the struct of cipal
 type CIPALObject struct {
-	UserAddress  string        `json:"user_address" yaml:"user_address"`
-	ServiceInfos []ServiceInfo `json:"service_infos" yaml:"service_infos"`
+	UserAddress  string               `json:"user_address" yaml:"user_address"`
+	ServiceInfos []ServiceInfo        `json:"service_infos" yaml:"service_infos"`
+	PublicKeys   ipaltypes.PublicKeys `json:"public_keys" yaml:"public_keys"` // current messaging public keys of the user
 }
 
 type CIPALObjects []CIPALObject
@@ -32,9 +35,11 @@ func (obj CIPALObject) MarshalYAML() (interface{}, error) {
 	bs, err := yaml.Marshal(struct {
 		UserAddress  string
 		ServiceInfos []ServiceInfo
+		PublicKeys   ipaltypes.PublicKeys
 	}{
 		UserAddress:  obj.UserAddress,
 		ServiceInfos: obj.ServiceInfos,
+		PublicKeys:   obj.PublicKeys,
 	})
 
 	if err != nil {
@@ -72,5 +77,13 @@ func getServiceInfosString(infos []ServiceInfo) string {
 func (obj CIPALObject) String() string {
 	return fmt.Sprintf(`CIPALObject
 User Address:			%s
-Service Infos:		    %s`, obj.UserAddress, getServiceInfosString(obj.ServiceInfos))
+Service Infos:		    %s
+Public Keys:		    %s`, obj.UserAddress, getServiceInfosString(obj.ServiceInfos), obj.PublicKeys)
+}
+
+func (objs CIPALObjects) String() (out string) {
+	for _, obj := range objs {
+		out += obj.String() + "\n"
+	}
+	return strings.TrimSpace(out)
 }
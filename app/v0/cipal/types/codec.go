@@ -6,6 +6,8 @@ import (
 
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgCIPALClaim{}, "nch/CIPALClaim", nil)
+	cdc.RegisterConcrete(MsgCIPALRevoke{}, "nch/CIPALRevoke", nil)
+	cdc.RegisterConcrete(MsgCIPALPublishKeys{}, "nch/CIPALPublishKeys", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout module
@@ -10,4 +10,10 @@ var (
 	ErrInvalidSignature               = sdkerrors.New(ModuleName, 3, "CIPAL invalid user_request signature")
 	ErrIPALClaimUserRequestExpired    = sdkerrors.New(ModuleName, 4, "CIPAL user_request time expired")
 	ErrCIPALClaimUserRequestSigVerify = sdkerrors.New(ModuleName, 5, "CIPAL user_request signature verify failed")
+	ErrInvalidNonce                   = sdkerrors.New(ModuleName, 6, "CIPAL user_request invalid nonce")
+	ErrUserAddressMismatch            = sdkerrors.New(ModuleName, 7, "CIPAL user_request not signed by the user")
+	ErrCIPALObjectNotFound            = sdkerrors.New(ModuleName, 8, "CIPAL object not found")
+	ErrServiceTypeNotFound            = sdkerrors.New(ModuleName, 9, "CIPAL service type not bound")
+	ErrInvalidIPALServiceAddress      = sdkerrors.New(ModuleName, 10, "CIPAL service address is not an ipal node")
+	ErrInvalidServiceInfo             = sdkerrors.New(ModuleName, 11, "CIPAL service type not registered or address invalid")
 )
@@ -1,5 +1,15 @@
 package types
 
+const (
+	EventTypeIPALUnbind  = "cipal_ipal_unbind"
+	EventTypePublishKeys = "cipal_publish_keys"
+
+	AttributeKeyUserAddress    = "user_address"
+	AttributeKeyServiceType    = "service_type"
+	AttributeKeyServiceAddress = "service_address"
+	AttributeKeyVersion        = "version"
+)
+
 var (
 	AttributeValueCategory = ModuleName
 )
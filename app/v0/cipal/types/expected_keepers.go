@@ -0,0 +1,11 @@
+package types
+
+import (
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// IPALKeeper is used to check that the ipal service addresses are ipal node operators
+type IPALKeeper interface {
+	GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj ipaltypes.IPALNode, found bool)
+}
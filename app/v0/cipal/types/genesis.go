@@ -1,18 +1,44 @@
 package types
 
+import (
+	"fmt"
+
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+)
+
+// UserNonce is the next nonce of the user requests of UserAddress
+type UserNonce struct {
+	UserAddress string `json:"user_address" yaml:"user_address"`
+	Nonce       uint64 `json:"nonce" yaml:"nonce"`
+}
+
+func (n UserNonce) String() string {
+	return fmt.Sprintf(`UserNonce:
+  User Address: %s
+  Nonce:        %d`, n.UserAddress, n.Nonce)
+}
+
 // GenesisState is the supply state that must be provided at genesis.
 type GenesisState struct {
-	CIPALObjs CIPALObjects `json:"cipal_objects" yaml:"cipal_objects"`
+	Params    Params            `json:"params" yaml:"params"`
+	CIPALObjs CIPALObjects      `json:"cipal_objects" yaml:"cipal_objects"`
+	Nonces    []UserNonce       `json:"nonces" yaml:"nonces"`
+	KeySets   ipaltypes.KeySets `json:"key_sets" yaml:"key_sets"`
 }
 
 // NewGenesisState creates a new genesis state.
-func NewGenesisState(objs CIPALObjects) GenesisState {
+func NewGenesisState(params Params, objs CIPALObjects, nonces []UserNonce, keySets ipaltypes.KeySets) GenesisState {
 	return GenesisState{
+		KeySets:   keySets,
+		Params:    params,
 		CIPALObjs: objs,
+		Nonces:    nonces,
 	}
 }
 
 // DefaultGenesisState returns a default genesis state
 func DefaultGenesisState() GenesisState {
-	return GenesisState{}
+	return GenesisState{
+		Params: DefaultParams(),
+	}
 }
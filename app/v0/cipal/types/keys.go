@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/binary"
+
 	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 const (
@@ -13,8 +16,87 @@ const (
 
 var (
 	CIPALObjectKey = []byte{0x11}
+	NonceKey       = []byte{0x12}
+	IPALBindingKey = []byte{0x13}
+
+	ByServiceAddressKey = []byte{0x14}
+	ByServiceTypeKey    = []byte{0x15}
+	ChangeKey           = []byte{0x16}
+	LastChangeKey       = []byte{0x17}
+	KeySetKey           = []byte{0x18}
 )
 
 func GetCIPALObjectKey(addr string) []byte {
 	return append(CIPALObjectKey, []byte(addr)...)
 }
+
+// GetNonceKey returns the key of the next nonce of the user requests of addr
+func GetNonceKey(addr string) []byte {
+	return append(NonceKey, []byte(addr)...)
+}
+
+// GetIPALBindingKey returns the key of the binding of the user to the ipal node of operator
+func GetIPALBindingKey(operator sdk.AccAddress, userAddress string) []byte {
+	return append(GetIPALBindingsKey(operator), []byte(userAddress)...)
+}
+
+// GetIPALBindingsKey returns the prefix of the bindings of the users to the ipal node of operator
+func GetIPALBindingsKey(operator sdk.AccAddress) []byte {
+	return append(sdk.CopyBytes(IPALBindingKey), operator.Bytes()...)
+}
+
+// GetByServiceAddressKey returns the key of the index of the user by the service address, the
+// address is length prefixed so that the users of an address are not mixed with its extensions
+func GetByServiceAddressKey(serviceAddress, userAddress string) []byte {
+	return append(GetByServiceAddressPrefix(serviceAddress), []byte(userAddress)...)
+}
+
+// GetByServiceAddressPrefix returns the prefix of the users bound to the service address
+func GetByServiceAddressPrefix(serviceAddress string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(serviceAddress)))
+	return append(append(sdk.CopyBytes(ByServiceAddressKey), bz...), []byte(serviceAddress)...)
+}
+
+// GetByServiceTypeKey returns the key of the index of the user by the service type
+func GetByServiceTypeKey(serviceType uint64, userAddress string) []byte {
+	return append(GetByServiceTypePrefix(serviceType), []byte(userAddress)...)
+}
+
+// GetByServiceTypePrefix returns the prefix of the users bound to the service type
+func GetByServiceTypePrefix(serviceType uint64) []byte {
+	return append(sdk.CopyBytes(ByServiceTypeKey), sdk.Uint64ToBigEndian(serviceType)...)
+}
+
+// GetChangeKey returns the key of the last change of the cipal object of the user, at height
+func GetChangeKey(height int64, userAddress string) []byte {
+	return append(GetChangesPrefix(height), []byte(userAddress)...)
+}
+
+// GetChangesPrefix returns the prefix of the changes of the cipal objects at height
+func GetChangesPrefix(height int64) []byte {
+	return append(sdk.CopyBytes(ChangeKey), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// SplitChangeKey returns the height and the user address of a change key
+func SplitChangeKey(key []byte) (height int64, userAddress string) {
+	return int64(binary.BigEndian.Uint64(key[1:9])), string(key[9:])
+}
+
+// GetLastChangeKey returns the key of the height of the last change of the cipal object of the user
+func GetLastChangeKey(userAddress string) []byte {
+	return append(sdk.CopyBytes(LastChangeKey), []byte(userAddress)...)
+}
+
+// GetKeySetKey returns the key of a version of the public keys of the user
+func GetKeySetKey(userAddress string, version uint64) []byte {
+	return append(GetKeySetsKey(userAddress), sdk.Uint64ToBigEndian(version)...)
+}
+
+// GetKeySetsKey returns the prefix of the versions of the public keys of the user, the address is
+// length prefixed so that the versions of a user are not mixed with those of its extensions
+func GetKeySetsKey(userAddress string) []byte {
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(len(userAddress)))
+	return append(append(sdk.CopyBytes(KeySetKey), bz...), []byte(userAddress)...)
+}
@@ -6,16 +6,19 @@ import (
 	"time"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
 
 const (
-	maxUserAddressLength = 256
+	maxUserAddressLength    = 256
+	maxServiceAddressLength = 256
 )
 
 var (
 	_ sdk.Msg = MsgCIPALClaim{}
+	_ sdk.Msg = MsgCIPALRevoke{}
 )
 
 // ServiceInfo defines the struct of service type and service address
@@ -24,11 +27,13 @@ type ServiceInfo struct {
 	Address string `json:"address" yaml:"address"`
 }
 
-// ADParam defines the struct of user address and service info
+// ADParam defines the struct of user address and service info, Nonce must be the next nonce of
+// the user so that a signed request can not be replayed
 type ADParam struct {
 	UserAddress string      `json:"user_address" yaml:"user_address"`
 	ServiceInfo ServiceInfo `json:"service_info" yaml:"service_info"`
 	Expiration  time.Time   `json:"expiration"`
+	Nonce       uint64      `json:"nonce" yaml:"nonce"`
 }
 
 // CIPALUserRequest defines the struct of user request for CIPAL claim
@@ -66,31 +71,40 @@ func (p ADParam) Validate() error {
 		return sdkerrors.Wrap(ErrStringTooLong, "user address too long")
 	}
 
+	if p.ServiceInfo.Type == 0 {
+		return sdkerrors.Wrap(ErrInvalidServiceInfo, "service type 0 is reserved")
+	}
+
+	if len(p.ServiceInfo.Address) > maxServiceAddressLength {
+		return sdkerrors.Wrap(ErrStringTooLong, "service address too long")
+	}
+
 	return nil
 }
 
 // NewADParam - create a new instance of ADParam
-func NewADParam(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time) ADParam {
+func NewADParam(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64) ADParam {
 	return ADParam{
 		UserAddress: userAddress,
 		ServiceInfo: ServiceInfo{Type: serviceType, Address: serviceAddress},
 		Expiration:  expiration,
+		Nonce:       nonce,
 	}
 }
 
 // NewCIPALUserRequest - create a new instance of CIPALUserRequest
-func NewCIPALUserRequest(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, sig auth.StdSignature) CIPALUserRequest {
+func NewCIPALUserRequest(userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) CIPALUserRequest {
 	return CIPALUserRequest{
-		Params: NewADParam(userAddress, serviceAddress, serviceType, expiration),
+		Params: NewADParam(userAddress, serviceAddress, serviceType, expiration, nonce),
 		Sig:    sig,
 	}
 }
 
 // NewMsgCIPALClaim - create a new instance of MsgCIPALClaim
-func NewMsgCIPALClaim(from sdk.AccAddress, userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, sig auth.StdSignature) MsgCIPALClaim {
+func NewMsgCIPALClaim(from sdk.AccAddress, userAddress string, serviceAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) MsgCIPALClaim {
 	return MsgCIPALClaim{
 		from,
-		NewCIPALUserRequest(userAddress, serviceAddress, serviceType, expiration, sig),
+		NewCIPALUserRequest(userAddress, serviceAddress, serviceType, expiration, nonce, sig),
 	}
 }
 
@@ -133,3 +147,214 @@ func (msg MsgCIPALClaim) GetSignBytes() []byte {
 func (msg MsgCIPALClaim) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.From}
 }
+
+// RevokeParam defines the struct of a user request removing the binding of ServiceType, or the
+// whole CIPAL object of the user when ServiceType is 0
+type RevokeParam struct {
+	UserAddress string    `json:"user_address" yaml:"user_address"`
+	ServiceType uint64    `json:"service_type" yaml:"service_type"`
+	Expiration  time.Time `json:"expiration"`
+	Nonce       uint64    `json:"nonce" yaml:"nonce"`
+}
+
+// CIPALRevokeRequest defines the struct of user request for CIPAL revoke
+type CIPALRevokeRequest struct {
+	Params RevokeParam       `json:"params" yaml:"params"`
+	Sig    auth.StdSignature `json:"signature" yaml:"signature"`
+}
+
+// MsgCIPALRevoke defines the transaction struct of CIPAL revoke
+type MsgCIPALRevoke struct {
+	From          sdk.AccAddress     `json:"from" yaml:"from"`
+	RevokeRequest CIPALRevokeRequest `json:"revoke_request" yaml:"revoke_request"`
+}
+
+// GetSignBytes - get the bytes for the message signer to sign on
+func (p RevokeParam) GetSignBytes() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Validate - quick validity check
+func (p RevokeParam) Validate() error {
+	if p.UserAddress == "" {
+		return sdkerrors.Wrap(ErrEmptyInputs, "user address empty")
+	}
+
+	if len(p.UserAddress) > maxUserAddressLength {
+		return sdkerrors.Wrap(ErrStringTooLong, "user address too long")
+	}
+
+	return nil
+}
+
+// NewRevokeParam - create a new instance of RevokeParam
+func NewRevokeParam(userAddress string, serviceType uint64, expiration time.Time, nonce uint64) RevokeParam {
+	return RevokeParam{
+		UserAddress: userAddress,
+		ServiceType: serviceType,
+		Expiration:  expiration,
+		Nonce:       nonce,
+	}
+}
+
+// NewMsgCIPALRevoke - create a new instance of MsgCIPALRevoke
+func NewMsgCIPALRevoke(from sdk.AccAddress, userAddress string, serviceType uint64, expiration time.Time, nonce uint64, sig auth.StdSignature) MsgCIPALRevoke {
+	return MsgCIPALRevoke{
+		From: from,
+		RevokeRequest: CIPALRevokeRequest{
+			Params: NewRevokeParam(userAddress, serviceType, expiration, nonce),
+			Sig:    sig,
+		},
+	}
+}
+
+// Route Implements Msg
+func (msg MsgCIPALRevoke) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgCIPALRevoke) Type() string { return "cipal_revoke" }
+
+// ValidateBasic Implements Msg
+func (msg MsgCIPALRevoke) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	err := msg.RevokeRequest.Params.Validate()
+	if err != nil {
+		return err
+	}
+
+	pubKey := msg.RevokeRequest.Sig.PubKey
+	signBytes := msg.RevokeRequest.Params.GetSignBytes()
+	if !pubKey.VerifyBytes(signBytes, msg.RevokeRequest.Sig.Signature) {
+		return sdkerrors.Wrap(ErrInvalidSignature, "revoke request signature invalid")
+	}
+
+	return nil
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgCIPALRevoke) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCIPALRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+var _ sdk.Msg = MsgCIPALPublishKeys{}
+
+// KeysParam defines the struct of a user request publishing the messaging public keys of the user,
+// empty PublicKeys unpublish the keys
+type KeysParam struct {
+	UserAddress string               `json:"user_address" yaml:"user_address"`
+	PublicKeys  ipaltypes.PublicKeys `json:"public_keys" yaml:"public_keys"`
+	Expiration  time.Time            `json:"expiration"`
+	Nonce       uint64               `json:"nonce" yaml:"nonce"`
+}
+
+// CIPALKeysRequest defines the struct of user request for CIPAL keys publishing
+type CIPALKeysRequest struct {
+	Params KeysParam         `json:"params" yaml:"params"`
+	Sig    auth.StdSignature `json:"signature" yaml:"signature"`
+}
+
+// MsgCIPALPublishKeys defines the transaction struct of CIPAL keys publishing
+type MsgCIPALPublishKeys struct {
+	From        sdk.AccAddress   `json:"from" yaml:"from"`
+	KeysRequest CIPALKeysRequest `json:"keys_request" yaml:"keys_request"`
+}
+
+// GetSignBytes - get the bytes for the message signer to sign on
+func (p KeysParam) GetSignBytes() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Validate - quick validity check
+func (p KeysParam) Validate() error {
+	if p.UserAddress == "" {
+		return sdkerrors.Wrap(ErrEmptyInputs, "user address empty")
+	}
+
+	if len(p.UserAddress) > maxUserAddressLength {
+		return sdkerrors.Wrap(ErrStringTooLong, "user address too long")
+	}
+
+	return p.PublicKeys.Validate()
+}
+
+// NewKeysParam - create a new instance of KeysParam
+func NewKeysParam(userAddress string, publicKeys ipaltypes.PublicKeys, expiration time.Time, nonce uint64) KeysParam {
+	return KeysParam{
+		UserAddress: userAddress,
+		PublicKeys:  publicKeys,
+		Expiration:  expiration,
+		Nonce:       nonce,
+	}
+}
+
+// NewMsgCIPALPublishKeys - create a new instance of MsgCIPALPublishKeys
+func NewMsgCIPALPublishKeys(from sdk.AccAddress, userAddress string, publicKeys ipaltypes.PublicKeys, expiration time.Time,
+	nonce uint64, sig auth.StdSignature) MsgCIPALPublishKeys {
+	return MsgCIPALPublishKeys{
+		From: from,
+		KeysRequest: CIPALKeysRequest{
+			Params: NewKeysParam(userAddress, publicKeys, expiration, nonce),
+			Sig:    sig,
+		},
+	}
+}
+
+// Route Implements Msg
+func (msg MsgCIPALPublishKeys) Route() string { return RouterKey }
+
+// Type Implements Msg
+func (msg MsgCIPALPublishKeys) Type() string { return "cipal_publish_keys" }
+
+// ValidateBasic Implements Msg
+func (msg MsgCIPALPublishKeys) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	err := msg.KeysRequest.Params.Validate()
+	if err != nil {
+		return err
+	}
+
+	pubKey := msg.KeysRequest.Sig.PubKey
+	signBytes := msg.KeysRequest.Params.GetSignBytes()
+	if !pubKey.VerifyBytes(signBytes, msg.KeysRequest.Sig.Signature) {
+		return sdkerrors.Wrap(ErrInvalidSignature, "keys request signature invalid")
+	}
+
+	return nil
+}
+
+// GetSignBytes Implements Msg
+func (msg MsgCIPALPublishKeys) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCIPALPublishKeys) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
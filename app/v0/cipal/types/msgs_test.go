@@ -22,7 +22,7 @@ func TestIPALUserRequestSig(t *testing.T) {
 	fmt.Println(expiration)
 	require.Nil(t, err)
 
-	adParam := NewADParam(userAddress, serviceAddress, serviceType, expiration)
+	adParam := NewADParam(userAddress, serviceAddress, serviceType, expiration, 0)
 	//fmt.Println(fmt.Sprintf("param: %x", adParam.GetSignBytes()))
 
 	// parse private key
@@ -44,3 +44,21 @@ func TestIPALUserRequestSig(t *testing.T) {
 	sigVerifyPass := stdSig.VerifyBytes(adParam.GetSignBytes(), stdSig.Signature)
 	require.True(t, sigVerifyPass)
 }
+
+func TestMsgCIPALRevokeValidateBasic(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	userAddress := sdk.AccAddress(privKey.PubKey().Address()).String()
+	from := sdk.AccAddress([]byte("from"))
+	expiration := time.Now().UTC()
+
+	param := NewRevokeParam(userAddress, 0, expiration, 1)
+	sig, err := privKey.Sign(param.GetSignBytes())
+	require.NoError(t, err)
+	stdSig := auth.StdSignature{PubKey: privKey.PubKey(), Signature: sig}
+
+	require.NoError(t, NewMsgCIPALRevoke(from, userAddress, 0, expiration, 1, stdSig).ValidateBasic())
+	// the nonce is part of the signed bytes
+	require.Error(t, NewMsgCIPALRevoke(from, userAddress, 0, expiration, 2, stdSig).ValidateBasic())
+	require.Error(t, NewMsgCIPALRevoke(from, "", 0, expiration, 1, stdSig).ValidateBasic())
+	require.Error(t, NewMsgCIPALRevoke(nil, userAddress, 0, expiration, 1, stdSig).ValidateBasic())
+}
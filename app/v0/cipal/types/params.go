@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+)
+
+var (
+	KeyIPALServiceTypes = []byte("IPALServiceTypes")
+	KeyServiceTypes     = []byte("ServiceTypes")
+)
+
+// Params of the cipal module. The service addresses bound to the IPALServiceTypes must be the
+// operator address of an existing ipal node, and the bindings are removed with the node. The
+// services must be of the ServiceTypes when some are registered.
+type Params struct {
+	IPALServiceTypes []uint64            `json:"ipal_service_types" yaml:"ipal_service_types"`
+	ServiceTypes     ipaltypes.TypeSpecs `json:"service_types" yaml:"service_types"`
+}
+
+var _ params.ParamSet = (*Params)(nil)
+
+func NewParams(ipalServiceTypes []uint64, serviceTypes ipaltypes.TypeSpecs) Params {
+	return Params{
+		IPALServiceTypes: ipalServiceTypes,
+		ServiceTypes:     serviceTypes,
+	}
+}
+
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyIPALServiceTypes, &p.IPALServiceTypes, validateIPALServiceTypes),
+		params.NewParamSetPair(KeyServiceTypes, &p.ServiceTypes, validateServiceTypes),
+	}
+}
+
+// DefaultParams binds no service type to the ipal nodes and registers no service type
+func DefaultParams() Params {
+	return NewParams(nil, nil)
+}
+
+// IsIPALServiceType returns whether the addresses of serviceType are ipal node operators
+func (p Params) IsIPALServiceType(serviceType uint64) bool {
+	for _, t := range p.IPALServiceTypes {
+		if t == serviceType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  IPAL Service Types   : %v
+  Service Types   : %v`,
+		p.IPALServiceTypes,
+		p.ServiceTypes)
+}
+
+func validateIPALServiceTypes(i interface{}) error {
+	v, ok := i.([]uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[uint64]bool)
+	for _, t := range v {
+		if seen[t] {
+			return fmt.Errorf("duplicate ipal service type: %d", t)
+		}
+		seen[t] = true
+	}
+
+	return nil
+}
+
+func validateServiceTypes(i interface{}) error {
+	v, ok := i.(ipaltypes.TypeSpecs)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return v.Validate()
+}
@@ -1,9 +1,22 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
 const (
 	QueryCIPAL      = "query"
 	QueryCIPALCount = "count"
 	QueryCIPALs     = "batch_query"
+	QueryNonce      = "nonce"
+	QueryBindings   = "bindings"
+	QueryParams     = "params"
+	QueryByService  = "by_service"
+	QueryChanges    = "changes"
+	QueryPublicKeys = "public_keys"
 )
 
 type QueryCIPALParams struct {
@@ -19,3 +32,120 @@ func NewQueryCIPALParams(accAddr string) QueryCIPALParams {
 		AccAddr: accAddr,
 	}
 }
+
+// QueryBindingsParams queries the users bound to the ipal node of Operator
+type QueryBindingsParams struct {
+	Operator sdk.AccAddress `json:"operator"`
+}
+
+func NewQueryBindingsParams(operator sdk.AccAddress) QueryBindingsParams {
+	return QueryBindingsParams{
+		Operator: operator,
+	}
+}
+
+// BoundUsers are the addresses of the users bound to an ipal node
+type BoundUsers []string
+
+func (u BoundUsers) String() string {
+	return strings.Join(u, "\n")
+}
+
+// QueryByServiceParams are the params of the query of the cipal objects bound to a service. The
+// objects bound to ServiceAddress are listed when it is set, only those bound with ServiceType
+// when it is set too, otherwise the objects bound to ServiceType. They are ordered by user
+// address, Offset objects are skipped and at most Limit objects are returned, Limit 0 returns all
+// of them. After is a cursor: the listing resumes after this user address, usually the last user of
+// the previous page.
+type QueryByServiceParams struct {
+	ServiceAddress string `json:"service_address"`
+	ServiceType    uint64 `json:"service_type"`
+	Offset         int    `json:"offset"`
+	Limit          int    `json:"limit"`
+	After          string `json:"after"`
+}
+
+func NewQueryByServiceParams(serviceAddress string, serviceType uint64, offset, limit int, after string) QueryByServiceParams {
+	return QueryByServiceParams{
+		ServiceAddress: serviceAddress,
+		ServiceType:    serviceType,
+		Offset:         offset,
+		Limit:          limit,
+		After:          after,
+	}
+}
+
+// Validate checks that a service is set and the pagination of the params
+func (p QueryByServiceParams) Validate() error {
+	if p.ServiceAddress == "" && p.ServiceType == 0 {
+		return fmt.Errorf("service address or service type required")
+	}
+
+	if p.Offset < 0 || p.Limit < 0 {
+		return fmt.Errorf("offset and limit must not be negative: %d, %d", p.Offset, p.Limit)
+	}
+
+	return nil
+}
+
+// Matches returns whether the object is bound to the service of the params
+func (p QueryByServiceParams) Matches(obj CIPALObject) bool {
+	for _, info := range obj.ServiceInfos {
+		if (p.ServiceAddress == "" || info.Address == p.ServiceAddress) && (p.ServiceType == 0 || info.Type == p.ServiceType) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryChangesParams are the params of the query of the last changes of the cipal objects from
+// FromHeight included, ordered by height and user address. At most Limit changes are returned,
+// Limit 0 returns all of them. After is a cursor: the listing resumes after the change of this user
+// address at FromHeight, usually the last change of the previous page.
+type QueryChangesParams struct {
+	FromHeight int64  `json:"from_height"`
+	After      string `json:"after"`
+	Limit      int    `json:"limit"`
+}
+
+func NewQueryChangesParams(fromHeight int64, after string, limit int) QueryChangesParams {
+	return QueryChangesParams{
+		FromHeight: fromHeight,
+		After:      after,
+		Limit:      limit,
+	}
+}
+
+// Validate checks the pagination of the params
+func (p QueryChangesParams) Validate() error {
+	if p.FromHeight < 0 || p.Limit < 0 {
+		return fmt.Errorf("from height and limit must not be negative: %d, %d", p.FromHeight, p.Limit)
+	}
+
+	return nil
+}
+
+// CIPALChange is the last change of the cipal object of a user, at Height. The service infos are
+// the current ones, none when the object is deleted.
+type CIPALChange struct {
+	Height       int64         `json:"height" yaml:"height"`
+	UserAddress  string        `json:"user_address" yaml:"user_address"`
+	Deleted      bool          `json:"deleted" yaml:"deleted"`
+	ServiceInfos []ServiceInfo `json:"service_infos" yaml:"service_infos"`
+}
+
+// CIPALChanges are the changes returned by the changes query, Height is the height of the query
+// state: a server synced with all of them is synced up to Height
+type CIPALChanges struct {
+	Height  int64         `json:"height" yaml:"height"`
+	Changes []CIPALChange `json:"changes" yaml:"changes"`
+}
+
+func (c CIPALChanges) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Height: %d", c.Height)
+	for _, change := range c.Changes {
+		fmt.Fprintf(&b, "\n%d %s deleted=%t %v", change.Height, change.UserAddress, change.Deleted, change.ServiceInfos)
+	}
+	return b.String()
+}
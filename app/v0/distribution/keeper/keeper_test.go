@@ -87,3 +87,19 @@ func TestGetTotalRewards(t *testing.T) {
 
 	require.Equal(t, expectedRewards, totalRewards)
 }
+
+func TestFundCommunityPool(t *testing.T) {
+	ctx, ak, keeper, _, _ := CreateTestInputDefault(t, false, 1000)
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100))
+	acc := ak.GetAccount(ctx, delAddr1)
+	require.NoError(t, acc.SetCoins(amount))
+	ak.SetAccount(ctx, acc)
+
+	initPool := keeper.GetFeePoolCommunityCoins(ctx)
+	require.NoError(t, keeper.FundCommunityPool(ctx, amount, delAddr1))
+	require.Equal(t, initPool.Add(sdk.NewDecCoins(amount)), keeper.GetFeePoolCommunityCoins(ctx))
+	require.True(t, ak.GetAccount(ctx, delAddr1).GetCoins().Empty())
+
+	require.Error(t, keeper.FundCommunityPool(ctx, amount, delAddr1))
+}
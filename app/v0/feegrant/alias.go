@@ -0,0 +1,50 @@
+package feegrant
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/types"
+)
+
+const (
+	ModuleName   = types.ModuleName
+	StoreKey     = types.StoreKey
+	RouterKey    = types.RouterKey
+	QuerierRoute = types.QuerierRoute
+)
+
+var (
+	RegisterCodec             = types.RegisterCodec
+	NewKeeper                 = keeper.NewKeeper
+	NewQuerier                = keeper.NewQuerier
+	NewBasicFeeAllowance      = types.NewBasicFeeAllowance
+	NewPeriodicFeeAllowance   = types.NewPeriodicFeeAllowance
+	NewAllowedMsgFeeAllowance = types.NewAllowedMsgFeeAllowance
+	NewFeeAllowanceGrant      = types.NewFeeAllowanceGrant
+	NewMsgGrantFeeAllowance   = types.NewMsgGrantFeeAllowance
+	NewMsgRevokeFeeAllowance  = types.NewMsgRevokeFeeAllowance
+	NewGenesisState           = types.NewGenesisState
+	DefaultGenesisState       = types.DefaultGenesisState
+	ValidateGenesis           = types.ValidateGenesis
+	MsgTypeURL                = types.MsgTypeURL
+	ModuleCdc                 = types.ModuleCdc
+	AttributeValueCategory    = types.AttributeValueCategory
+	ErrFeeLimitExceeded       = types.ErrFeeLimitExceeded
+	ErrFeeAllowanceExpired    = types.ErrFeeAllowanceExpired
+	ErrInvalidDuration        = types.ErrInvalidDuration
+	ErrNoAllowance            = types.ErrNoAllowance
+	ErrMessageNotAllowed      = types.ErrMessageNotAllowed
+	ErrInvalidAllowance       = types.ErrInvalidAllowance
+)
+
+type (
+	Keeper                 = keeper.Keeper
+	GenesisState           = types.GenesisState
+	FeeAllowance           = types.FeeAllowance
+	BasicFeeAllowance      = types.BasicFeeAllowance
+	PeriodicFeeAllowance   = types.PeriodicFeeAllowance
+	AllowedMsgFeeAllowance = types.AllowedMsgFeeAllowance
+	FeeAllowanceGrant      = types.FeeAllowanceGrant
+	FeeAllowanceGrants     = types.FeeAllowanceGrants
+	MsgGrantFeeAllowance   = types.MsgGrantFeeAllowance
+	MsgRevokeFeeAllowance  = types.MsgRevokeFeeAllowance
+)
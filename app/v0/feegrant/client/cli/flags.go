@@ -0,0 +1,9 @@
+package cli
+
+const (
+	flagSpendLimit      = "spend-limit"
+	flagExpiration      = "expiration"
+	flagPeriod          = "period"
+	flagPeriodLimit     = "period-limit"
+	flagAllowedMessages = "allowed-messages"
+)
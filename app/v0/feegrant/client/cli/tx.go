@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+// GetTxCmd returns the transaction commands for the feegrant module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Fee grant transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdGrantFeeAllowance(cdc),
+		GetCmdRevokeFeeAllowance(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdGrantFeeAllowance returns the command granting a fee allowance
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee]",
+		Short: "Grant a fee allowance to an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Grant an allowance to pay the fees of the txs of the grantee, the grantee sets
+the granter with --fee-granter to have its tx fee paid by the granter.
+A periodic allowance is granted with --period and --period-limit, the allowance can be
+restricted to some msg types with --allowed-messages.
+
+Example:
+$ %s tx feegrant grant nch1... --spend-limit=1000000pnch --expiration=2021-01-01T00:00:00Z --from=<key-name>
+$ %s tx feegrant grant nch1... --period=24h --period-limit=1000pnch --allowed-messages=bank/send,cipal/cipal_claim --from=<key-name>
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := allowanceFromFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgGrantFeeAllowance(cliCtx.GetFromAddress(), grantee, allowance)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagSpendLimit, "", "Total amount of fees the grantee can spend, unlimited if empty")
+	cmd.Flags().String(flagExpiration, "", "RFC3339 time the allowance expires at, never if empty")
+	cmd.Flags().String(flagPeriod, "", "Duration of the periods of a periodic allowance, e.g. 24h")
+	cmd.Flags().String(flagPeriodLimit, "", "Amount of fees the grantee can spend each period of a periodic allowance")
+	cmd.Flags().StringSlice(flagAllowedMessages, nil, "Msg types the allowance pays the fees of, as <route>/<type>, e.g. bank/send")
+
+	return cmd
+}
+
+func allowanceFromFlags() (types.FeeAllowance, error) {
+	spendLimit, err := sdk.ParseCoins(viper.GetString(flagSpendLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	var expiration time.Time
+	if s := viper.GetString(flagExpiration); s != "" {
+		expiration, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var allowance types.FeeAllowance = types.NewBasicFeeAllowance(spendLimit, expiration)
+
+	periodStr, periodLimitStr := viper.GetString(flagPeriod), viper.GetString(flagPeriodLimit)
+	if periodStr != "" || periodLimitStr != "" {
+		period, err := time.ParseDuration(periodStr)
+		if err != nil {
+			return nil, err
+		}
+		periodLimit, err := sdk.ParseCoins(periodLimitStr)
+		if err != nil {
+			return nil, err
+		}
+		allowance = types.NewPeriodicFeeAllowance(*types.NewBasicFeeAllowance(spendLimit, expiration), period, periodLimit)
+	}
+
+	if allowedMessages := viper.GetStringSlice(flagAllowedMessages); len(allowedMessages) != 0 {
+		allowance = types.NewAllowedMsgFeeAllowance(allowance, allowedMessages)
+	}
+
+	return allowance, nil
+}
+
+// GetCmdRevokeFeeAllowance returns the command revoking a fee allowance
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke [grantee]",
+		Short:   "Revoke the fee allowance granted to an account",
+		Example: fmt.Sprintf("%s tx feegrant revoke nch1... --from=<key-name>", version.ClientName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeFeeAllowance(cliCtx.GetFromAddress(), grantee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/feegrant/grant/{grantee}", grantHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/feegrant/revoke/{grantee}", revokeHandlerFn(cliCtx)).Methods("POST")
+}
+
+// GrantReq defines the properties of a fee allowance grant request's body, Period and
+// PeriodLimit make a periodic allowance and AllowedMessages restrict the allowance to some msg types
+type GrantReq struct {
+	BaseReq         rest.BaseReq `json:"base_req" yaml:"base_req"`
+	SpendLimit      sdk.Coins    `json:"spend_limit" yaml:"spend_limit"`
+	Expiration      time.Time    `json:"expiration" yaml:"expiration"`
+	Period          string       `json:"period" yaml:"period"`
+	PeriodLimit     sdk.Coins    `json:"period_limit" yaml:"period_limit"`
+	AllowedMessages []string     `json:"allowed_messages" yaml:"allowed_messages"`
+}
+
+// RevokeReq defines the properties of a fee allowance revoke request's body
+type RevokeReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+}
+
+func grantHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		grantee, err := sdk.AccAddressFromBech32(mux.Vars(r)["grantee"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req GrantReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		granter, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var allowance types.FeeAllowance = types.NewBasicFeeAllowance(req.SpendLimit, req.Expiration)
+		if req.Period != "" || !req.PeriodLimit.Empty() {
+			period, err := time.ParseDuration(req.Period)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			allowance = types.NewPeriodicFeeAllowance(*types.NewBasicFeeAllowance(req.SpendLimit, req.Expiration), period, req.PeriodLimit)
+		}
+		if len(req.AllowedMessages) != 0 {
+			allowance = types.NewAllowedMsgFeeAllowance(allowance, req.AllowedMessages)
+		}
+
+		msg := types.NewMsgGrantFeeAllowance(granter, grantee, allowance)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func revokeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		grantee, err := sdk.AccAddressFromBech32(mux.Vars(r)["grantee"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req RevokeReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		granter, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRevokeFeeAllowance(granter, grantee)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -0,0 +1,22 @@
+package feegrant
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// InitGenesis stores the fee allowances of the genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, grant := range data.FeeAllowances {
+		keeper.GrantFeeAllowance(ctx, grant)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	grants := FeeAllowanceGrants{}
+	keeper.IterateAllFeeAllowances(ctx, func(grant FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return NewGenesisState(grants)
+}
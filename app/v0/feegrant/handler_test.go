@@ -0,0 +1,57 @@
+package feegrant
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	h := NewHandler(Keeper{})
+
+	res, err := h(sdk.NewContext(nil, abci.Header{}, false, nil), sdk.NewTestMsg())
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.True(t, strings.Contains(err.Error(), "unrecognized feegrant message type"))
+}
+
+func TestHandleGrantAndRevoke(t *testing.T) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(StoreKey)
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	k := NewKeeper(key, ModuleCdc)
+	h := NewHandler(k)
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+
+	allowance := NewBasicFeeAllowance(sdk.NewCoins(sdk.NewInt64Coin("pnch", 100)), time.Time{})
+	_, err := h(ctx, NewMsgGrantFeeAllowance(granter, grantee, allowance))
+	require.NoError(t, err)
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	require.True(t, found)
+	require.Equal(t, allowance, grant.Allowance)
+
+	// a new grant replaces the allowance
+	_, err = h(ctx, NewMsgGrantFeeAllowance(granter, grantee, NewBasicFeeAllowance(nil, time.Time{})))
+	require.NoError(t, err)
+	grant, _ = k.GetFeeGrant(ctx, granter, grantee)
+	require.Empty(t, grant.Allowance.(*BasicFeeAllowance).SpendLimit)
+
+	require.Len(t, ExportGenesis(ctx, k).FeeAllowances, 1)
+
+	_, err = h(ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	require.NoError(t, err)
+	_, err = h(ctx, NewMsgRevokeFeeAllowance(granter, grantee))
+	require.True(t, ErrNoAllowance.Is(err))
+}
@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+	return Keeper{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("modules/%s", types.ModuleName))
+}
+
+// GrantFeeAllowance stores the grant, it replaces any allowance granted by the granter to the grantee.
+// The grants with an expiration are queued to be pruned once expired.
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, grant types.FeeAllowanceGrant) {
+	if old, found := k.GetFeeGrant(ctx, grant.Granter, grant.Grantee); found {
+		k.deleteFeeAllowance(ctx, old)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	key := types.FeeAllowanceKey(grant.Granter, grant.Grantee)
+	bz := k.cdc.MustMarshalBinaryBare(grant)
+	store.Set(key, bz)
+	if expiration := grant.Allowance.ExpiresAt(); !expiration.IsZero() {
+		store.Set(types.ExpirationQueueKey(expiration, grant.Granter, grant.Grantee), key)
+	}
+}
+
+// RevokeFeeAllowance deletes the fee allowance granted by the granter to the grantee
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) error {
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrNoAllowance, "granter %s, grantee %s", granter, grantee)
+	}
+
+	k.deleteFeeAllowance(ctx, grant)
+	return nil
+}
+
+// PruneExpiredFeeAllowances deletes the fee allowances expired at the current block time
+func (k Keeper) PruneExpiredFeeAllowances(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ExpirationQueueKeyPrefix, sdk.PrefixEndBytes(types.ExpirationQueuePrefix(ctx.BlockHeader().Time)))
+	var queueKeys, grantKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		queueKeys = append(queueKeys, iterator.Key())
+		grantKeys = append(grantKeys, iterator.Value())
+	}
+	iterator.Close()
+
+	for i := range queueKeys {
+		store.Delete(queueKeys[i])
+		store.Delete(grantKeys[i])
+	}
+}
+
+func (k Keeper) deleteFeeAllowance(ctx sdk.Context, grant types.FeeAllowanceGrant) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.FeeAllowanceKey(grant.Granter, grant.Grantee))
+	if expiration := grant.Allowance.ExpiresAt(); !expiration.IsZero() {
+		store.Delete(types.ExpirationQueueKey(expiration, grant.Granter, grant.Grantee))
+	}
+}
+
+// GetFeeGrant returns the fee allowance granted by the granter to the grantee
+func (k Keeper) GetFeeGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) (grant types.FeeAllowanceGrant, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.FeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return grant, false
+	}
+
+	k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+	return grant, true
+}
+
+// IterateGranteeFeeAllowances iterates over the fee allowances granted to the grantee until cb returns true
+func (k Keeper) IterateGranteeFeeAllowances(ctx sdk.Context, grantee sdk.AccAddress, cb func(grant types.FeeAllowanceGrant) (stop bool)) {
+	k.iterateFeeAllowances(ctx, types.FeeAllowancePrefixByGrantee(grantee), cb)
+}
+
+// IterateAllFeeAllowances iterates over all the fee allowances until cb returns true
+func (k Keeper) IterateAllFeeAllowances(ctx sdk.Context, cb func(grant types.FeeAllowanceGrant) (stop bool)) {
+	k.iterateFeeAllowances(ctx, types.FeeAllowanceKeyPrefix, cb)
+}
+
+func (k Keeper) iterateFeeAllowances(ctx sdk.Context, prefix []byte, cb func(grant types.FeeAllowanceGrant) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// UseGrantedFees deducts the fee of the msgs of the grantee from the fee allowance granted by the
+// granter, the allowance is deleted once it is used up. The expired allowances are rejected and left
+// to PruneExpiredFeeAllowances.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) error {
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrNoAllowance, "granter %s, grantee %s", granter, grantee)
+	}
+
+	remove, err := grant.Allowance.Accept(fee, ctx.BlockHeader().Time, msgs)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		k.deleteFeeAllowance(ctx, grant)
+	} else {
+		k.GrantFeeAllowance(ctx, grant)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeUseFeeAllowance,
+			sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		),
+	)
+
+	return nil
+}
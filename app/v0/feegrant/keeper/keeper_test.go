@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant/types"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func setupKeeper() (sdk.Context, Keeper) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(types.StoreKey)
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.LoadLatestVersion()
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "test-chain-id", Time: time.Now().UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(key, types.ModuleCdc)
+}
+
+func TestKeeperFeeAllowances(t *testing.T) {
+	ctx, k := setupKeeper()
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	otherGranter := sdk.AccAddress("other_granter_______")
+	msgs := []sdk.Msg{sdk.NewTestMsg(grantee)}
+
+	require.True(t, types.ErrNoAllowance.Is(k.UseGrantedFees(ctx, granter, grantee, nil, msgs)))
+
+	limit := sdk.NewCoins(sdk.NewInt64Coin("pnch", 100))
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(granter, grantee, types.NewBasicFeeAllowance(limit, time.Time{})))
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(otherGranter, grantee, types.NewBasicFeeAllowance(nil, time.Time{})))
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(grantee, granter, types.NewBasicFeeAllowance(nil, time.Time{})))
+
+	var grants types.FeeAllowanceGrants
+	k.IterateGranteeFeeAllowances(ctx, grantee, func(grant types.FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 2)
+
+	// the used fees are deducted from the allowance
+	require.NoError(t, k.UseGrantedFees(ctx, granter, grantee, sdk.NewCoins(sdk.NewInt64Coin("pnch", 30)), msgs))
+	grant, found := k.GetFeeGrant(ctx, granter, grantee)
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("pnch", 70)), grant.Allowance.(*types.BasicFeeAllowance).SpendLimit)
+
+	err := k.UseGrantedFees(ctx, granter, grantee, sdk.NewCoins(sdk.NewInt64Coin("pnch", 71)), msgs)
+	require.True(t, types.ErrFeeLimitExceeded.Is(err))
+
+	// a used up allowance is removed
+	require.NoError(t, k.UseGrantedFees(ctx, granter, grantee, sdk.NewCoins(sdk.NewInt64Coin("pnch", 70)), msgs))
+	_, found = k.GetFeeGrant(ctx, granter, grantee)
+	require.False(t, found)
+
+	require.NoError(t, k.RevokeFeeAllowance(ctx, otherGranter, grantee))
+	require.True(t, types.ErrNoAllowance.Is(k.RevokeFeeAllowance(ctx, otherGranter, grantee)))
+
+	grants = nil
+	k.IterateAllFeeAllowances(ctx, func(grant types.FeeAllowanceGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 1)
+	require.Equal(t, grantee, grants[0].Granter)
+}
+
+func TestPruneExpiredFeeAllowances(t *testing.T) {
+	ctx, k := setupKeeper()
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	otherGranter := sdk.AccAddress("other_granter_______")
+	now := ctx.BlockHeader().Time
+
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(granter, grantee, types.NewBasicFeeAllowance(nil, now.Add(time.Hour))))
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(otherGranter, grantee, types.NewBasicFeeAllowance(nil, time.Time{})))
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(grantee, granter, types.NewBasicFeeAllowance(nil, now.Add(time.Hour))))
+
+	// the regranted allowance expires at its new expiration
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(grantee, granter, types.NewBasicFeeAllowance(nil, now.Add(3*time.Hour))))
+
+	k.PruneExpiredFeeAllowances(ctx)
+	_, found := k.GetFeeGrant(ctx, granter, grantee)
+	require.True(t, found)
+
+	k.PruneExpiredFeeAllowances(ctx.WithBlockTime(now.Add(time.Hour)))
+	_, found = k.GetFeeGrant(ctx, granter, grantee)
+	require.False(t, found)
+	_, found = k.GetFeeGrant(ctx, otherGranter, grantee)
+	require.True(t, found)
+	_, found = k.GetFeeGrant(ctx, grantee, granter)
+	require.True(t, found)
+
+	k.PruneExpiredFeeAllowances(ctx.WithBlockTime(now.Add(3 * time.Hour)))
+	_, found = k.GetFeeGrant(ctx, grantee, granter)
+	require.False(t, found)
+
+	// the queue holds no revoked or pruned allowances
+	require.NoError(t, k.RevokeFeeAllowance(ctx, otherGranter, grantee))
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ExpirationQueueKeyPrefix)
+	defer iterator.Close()
+	require.False(t, iterator.Valid())
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	ErrFeeLimitExceeded    = sdkerrors.New(ModuleName, 1, "fee limit exceeded")
+	ErrFeeAllowanceExpired = sdkerrors.New(ModuleName, 2, "fee allowance expired")
+	ErrInvalidDuration     = sdkerrors.New(ModuleName, 3, "invalid duration")
+	ErrNoAllowance         = sdkerrors.New(ModuleName, 4, "no fee allowance")
+	ErrMessageNotAllowed   = sdkerrors.New(ModuleName, 5, "message not allowed")
+	ErrInvalidAllowance    = sdkerrors.New(ModuleName, 6, "invalid fee allowance")
+)
@@ -0,0 +1,14 @@
+package types
+
+const (
+	EventTypeGrantFeeAllowance  = "grant_fee_allowance"
+	EventTypeRevokeFeeAllowance = "revoke_fee_allowance"
+	EventTypeUseFeeAllowance    = "use_fee_allowance"
+
+	AttributeKeyGranter = "granter"
+	AttributeKeyGrantee = "grantee"
+)
+
+var (
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,312 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	_ FeeAllowance = (*BasicFeeAllowance)(nil)
+	_ FeeAllowance = (*PeriodicFeeAllowance)(nil)
+	_ FeeAllowance = (*AllowedMsgFeeAllowance)(nil)
+)
+
+// FeeAllowance is granted by a granter to pay the fees of the txs of a grantee
+type FeeAllowance interface {
+	// Accept checks the fee can be paid out of the allowance for the given msgs at the given block
+	// time and deducts it from the allowance. It returns remove=true when the allowance is used up
+	// or expired and must be deleted.
+	Accept(fee sdk.Coins, blockTime time.Time, msgs []sdk.Msg) (remove bool, err error)
+
+	// ValidateBasic does a stateless validation of the allowance
+	ValidateBasic() error
+
+	// ExpiresAt returns the expiration of the allowance, the zero time when it never expires
+	ExpiresAt() time.Time
+}
+
+// BasicFeeAllowance grants fees up to SpendLimit until Expiration, an empty SpendLimit is
+// unlimited and a zero Expiration never expires
+type BasicFeeAllowance struct {
+	SpendLimit sdk.Coins `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time `json:"expiration" yaml:"expiration"`
+}
+
+// NewBasicFeeAllowance creates a new BasicFeeAllowance instance
+func NewBasicFeeAllowance(spendLimit sdk.Coins, expiration time.Time) *BasicFeeAllowance {
+	return &BasicFeeAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+}
+
+// IsExpired returns whether the allowance is expired at the given block time
+func (a BasicFeeAllowance) IsExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+func (a BasicFeeAllowance) ExpiresAt() time.Time {
+	return a.Expiration
+}
+
+func (a *BasicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time, _ []sdk.Msg) (bool, error) {
+	if a.IsExpired(blockTime) {
+		return true, sdkerrors.Wrapf(ErrFeeAllowanceExpired, "expired at %s", a.Expiration)
+	}
+
+	if a.SpendLimit.Empty() {
+		return false, nil
+	}
+
+	left, hasNeg := a.SpendLimit.SafeSub(fee)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "fee %s exceeds the spend limit %s", fee, a.SpendLimit)
+	}
+	a.SpendLimit = left
+
+	return left.IsZero(), nil
+}
+
+func (a BasicFeeAllowance) ValidateBasic() error {
+	if !a.SpendLimit.IsValid() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "spend limit: %s", a.SpendLimit)
+	}
+	return nil
+}
+
+func (a BasicFeeAllowance) String() string {
+	return fmt.Sprintf(`BasicFeeAllowance:
+  SpendLimit: %s
+  Expiration: %s`, a.SpendLimit, a.Expiration)
+}
+
+// PeriodicFeeAllowance grants fees up to PeriodSpendLimit each Period, on top of the limits of
+// the Basic allowance. PeriodCanSpend is what is left to spend until PeriodReset.
+type PeriodicFeeAllowance struct {
+	Basic            BasicFeeAllowance `json:"basic" yaml:"basic"`
+	Period           time.Duration     `json:"period" yaml:"period"`
+	PeriodSpendLimit sdk.Coins         `json:"period_spend_limit" yaml:"period_spend_limit"`
+	PeriodCanSpend   sdk.Coins         `json:"period_can_spend" yaml:"period_can_spend"`
+	PeriodReset      time.Time         `json:"period_reset" yaml:"period_reset"`
+}
+
+// NewPeriodicFeeAllowance creates a new PeriodicFeeAllowance instance, its first period starts
+// with the first fee it pays
+func NewPeriodicFeeAllowance(basic BasicFeeAllowance, period time.Duration, periodSpendLimit sdk.Coins) *PeriodicFeeAllowance {
+	return &PeriodicFeeAllowance{
+		Basic:            basic,
+		Period:           period,
+		PeriodSpendLimit: periodSpendLimit,
+	}
+}
+
+func (a PeriodicFeeAllowance) ExpiresAt() time.Time {
+	return a.Basic.Expiration
+}
+
+func (a *PeriodicFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time, _ []sdk.Msg) (bool, error) {
+	if a.Basic.IsExpired(blockTime) {
+		return true, sdkerrors.Wrapf(ErrFeeAllowanceExpired, "expired at %s", a.Basic.Expiration)
+	}
+
+	a.tryResetPeriod(blockTime)
+
+	left, hasNeg := a.PeriodCanSpend.SafeSub(fee)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "fee %s exceeds the period spend limit %s", fee, a.PeriodCanSpend)
+	}
+	a.PeriodCanSpend = left
+
+	if a.Basic.SpendLimit.Empty() {
+		return false, nil
+	}
+
+	basicLeft, hasNeg := a.Basic.SpendLimit.SafeSub(fee)
+	if hasNeg {
+		return false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "fee %s exceeds the spend limit %s", fee, a.Basic.SpendLimit)
+	}
+	a.Basic.SpendLimit = basicLeft
+
+	return basicLeft.IsZero(), nil
+}
+
+// tryResetPeriod starts a new period when the current one is over, the amount left to spend in
+// the new period is bounded by the amount left in the basic allowance
+func (a *PeriodicFeeAllowance) tryResetPeriod(blockTime time.Time) {
+	if !a.PeriodReset.IsZero() && blockTime.Before(a.PeriodReset) {
+		return
+	}
+
+	a.PeriodCanSpend = a.PeriodSpendLimit
+	if !a.Basic.SpendLimit.Empty() {
+		a.PeriodCanSpend = minCoins(a.PeriodSpendLimit, a.Basic.SpendLimit)
+	}
+
+	// a missed period is not carried over
+	a.PeriodReset = a.PeriodReset.Add(a.Period)
+	if blockTime.After(a.PeriodReset) {
+		a.PeriodReset = blockTime.Add(a.Period)
+	}
+}
+
+func (a PeriodicFeeAllowance) ValidateBasic() error {
+	if err := a.Basic.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if !a.PeriodSpendLimit.IsValid() || a.PeriodSpendLimit.Empty() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "period spend limit: %s", a.PeriodSpendLimit)
+	}
+	if !a.PeriodCanSpend.IsValid() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "period can spend: %s", a.PeriodCanSpend)
+	}
+	if !a.Basic.SpendLimit.Empty() && !a.PeriodSpendLimit.DenomsSubsetOf(a.Basic.SpendLimit) {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "period spend limit has different denoms than the spend limit")
+	}
+	if a.Period <= 0 {
+		return sdkerrors.Wrapf(ErrInvalidDuration, "period must be positive: %s", a.Period)
+	}
+
+	return nil
+}
+
+func (a PeriodicFeeAllowance) String() string {
+	return fmt.Sprintf(`PeriodicFeeAllowance:
+  SpendLimit:       %s
+  Expiration:       %s
+  Period:           %s
+  PeriodSpendLimit: %s
+  PeriodCanSpend:   %s
+  PeriodReset:      %s`, a.Basic.SpendLimit, a.Basic.Expiration, a.Period, a.PeriodSpendLimit, a.PeriodCanSpend, a.PeriodReset)
+}
+
+// AllowedMsgFeeAllowance restricts Allowance to the txs whose msgs all are of the allowed types,
+// a msg type is identified by "<route>/<type>" of the msg, e.g. "bank/send"
+type AllowedMsgFeeAllowance struct {
+	Allowance       FeeAllowance `json:"allowance" yaml:"allowance"`
+	AllowedMessages []string     `json:"allowed_messages" yaml:"allowed_messages"`
+}
+
+// NewAllowedMsgFeeAllowance creates a new AllowedMsgFeeAllowance instance
+func NewAllowedMsgFeeAllowance(allowance FeeAllowance, allowedMessages []string) *AllowedMsgFeeAllowance {
+	return &AllowedMsgFeeAllowance{
+		Allowance:       allowance,
+		AllowedMessages: allowedMessages,
+	}
+}
+
+// MsgTypeURL returns the identifier of the type of msg matched against the allowed messages
+func MsgTypeURL(msg sdk.Msg) string {
+	return fmt.Sprintf("%s/%s", msg.Route(), msg.Type())
+}
+
+func (a *AllowedMsgFeeAllowance) Accept(fee sdk.Coins, blockTime time.Time, msgs []sdk.Msg) (bool, error) {
+	for _, msg := range msgs {
+		if !a.allowed(MsgTypeURL(msg)) {
+			return false, sdkerrors.Wrapf(ErrMessageNotAllowed, "%s", MsgTypeURL(msg))
+		}
+	}
+
+	return a.Allowance.Accept(fee, blockTime, msgs)
+}
+
+func (a AllowedMsgFeeAllowance) ExpiresAt() time.Time {
+	return a.Allowance.ExpiresAt()
+}
+
+func (a AllowedMsgFeeAllowance) allowed(msgType string) bool {
+	for _, allowed := range a.AllowedMessages {
+		if allowed == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+func (a AllowedMsgFeeAllowance) ValidateBasic() error {
+	if a.Allowance == nil {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "missing allowance")
+	}
+	if _, ok := a.Allowance.(*AllowedMsgFeeAllowance); ok {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "allowed msg allowances can not be nested")
+	}
+	if len(a.AllowedMessages) == 0 {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "allowed messages are empty")
+	}
+
+	return a.Allowance.ValidateBasic()
+}
+
+func (a AllowedMsgFeeAllowance) String() string {
+	return fmt.Sprintf(`AllowedMsgFeeAllowance:
+  AllowedMessages: %v
+  Allowance:       %v`, a.AllowedMessages, a.Allowance)
+}
+
+// minCoins returns the minimum of each denom of a, ignoring the denoms missing in b
+func minCoins(a, b sdk.Coins) sdk.Coins {
+	var min sdk.Coins
+	for _, coin := range a {
+		amount := sdk.MinInt(coin.Amount, b.AmountOf(coin.Denom))
+		if amount.IsPositive() {
+			min = append(min, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return min
+}
+
+// FeeAllowanceGrant is a fee allowance granted by Granter to Grantee
+type FeeAllowanceGrant struct {
+	Granter   sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+// NewFeeAllowanceGrant creates a new FeeAllowanceGrant instance
+func NewFeeAllowanceGrant(granter, grantee sdk.AccAddress, allowance FeeAllowance) FeeAllowanceGrant {
+	return FeeAllowanceGrant{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// ValidateBasic does a stateless validation of the grant
+func (g FeeAllowanceGrant) ValidateBasic() error {
+	if g.Granter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing granter address")
+	}
+	if g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing grantee address")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot self-grant fee allowance")
+	}
+	if g.Allowance == nil {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "missing allowance")
+	}
+
+	return g.Allowance.ValidateBasic()
+}
+
+func (g FeeAllowanceGrant) String() string {
+	return fmt.Sprintf(`FeeAllowanceGrant:
+  Granter:   %s
+  Grantee:   %s
+  Allowance: %v`, g.Granter, g.Grantee, g.Allowance)
+}
+
+// FeeAllowanceGrants is a slice of FeeAllowanceGrant
+type FeeAllowanceGrants []FeeAllowanceGrant
+
+func (g FeeAllowanceGrants) String() string {
+	out := make([]string, len(g))
+	for i, grant := range g {
+		out[i] = grant.String()
+	}
+	return strings.Join(out, "\n")
+}
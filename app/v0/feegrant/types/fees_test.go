@@ -0,0 +1,114 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestBasicFeeAllowance(t *testing.T) {
+	now := time.Now().UTC()
+	fee := sdk.NewCoins(sdk.NewInt64Coin("pnch", 40))
+
+	unlimited := NewBasicFeeAllowance(nil, time.Time{})
+	remove, err := unlimited.Accept(fee, now, nil)
+	require.NoError(t, err)
+	require.False(t, remove)
+
+	limited := NewBasicFeeAllowance(sdk.NewCoins(sdk.NewInt64Coin("pnch", 100)), now.Add(time.Hour))
+	require.NoError(t, limited.ValidateBasic())
+	remove, err = limited.Accept(fee, now, nil)
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("pnch", 60)), limited.SpendLimit)
+
+	// another denom or more than the spend limit
+	_, err = limited.Accept(sdk.NewCoins(sdk.NewInt64Coin("other", 1)), now, nil)
+	require.True(t, ErrFeeLimitExceeded.Is(err))
+	_, err = limited.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 61)), now, nil)
+	require.True(t, ErrFeeLimitExceeded.Is(err))
+
+	// used up
+	remove, err = limited.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 60)), now, nil)
+	require.NoError(t, err)
+	require.True(t, remove)
+
+	// expired
+	expired := NewBasicFeeAllowance(nil, now)
+	remove, err = expired.Accept(fee, now, nil)
+	require.True(t, ErrFeeAllowanceExpired.Is(err))
+	require.True(t, remove)
+}
+
+func TestPeriodicFeeAllowance(t *testing.T) {
+	now := time.Now().UTC()
+	basic := NewBasicFeeAllowance(sdk.NewCoins(sdk.NewInt64Coin("pnch", 250)), time.Time{})
+	periodic := NewPeriodicFeeAllowance(*basic, time.Hour, sdk.NewCoins(sdk.NewInt64Coin("pnch", 100)))
+	require.NoError(t, periodic.ValidateBasic())
+
+	// the first fee starts the first period
+	remove, err := periodic.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 60)), now, nil)
+	require.NoError(t, err)
+	require.False(t, remove)
+	require.Equal(t, now.Add(time.Hour), periodic.PeriodReset)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("pnch", 40)), periodic.PeriodCanSpend)
+
+	_, err = periodic.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 50)), now.Add(time.Minute), nil)
+	require.True(t, ErrFeeLimitExceeded.Is(err))
+
+	// a new period
+	_, err = periodic.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 100)), now.Add(time.Hour), nil)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2*time.Hour), periodic.PeriodReset)
+
+	// missed periods are not carried over, the period is bounded by the basic spend limit
+	later := now.Add(10 * time.Hour)
+	remove, err = periodic.Accept(sdk.NewCoins(sdk.NewInt64Coin("pnch", 90)), later, nil)
+	require.NoError(t, err)
+	require.True(t, remove)
+	require.Equal(t, later.Add(time.Hour), periodic.PeriodReset)
+
+	invalid := NewPeriodicFeeAllowance(*basic, 0, sdk.NewCoins(sdk.NewInt64Coin("pnch", 100)))
+	require.True(t, ErrInvalidDuration.Is(invalid.ValidateBasic()))
+	invalid = NewPeriodicFeeAllowance(*basic, time.Hour, sdk.NewCoins(sdk.NewInt64Coin("other", 100)))
+	require.Error(t, invalid.ValidateBasic())
+}
+
+func TestAllowedMsgFeeAllowance(t *testing.T) {
+	now := time.Now().UTC()
+	fee := sdk.NewCoins(sdk.NewInt64Coin("pnch", 10))
+	msg := sdk.NewTestMsg(sdk.AccAddress("signer"))
+
+	allowed := NewAllowedMsgFeeAllowance(NewBasicFeeAllowance(nil, time.Time{}), []string{MsgTypeURL(msg)})
+	require.NoError(t, allowed.ValidateBasic())
+	_, err := allowed.Accept(fee, now, []sdk.Msg{msg})
+	require.NoError(t, err)
+
+	other := MsgRevokeFeeAllowance{}
+	_, err = allowed.Accept(fee, now, []sdk.Msg{msg, other})
+	require.True(t, ErrMessageNotAllowed.Is(err))
+
+	require.Error(t, NewAllowedMsgFeeAllowance(allowed, []string{MsgTypeURL(msg)}).ValidateBasic())
+	require.Error(t, NewAllowedMsgFeeAllowance(NewBasicFeeAllowance(nil, time.Time{}), nil).ValidateBasic())
+}
+
+func TestFeeAllowanceGrantValidateBasic(t *testing.T) {
+	granter, grantee := sdk.AccAddress("granter_____________"), sdk.AccAddress("grantee_____________")
+	allowance := NewBasicFeeAllowance(nil, time.Time{})
+
+	require.NoError(t, NewMsgGrantFeeAllowance(granter, grantee, allowance).ValidateBasic())
+	require.Error(t, NewMsgGrantFeeAllowance(granter, granter, allowance).ValidateBasic())
+	require.Error(t, NewMsgGrantFeeAllowance(nil, grantee, allowance).ValidateBasic())
+	require.Error(t, NewMsgGrantFeeAllowance(granter, grantee, nil).ValidateBasic())
+	require.NoError(t, NewMsgRevokeFeeAllowance(granter, grantee).ValidateBasic())
+	require.Error(t, NewMsgRevokeFeeAllowance(granter, nil).ValidateBasic())
+
+	// the grant msg goes through the amino codec
+	msg := NewMsgGrantFeeAllowance(granter, grantee, NewAllowedMsgFeeAllowance(allowance, []string{"bank/send"}))
+	var decoded MsgGrantFeeAllowance
+	ModuleCdc.MustUnmarshalJSON(ModuleCdc.MustMarshalJSON(msg), &decoded)
+	require.Equal(t, msg, decoded)
+}
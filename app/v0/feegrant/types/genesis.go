@@ -0,0 +1,28 @@
+package types
+
+// GenesisState is the feegrant state that must be provided at genesis.
+type GenesisState struct {
+	FeeAllowances FeeAllowanceGrants `json:"fee_allowances" yaml:"fee_allowances"`
+}
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(grants FeeAllowanceGrants) GenesisState {
+	return GenesisState{
+		FeeAllowances: grants,
+	}
+}
+
+// DefaultGenesisState returns a default genesis state
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis performs basic validation of the feegrant genesis data
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.FeeAllowances {
+		if err := grant.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	ModuleName   = protocol.FeegrantModuleName
+	StoreKey     = ModuleName
+	RouterKey    = ModuleName
+	QuerierRoute = ModuleName
+)
+
+var (
+	FeeAllowanceKeyPrefix    = []byte{0x00}
+	ExpirationQueueKeyPrefix = []byte{0x01}
+)
+
+// FeeAllowanceKey returns the key of the fee allowance granted by granter to grantee
+func FeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(FeeAllowancePrefixByGrantee(grantee), granter.Bytes()...)
+}
+
+// FeeAllowancePrefixByGrantee returns the prefix of the keys of the fee allowances granted to grantee
+func FeeAllowancePrefixByGrantee(grantee sdk.AccAddress) []byte {
+	return append(FeeAllowanceKeyPrefix, grantee.Bytes()...)
+}
+
+// ExpirationQueueKey returns the key of the fee allowance granted by granter to grantee in the queue
+// of the allowances expiring at t
+func ExpirationQueueKey(t time.Time, granter, grantee sdk.AccAddress) []byte {
+	return append(ExpirationQueuePrefix(t), FeeAllowanceKey(granter, grantee)...)
+}
+
+// ExpirationQueuePrefix returns the prefix of the keys of the fee allowances expiring at t
+func ExpirationQueuePrefix(t time.Time) []byte {
+	return append(ExpirationQueueKeyPrefix, sdk.FormatTimeBytes(t)...)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	QueryAllowance  = "allowance"
+	QueryAllowances = "allowances"
+)
+
+// QueryAllowanceParams are the params of the query of the fee allowance granted by Granter to Grantee
+type QueryAllowanceParams struct {
+	Granter sdk.AccAddress `json:"granter" yaml:"granter"`
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+func NewQueryAllowanceParams(granter, grantee sdk.AccAddress) QueryAllowanceParams {
+	return QueryAllowanceParams{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// QueryAllowancesParams are the params of the query of all the fee allowances granted to Grantee
+type QueryAllowancesParams struct {
+	Grantee sdk.AccAddress `json:"grantee" yaml:"grantee"`
+}
+
+func NewQueryAllowancesParams(grantee sdk.AccAddress) QueryAllowancesParams {
+	return QueryAllowancesParams{
+		Grantee: grantee,
+	}
+}
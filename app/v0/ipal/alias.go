@@ -14,26 +14,68 @@ const (
 )
 
 var (
-	NewKeeper              = keeper.NewKeeper
-	NewQuerier             = keeper.NewQuerier
-	RegisterCodec          = types.RegisterCodec
-	NewIPALNodeObject      = types.NewIPALNode
-	NewMsgIPALNodeClaim    = types.NewMsgIPALNodeClaim
-	ModuleCdc              = types.ModuleCdc
-	AttributeValueCategory = types.AttributeValueCategory
-	NewEndpoint            = types.NewEndpoint
-	ErrEmptyInputs         = types.ErrEmptyInputs
-	ErrBadDenom            = types.ErrBadDenom
-	ErrBondInsufficient    = types.ErrBondInsufficient
-	ErrMonikerExist        = types.ErrMonikerExist
-	ErrEndpointsFormat     = types.ErrEndpointsFormat
-	ErrEndpointsEmpty      = types.ErrEndpointsEmpty
-	ErrEndpointsDuplicate  = types.ErrEndpointsDuplicate
+	NewKeeper                    = keeper.NewKeeper
+	NewQuerier                   = keeper.NewQuerier
+	RegisterCodec                = types.RegisterCodec
+	NewIPALNodeObject            = types.NewIPALNode
+	NewMsgIPALNodeClaim          = types.NewMsgIPALNodeClaim
+	NewMsgIPALAvailabilityReport = types.NewMsgIPALAvailabilityReport
+	NewMsgIPALNodeUnjail         = types.NewMsgIPALNodeUnjail
+	NewMsgIPALDelegate           = types.NewMsgIPALDelegate
+	NewMsgIPALUndelegate         = types.NewMsgIPALUndelegate
+	NewMsgIPALWithdrawRewards    = types.NewMsgIPALWithdrawRewards
+	NewMsgIPALSetCommission      = types.NewMsgIPALSetCommission
+	NewDelegation                = types.NewDelegation
+	NewNodeAvailability          = types.NewNodeAvailability
+	NewAvailabilityReport        = types.NewAvailabilityReport
+	ModuleCdc                    = types.ModuleCdc
+	AttributeValueCategory       = types.AttributeValueCategory
+	NewEndpoint                  = types.NewEndpoint
+	NewTypeSpec                  = types.NewTypeSpec
+	NewPublicKey                 = types.NewPublicKey
+	NewKeySet                    = types.NewKeySet
+	PublicKeysFromString         = types.PublicKeysFromString
+	ErrInvalidPublicKeys         = types.ErrInvalidPublicKeys
+	ErrEmptyInputs               = types.ErrEmptyInputs
+	ErrBadDenom                  = types.ErrBadDenom
+	ErrBondInsufficient          = types.ErrBondInsufficient
+	ErrMonikerExist              = types.ErrMonikerExist
+	ErrEndpointsFormat           = types.ErrEndpointsFormat
+	ErrEndpointsEmpty            = types.ErrEndpointsEmpty
+	ErrEndpointsDuplicate        = types.ErrEndpointsDuplicate
+	ErrNotReporter               = types.ErrNotReporter
+	ErrIPALNodeNotFound          = types.ErrIPALNodeNotFound
+	ErrIPALNodeJailed            = types.ErrIPALNodeJailed
+	ErrIPALNodeNotJailed         = types.ErrIPALNodeNotJailed
+	ErrStillJailed               = types.ErrStillJailed
+	ErrDuplicateReport           = types.ErrDuplicateReport
+	ErrDelegationNotFound        = types.ErrDelegationNotFound
+	ErrInsufficientDelegation    = types.ErrInsufficientDelegation
+	ErrInvalidCommission         = types.ErrInvalidCommission
+	ErrNoRewards                 = types.ErrNoRewards
 )
 
 type (
-	Keeper           = keeper.Keeper
-	MsgIPALNodeClaim = types.MsgIPALNodeClaim
-	Endpoint         = types.Endpoint
-	Endpoints        = types.Endpoints
+	Keeper                    = keeper.Keeper
+	MsgIPALNodeClaim          = types.MsgIPALNodeClaim
+	MsgIPALAvailabilityReport = types.MsgIPALAvailabilityReport
+	MsgIPALNodeUnjail         = types.MsgIPALNodeUnjail
+	MsgIPALDelegate           = types.MsgIPALDelegate
+	MsgIPALUndelegate         = types.MsgIPALUndelegate
+	MsgIPALWithdrawRewards    = types.MsgIPALWithdrawRewards
+	MsgIPALSetCommission      = types.MsgIPALSetCommission
+	Delegation                = types.Delegation
+	Delegations               = types.Delegations
+	NodeAvailability          = types.NodeAvailability
+	AvailabilityReport        = types.AvailabilityReport
+	Endpoint                  = types.Endpoint
+	Endpoints                 = types.Endpoints
+	IPALHooks                 = types.IPALHooks
+	IPALNode                  = types.IPALNode
+	TypeSpec                  = types.TypeSpec
+	TypeSpecs                 = types.TypeSpecs
+	PublicKey                 = types.PublicKey
+	PublicKeys                = types.PublicKeys
+	KeySet                    = types.KeySet
+	KeySets                   = types.KeySets
 )
@@ -9,4 +9,16 @@ const (
 	flagDetails               = "details"
 	flagExtension             = "extension"
 	flagBond                  = "bond"
+	flagAvailable             = "available"
+	flagUnavailable           = "unavailable"
+	flagAmount                = "amount"
+	flagCommission            = "commission"
+	flagOffset                = "offset"
+	flagLimit                 = "limit"
+	flagAfter                 = "after"
+	flagEndpointType          = "endpoint-type"
+	flagMinBond               = "min-bond"
+	flagMonikerPrefix         = "moniker-prefix"
+	flagOrderBy               = "order-by"
+	flagPublicKeys            = "public_keys"
 )
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	"github.com/netcloth/netcloth-chain/client"
@@ -27,6 +28,11 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryIPALNodeList(cdc),
 		GetCmdQueryIPALNode(cdc),
+		GetCmdQueryAvailabilityReports(cdc),
+		GetCmdQueryDelegation(cdc),
+		GetCmdQueryDelegations(cdc),
+		GetCmdQueryRewards(cdc),
+		GetCmdQueryPublicKeys(cdc),
 	)...)
 
 	return ipalQueryCmd
@@ -59,30 +65,62 @@ $ %s query ipal params`, version.ClientName)),
 }
 
 func GetCmdQueryIPALNodeList(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Querying commands for IPALNodes",
-		Long: strings.TrimSpace(fmt.Sprintf(`List all IPALNodes.
+		Long: strings.TrimSpace(fmt.Sprintf(`List the IPALNodes ranked by bond, highest first, or ordered by moniker, filtered
+by endpoint type, min bond and moniker prefix. A page resumes after the IPALNode of --after, usually
+the last IPALNode of the previous page, and/or skips --offset IPALNodes.
 Example:
-$ %s query ipal list`, version.ClientName)),
+$ %s query ipal list
+$ %s query ipal list --endpoint-type=1 --min-bond=1000000000000 --limit=10
+$ %s query ipal list --order-by=moniker --moniker-prefix=nch --limit=10 --after=[address]`,
+			version.ClientName, version.ClientName, version.ClientName)),
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			resKVs, _, err := cliCtx.QuerySubspace(types.IPALNodeByBondKey, types.StoreKey)
+
+			var after sdk.AccAddress
+			if s := viper.GetString(flagAfter); s != "" {
+				addr, err := sdk.AccAddressFromBech32(s)
+				if err != nil {
+					return err
+				}
+				after = addr
+			}
+
+			params := types.NewQueryIPALNodeListParams(viper.GetInt(flagOffset), viper.GetInt(flagLimit), after,
+				viper.GetUint64(flagEndpointType), viper.GetInt64(flagMinBond), viper.GetString(flagMonikerPrefix),
+				viper.GetString(flagOrderBy))
+			if err := params.Validate(); err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
 				return err
 			}
 
-			var ipalNodes types.IPALNodes
-			if len(resKVs) > 0 {
-				for i := len(resKVs) - 1; i >= 0; i-- {
-					ipalNodes = append(ipalNodes, types.MustUnmarshalIPALNode(cdc, resKVs[i].Value))
-				}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodeList), bz)
+			if err != nil {
+				return err
 			}
 
+			var ipalNodes types.IPALNodes
+			cdc.MustUnmarshalJSON(res, &ipalNodes)
 			return cliCtx.PrintOutput(ipalNodes)
 		},
 	}
+
+	cmd.Flags().Int(flagOffset, 0, "number of IPALNodes to skip")
+	cmd.Flags().Int(flagLimit, 0, "max number of IPALNodes to list, 0 lists all of them")
+	cmd.Flags().String(flagAfter, "", "operator address of the IPALNode to resume the listing after")
+	cmd.Flags().Uint64(flagEndpointType, 0, "only list the IPALNodes serving this endpoint type")
+	cmd.Flags().Int64(flagMinBond, 0, "only list the IPALNodes with a bond, delegations included, of at least this amount of pnch")
+	cmd.Flags().String(flagMonikerPrefix, "", "only list the IPALNodes whose moniker starts with this prefix")
+	cmd.Flags().String(flagOrderBy, types.OrderByBond, fmt.Sprintf("order of the IPALNodes, %s or %s", types.OrderByBond, types.OrderByMoniker))
+
+	return cmd
 }
 
 func GetCmdQueryIPALNode(cdc *codec.Codec) *cobra.Command {
@@ -115,3 +153,170 @@ $ %s query ipal node [address]`, version.ClientName)),
 		},
 	}
 }
+
+func GetCmdQueryAvailabilityReports(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reports",
+		Short: "Querying the availability reports of an IPALNode",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the availability reports of the current window about the IPALNode of accAddr.
+Example:
+$ %s query ipal reports [address]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryAvailabilityReportsParams(addr))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReports), bz)
+			if err != nil {
+				return err
+			}
+
+			var reports types.AvailabilityReports
+			cdc.MustUnmarshalJSON(res, &reports)
+			return cliCtx.PrintOutput(reports)
+		},
+	}
+}
+
+func GetCmdQueryDelegation(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegation",
+		Short: "Querying the delegation of an account to an IPALNode",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the delegation of delegator to the IPALNode of operator.
+Example:
+$ %s query ipal delegation [delegator] [operator]`, version.ClientName)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := queryDelegation(cliCtx, cdc, types.QueryDelegation, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			var delegation types.Delegation
+			cdc.MustUnmarshalJSON(res, &delegation)
+			return cliCtx.PrintOutput(delegation)
+		},
+	}
+}
+
+func GetCmdQueryDelegations(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegations",
+		Short: "Querying the delegations to an IPALNode",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the delegations to the IPALNode of operator.
+Example:
+$ %s query ipal delegations [operator]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryDelegationParams(nil, operator))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDelegations), bz)
+			if err != nil {
+				return err
+			}
+
+			var delegations types.Delegations
+			cdc.MustUnmarshalJSON(res, &delegations)
+			return cliCtx.PrintOutput(delegations)
+		},
+	}
+}
+
+func GetCmdQueryRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards",
+		Short: "Querying the pending rewards of a delegation to an IPALNode",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the pending rewards of the delegation of delegator to the IPALNode of operator,
+and the outstanding commission of the IPALNode when delegator is its operator.
+Example:
+$ %s query ipal rewards [delegator] [operator]`, version.ClientName)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := queryDelegation(cliCtx, cdc, types.QueryRewards, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			var rewards types.QueryRewardsResponse
+			cdc.MustUnmarshalJSON(res, &rewards)
+			return cliCtx.PrintOutput(rewards)
+		},
+	}
+}
+
+func queryDelegation(cliCtx context.CLIContext, cdc *codec.Codec, path, delegatorAddr, operatorAddr string) ([]byte, error) {
+	delegator, err := sdk.AccAddressFromBech32(delegatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := sdk.AccAddressFromBech32(operatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := cdc.MarshalJSON(types.NewQueryDelegationParams(delegator, operator))
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, path), bz)
+	return res, err
+}
+
+func GetCmdQueryPublicKeys(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "keys",
+		Short: "Querying the public keys of an IPALNode",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the versions of the messaging public keys published by the IPALNode of accAddr,
+oldest first, the last one is the current one.
+Example:
+$ %s query ipal keys [address]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryIPALNodeParams(addr))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPublicKeys), bz)
+			if err != nil {
+				return err
+			}
+
+			var sets types.KeySets
+			cdc.MustUnmarshalJSON(res, &sets)
+			return cliCtx.PrintOutput(sets)
+		},
+	}
+}
@@ -22,6 +22,12 @@ func IPALCmd(cdc *codec.Codec) *cobra.Command {
 	}
 	txCmd.AddCommand(
 		IPALNodeClaimCmd(cdc),
+		IPALAvailabilityReportCmd(cdc),
+		IPALNodeUnjailCmd(cdc),
+		IPALDelegateCmd(cdc),
+		IPALUndelegateCmd(cdc),
+		IPALWithdrawRewardsCmd(cdc),
+		IPALSetCommissionCmd(cdc),
 	)
 	return txCmd
 }
@@ -58,7 +64,12 @@ func IPALNodeClaimCmd(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgIPALNodeClaim(cliCtx.GetFromAddress(), moniker, website, details, extension, endpoints, coin)
+			publicKeys, err := types.PublicKeysFromString(viper.GetString(flagPublicKeys))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALNodeClaim(cliCtx.GetFromAddress(), moniker, website, details, extension, endpoints, coin, publicKeys)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -75,6 +86,7 @@ func IPALNodeClaimCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagDetails, "", "ipal node details")
 	cmd.Flags().String(flagExtension, "", "extension for future user define")
 	cmd.Flags().String(flagBond, "", "stake amount (e.g. 1000000pnch)")
+	cmd.Flags().String(flagPublicKeys, "", "messaging public keys to publish, in format: type=hexKey[:hexSignature],type=hexKey[:hexSignature], the published keys are kept when empty")
 
 	cmd.MarkFlagRequired(flagMoniker)
 	cmd.MarkFlagRequired(flagEndpoints)
@@ -84,3 +96,197 @@ func IPALNodeClaimCmd(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func IPALAvailabilityReportCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Create and sign a IPALAvailabilityReport tx, reporting whether the endpoints of ipal nodes are reachable",
+		Example: "nchcli ipal report --from=<reporter key name> --available=<operator>,<operator> --unavailable=<operator>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var reports []types.NodeAvailability
+			for _, flag := range []string{flagAvailable, flagUnavailable} {
+				for _, s := range viper.GetStringSlice(flag) {
+					operator, err := sdk.AccAddressFromBech32(s)
+					if err != nil {
+						return err
+					}
+					reports = append(reports, types.NewNodeAvailability(operator, flag == flagAvailable))
+				}
+			}
+
+			msg := types.NewMsgIPALAvailabilityReport(cliCtx.GetFromAddress(), reports)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringSlice(flagAvailable, nil, "operator addresses of the ipal nodes whose endpoints are reachable")
+	cmd.Flags().StringSlice(flagUnavailable, nil, "operator addresses of the ipal nodes whose endpoints are unreachable")
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALNodeUnjailCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unjail",
+		Short:   "Create and sign a IPALNodeUnjail tx, putting the jailed ipal node of the sender back in the ranking",
+		Example: "nchcli ipal unjail --from=<user key name>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgIPALNodeUnjail(cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALDelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delegate [operator]",
+		Short:   "Create and sign a IPALDelegate tx, bonding coins to the ipal node of operator",
+		Example: "nchcli ipal delegate <operator> --from=<user key name> --amount=<amount>",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALDelegate(cliCtx.GetFromAddress(), operator, amount)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagAmount, "", "delegation amount (e.g. 1000000pnch)")
+	cmd.MarkFlagRequired(flagAmount)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALUndelegateCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "undelegate [operator]",
+		Short:   "Create and sign a IPALUndelegate tx, unbonding coins delegated to the ipal node of operator",
+		Example: "nchcli ipal undelegate <operator> --from=<user key name> --amount=<amount>",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALUndelegate(cliCtx.GetFromAddress(), operator, amount)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagAmount, "", "undelegation amount (e.g. 1000000pnch)")
+	cmd.MarkFlagRequired(flagAmount)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALWithdrawRewardsCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "withdraw-rewards [operator]",
+		Short:   "Create and sign a IPALWithdrawRewards tx, withdrawing the rewards of the delegation to the ipal node of operator and the commission of its operator",
+		Example: "nchcli ipal withdraw-rewards <operator> --from=<user key name>",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALWithdrawRewards(cliCtx.GetFromAddress(), operator)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+func IPALSetCommissionCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-commission",
+		Short:   "Create and sign a IPALSetCommission tx, setting the share of the delegator rewards kept by the operator of the ipal node",
+		Example: "nchcli ipal set-commission --from=<user key name> --commission=0.1",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			commission, err := sdk.NewDecFromStr(viper.GetString(flagCommission))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgIPALSetCommission(cliCtx.GetFromAddress(), commission)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagCommission, "", "share of the delegator rewards kept by the operator, between 0 and 1")
+	cmd.MarkFlagRequired(flagCommission)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -25,33 +26,59 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		nodeHandlerFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/ipal/reports/{accAddr}",
+		reportsHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/delegations/{operator}",
+		delegationsHandlerFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/delegations/{operator}/{delegator}",
+		delegationHandlerFn(cliCtx, types.QueryDelegation),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/rewards/{operator}/{delegator}",
+		delegationHandlerFn(cliCtx, types.QueryRewards),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/ipal/keys/{accAddr}",
+		publicKeysHandlerFn(cliCtx),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/ipal/nodes",
 		nodesHandlerFn(cliCtx),
 	).Methods("POST") //TODO should be GET, but go-sdk use lib[github.com/parnurzeal/gorequest] which can not use GET method to send body
 }
 
+// listHandlerFn lists the ranked ipal nodes, the optional query args are offset, limit, after,
+// endpoint_type, min_bond, moniker_prefix and order_by
 func listHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := parseListParams(r)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
 		if !ok {
 			return
 		}
 
-		resKVs, height, err := cliCtx.QuerySubspace(types.IPALNodeByBondKey, types.StoreKey)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		var ipalNodes types.IPALNodes
-		if len(resKVs) > 0 {
-			for i := len(resKVs) - 1; i >= 0; i-- {
-				ipalNodes = append(ipalNodes, types.MustUnmarshalIPALNode(cliCtx.Codec, resKVs[i].Value))
-			}
-		}
-
-		res, err := cliCtx.Codec.MarshalJSONIndent(ipalNodes, "", "  ")
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodeList), bz)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -62,6 +89,39 @@ func listHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func parseListParams(r *http.Request) (params types.QueryIPALNodeListParams, err error) {
+	query := r.URL.Query()
+	if s := query.Get("offset"); s != "" {
+		if params.Offset, err = strconv.Atoi(s); err != nil {
+			return params, err
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if params.Limit, err = strconv.Atoi(s); err != nil {
+			return params, err
+		}
+	}
+	if s := query.Get("after"); s != "" {
+		if params.After, err = sdk.AccAddressFromBech32(s); err != nil {
+			return params, err
+		}
+	}
+	if s := query.Get("endpoint_type"); s != "" {
+		if params.EndpointType, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return params, err
+		}
+	}
+	if s := query.Get("min_bond"); s != "" {
+		if params.MinBond, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return params, err
+		}
+	}
+	params.MonikerPrefix = query.Get("moniker_prefix")
+	params.OrderBy = query.Get("order_by")
+
+	return params, params.Validate()
+}
+
 func queryNode(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -136,3 +196,90 @@ func nodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 func nodesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryNodes(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryIPALNodes))
 }
+
+func publicKeysHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryNode(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryPublicKeys))
+}
+
+func reportsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["accAddr"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryAvailabilityReportsParams(accAddr))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryReports), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func delegationsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		operator, err := sdk.AccAddressFromBech32(mux.Vars(r)["operator"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		queryDelegation(w, r, cliCtx, types.QueryDelegations, types.NewQueryDelegationParams(nil, operator))
+	}
+}
+
+func delegationHandlerFn(cliCtx context.CLIContext, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		operator, err := sdk.AccAddressFromBech32(vars["operator"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegator, err := sdk.AccAddressFromBech32(vars["delegator"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		queryDelegation(w, r, cliCtx, path, types.NewQueryDelegationParams(delegator, operator))
+	}
+}
+
+func queryDelegation(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, path string, params types.QueryDelegationParams) {
+	cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+	if !ok {
+		return
+	}
+
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, height, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, path), bz)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	cliCtx = cliCtx.WithHeight(height)
+	rest.PostProcessResponse(w, cliCtx, res)
+}
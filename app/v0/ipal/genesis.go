@@ -13,18 +13,56 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState)
 
 	for _, node := range data.IPALNodes {
 		node.Bond = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(0))
+		if node.Delegated.Denom == "" {
+			node.Delegated = sdk.NewCoin(sdk.NativeTokenName, sdk.ZeroInt())
+		}
+		if node.Commission.IsNil() {
+			node.Commission = sdk.ZeroDec()
+		}
 		keeper.CreateIPALNode(ctx, node)
 	}
+
+	for _, report := range data.AvailabilityReports {
+		keeper.SetAvailabilityReport(ctx, report)
+	}
+
+	for _, delegation := range data.Delegations {
+		keeper.SetDelegation(ctx, delegation)
+	}
+
+	for _, set := range data.KeySets {
+		keeper.SetKeySet(ctx, set)
+	}
 	return []abci.ValidatorUpdate{}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	params := keeper.GetParams(ctx)
-	ipalNodes := keeper.GetAllIPALNodes(ctx)
 
-	return types.GenesisState{
-		Params:    params,
-		IPALNodes: ipalNodes,
-	}
+	var ipalNodes types.IPALNodes
+	keeper.IterateIPALNodes(ctx, func(node types.IPALNode) bool {
+		ipalNodes = append(ipalNodes, node)
+		return false
+	})
+
+	var reports types.AvailabilityReports
+	keeper.IterateAvailabilityReports(ctx, func(report types.AvailabilityReport) bool {
+		reports = append(reports, report)
+		return false
+	})
+
+	var delegations types.Delegations
+	keeper.IterateAllDelegations(ctx, func(delegation types.Delegation) bool {
+		delegations = append(delegations, delegation)
+		return false
+	})
+
+	var keySets types.KeySets
+	keeper.IterateAllKeySets(ctx, func(set types.KeySet) bool {
+		keySets = append(keySets, set)
+		return false
+	})
+
+	return types.NewGenesisState(params, ipalNodes, reports, delegations, keySets)
 }
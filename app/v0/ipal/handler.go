@@ -4,6 +4,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
 )
@@ -16,6 +17,18 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgIPALNodeClaim:
 			return handleMsgIPALNodeClaim(ctx, k, msg)
+		case MsgIPALAvailabilityReport:
+			return handleMsgIPALAvailabilityReport(ctx, k, msg)
+		case MsgIPALNodeUnjail:
+			return handleMsgIPALNodeUnjail(ctx, k, msg)
+		case MsgIPALDelegate:
+			return handleMsgIPALDelegate(ctx, k, msg)
+		case MsgIPALUndelegate:
+			return handleMsgIPALUndelegate(ctx, k, msg)
+		case MsgIPALWithdrawRewards:
+			return handleMsgIPALWithdrawRewards(ctx, k, msg)
+		case MsgIPALSetCommission:
+			return handleMsgIPALSetCommission(ctx, k, msg)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -30,6 +43,10 @@ func handleMsgIPALNodeClaim(ctx sdk.Context, k Keeper, m MsgIPALNodeClaim) (*sdk
 		return nil, err
 	}
 
+	if err := k.ValidateEndpoints(ctx, m.Endpoints); err != nil {
+		return nil, err
+	}
+
 	acc, monikerExist := k.GetIPALNodeAddByMoniker(ctx, m.Moniker)
 	if monikerExist && !acc.Equals(m.OperatorAddress) {
 		return nil, sdkerrors.Wrapf(ErrMonikerExist, "moniker: [%s] already exist", m.Moniker)
@@ -50,7 +67,144 @@ func handleMsgIPALNodeClaim(ctx sdk.Context, k Keeper, m MsgIPALNodeClaim) (*sdk
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgIPALAvailabilityReport(ctx sdk.Context, k Keeper, m MsgIPALAvailabilityReport) (*sdk.Result, error) {
+	err := k.ReportAvailability(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAvailabilityReport,
+			sdk.NewAttribute(types.AttributeKeyReporter, m.Reporter.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALNodeUnjail(ctx sdk.Context, k Keeper, m MsgIPALNodeUnjail) (*sdk.Result, error) {
+	err := k.Unjail(ctx, m.OperatorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnjail,
+			sdk.NewAttribute(types.AttributeKeyOperator, m.OperatorAddress.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALDelegate(ctx sdk.Context, k Keeper, m MsgIPALDelegate) (*sdk.Result, error) {
+	err := k.Delegate(ctx, m.Delegator, m.Operator, m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, m.Delegator.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, m.Operator.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, m.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALUndelegate(ctx sdk.Context, k Keeper, m MsgIPALUndelegate) (*sdk.Result, error) {
+	err := k.Undelegate(ctx, m.Delegator, m.Operator, m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUndelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, m.Delegator.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, m.Operator.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, m.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALWithdrawRewards(ctx sdk.Context, k Keeper, m MsgIPALWithdrawRewards) (*sdk.Result, error) {
+	rewards, err := k.WithdrawRewards(ctx, m.Delegator, m.Operator)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawRewards,
+			sdk.NewAttribute(types.AttributeKeyDelegator, m.Delegator.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, m.Operator.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, rewards.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgIPALSetCommission(ctx sdk.Context, k Keeper, m MsgIPALSetCommission) (*sdk.Result, error) {
+	err := k.SetCommission(ctx, m.OperatorAddress, m.Commission)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetCommission,
+			sdk.NewAttribute(types.AttributeKeyOperator, m.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCommission, m.Commission.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// BeginBlocker shares out the delegator rewards among the ipal nodes, it must run before the
+// distribution module allocates the collected fees
+func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
+	k.AllocateDelegatorRewards(ctx)
+}
+
+// EndBlocker tallies the availability reports at the end of each report window and releases the
+// mature unbondings
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
+	k.TallyAvailability(ctx)
+
 	matureUnstakings := k.DequeueAllMatureUnBondingQueue(ctx, ctx.BlockHeader().Time)
 	for _, matureUnstaking := range matureUnstakings {
 		k.DoUnbond(ctx, matureUnstaking)
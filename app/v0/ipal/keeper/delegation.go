@@ -0,0 +1,325 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// GetDelegation returns the delegation of delegator to the node of operator
+func (k Keeper) GetDelegation(ctx sdk.Context, delegator, operator sdk.AccAddress) (delegation types.Delegation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetDelegationKey(operator, delegator))
+	if value == nil {
+		return delegation, false
+	}
+
+	return types.MustUnmarshalDelegation(k.cdc, value), true
+}
+
+// SetDelegation stores a delegation
+func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetDelegationKey(delegation.Operator, delegation.Delegator), types.MustMarshalDelegation(k.cdc, delegation))
+}
+
+func (k Keeper) removeDelegation(ctx sdk.Context, delegation types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegationKey(delegation.Operator, delegation.Delegator))
+}
+
+// IterateNodeDelegations iterates over the delegations to the node of operator until cb returns true
+func (k Keeper) IterateNodeDelegations(ctx sdk.Context, operator sdk.AccAddress, cb func(delegation types.Delegation) (stop bool)) {
+	k.iterateDelegations(ctx, types.GetNodeDelegationsKey(operator), cb)
+}
+
+// IterateAllDelegations iterates over all the delegations, grouped by node, until cb returns true
+func (k Keeper) IterateAllDelegations(ctx sdk.Context, cb func(delegation types.Delegation) (stop bool)) {
+	k.iterateDelegations(ctx, types.DelegationKey, cb)
+}
+
+// GetNodeDelegations returns the delegations to the node of operator
+func (k Keeper) GetNodeDelegations(ctx sdk.Context, operator sdk.AccAddress) (delegations types.Delegations) {
+	k.IterateNodeDelegations(ctx, operator, func(delegation types.Delegation) bool {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	return delegations
+}
+
+func (k Keeper) iterateDelegations(ctx sdk.Context, prefix []byte, cb func(delegation types.Delegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(types.MustUnmarshalDelegation(k.cdc, iterator.Value())) {
+			break
+		}
+	}
+}
+
+// Delegate bonds amount of delegator to the node of operator, the pending rewards of a previous
+// delegation to the same node are withdrawn first
+func (k Keeper) Delegate(ctx sdk.Context, delegator, operator sdk.AccAddress, amount sdk.Coin) error {
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+	if node.Jailed {
+		return sdkerrors.Wrapf(types.ErrIPALNodeJailed, "operator: %s", operator)
+	}
+
+	if err := k.bond(ctx, delegator, amount); err != nil {
+		return err
+	}
+
+	delegation, found := k.GetDelegation(ctx, delegator, operator)
+	if found {
+		if _, err := k.withdrawDelegationRewards(ctx, node, delegation); err != nil {
+			return err
+		}
+	} else {
+		delegation = types.NewDelegation(delegator, operator, sdk.NewCoin(amount.Denom, sdk.ZeroInt()), nil)
+	}
+
+	delegation.Amount = delegation.Amount.Add(amount)
+	delegation.RewardsPerShare = node.RewardsPerShare
+	k.SetDelegation(ctx, delegation)
+
+	updated := node
+	updated.Delegated = node.Delegated.Add(amount)
+	k.updateIPALNode(ctx, node, updated)
+	return nil
+}
+
+// Undelegate unbonds amount of the delegation of delegator to the node of operator through the
+// unbonding queue, the pending rewards of the delegation are withdrawn first
+func (k Keeper) Undelegate(ctx sdk.Context, delegator, operator sdk.AccAddress, amount sdk.Coin) error {
+	delegation, found := k.GetDelegation(ctx, delegator, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrDelegationNotFound, "delegator: %s, operator: %s", delegator, operator)
+	}
+	if delegation.Amount.IsLT(amount) {
+		return sdkerrors.Wrapf(types.ErrInsufficientDelegation, "delegation: %s, undelegation: %s", delegation.Amount, amount)
+	}
+
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+
+	if _, err := k.withdrawDelegationRewards(ctx, node, delegation); err != nil {
+		return err
+	}
+
+	delegation.Amount = delegation.Amount.Sub(amount)
+	if delegation.Amount.IsZero() {
+		k.removeDelegation(ctx, delegation)
+	} else {
+		delegation.RewardsPerShare = node.RewardsPerShare
+		k.SetDelegation(ctx, delegation)
+	}
+
+	updated := node
+	updated.Delegated = node.Delegated.Sub(amount)
+	k.updateIPALNode(ctx, node, updated)
+
+	k.toUnbondingQueue(ctx, delegator, amount)
+	return nil
+}
+
+// WithdrawRewards withdraws the pending rewards of the delegation of delegator to the node of
+// operator, and the outstanding commission of the node when delegator is its operator
+func (k Keeper) WithdrawRewards(ctx sdk.Context, delegator, operator sdk.AccAddress) (rewards sdk.Coins, err error) {
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+
+	delegation, found := k.GetDelegation(ctx, delegator, operator)
+	if found {
+		rewards, err = k.withdrawDelegationRewards(ctx, node, delegation)
+		if err != nil {
+			return nil, err
+		}
+
+		delegation.RewardsPerShare = node.RewardsPerShare
+		k.SetDelegation(ctx, delegation)
+	}
+
+	if delegator.Equals(operator) {
+		commission, err := k.withdrawCommission(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		rewards = rewards.Add(commission)
+	}
+
+	if rewards.IsZero() {
+		return nil, sdkerrors.Wrapf(types.ErrNoRewards, "delegator: %s, operator: %s", delegator, operator)
+	}
+	return rewards, nil
+}
+
+// GetPendingRewards returns the rewards of the delegation of delegator to the node of operator, and
+// the outstanding commission of the node when delegator is its operator
+func (k Keeper) GetPendingRewards(ctx sdk.Context, delegator, operator sdk.AccAddress) (rewards, commission sdk.DecCoins, err error) {
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return nil, nil, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+
+	if delegation, found := k.GetDelegation(ctx, delegator, operator); found {
+		rewards = delegation.PendingRewards(node.RewardsPerShare)
+	}
+	if delegator.Equals(operator) {
+		commission = node.OutstandingCommission
+	}
+	return rewards, commission, nil
+}
+
+// withdrawDelegationRewards sends the pending rewards of a delegation to the delegator, the caller
+// must then update the rewards per share of the delegation. The decimal dust is left in the module
+// account.
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, node types.IPALNode, delegation types.Delegation) (sdk.Coins, error) {
+	rewards, _ := delegation.PendingRewards(node.RewardsPerShare).TruncateDecimal()
+	if rewards.IsZero() {
+		return nil, nil
+	}
+
+	err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, delegation.Delegator, rewards)
+	if err != nil {
+		return nil, err
+	}
+	return rewards, nil
+}
+
+func (k Keeper) withdrawCommission(ctx sdk.Context, node types.IPALNode) (sdk.Coins, error) {
+	commission, change := node.OutstandingCommission.TruncateDecimal()
+	if commission.IsZero() {
+		return nil, nil
+	}
+
+	err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, node.OperatorAddress, commission)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := node
+	updated.OutstandingCommission = change
+	k.updateIPALNode(ctx, node, updated)
+	return commission, nil
+}
+
+// SetCommission sets the share of the delegator rewards of the node of operator kept by the operator,
+// it applies to the rewards allocated from the next block. The commission is at most MaxCommission,
+// and is changed by at most MaxCommissionChangeRate once every CommissionUpdateInterval.
+func (k Keeper) SetCommission(ctx sdk.Context, operator sdk.AccAddress, commission sdk.Dec) error {
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+
+	if maxCommission := k.GetMaxCommission(ctx); commission.GT(maxCommission) {
+		return sdkerrors.Wrapf(types.ErrInvalidCommission, "commission %s is greater than the max commission %s", commission, maxCommission)
+	}
+	if maxChangeRate := k.GetMaxCommissionChangeRate(ctx); commission.Sub(node.Commission).Abs().GT(maxChangeRate) {
+		return sdkerrors.Wrapf(types.ErrInvalidCommission, "commission change from %s to %s is greater than the max change rate %s",
+			node.Commission, commission, maxChangeRate)
+	}
+	if !node.CommissionUpdateTime.IsZero() && ctx.BlockTime().Before(node.CommissionUpdateTime.Add(types.CommissionUpdateInterval)) {
+		return sdkerrors.Wrapf(types.ErrInvalidCommission, "commission can not be changed until %s", node.CommissionUpdateTime.Add(types.CommissionUpdateInterval))
+	}
+
+	updated := node
+	updated.Commission = commission
+	updated.CommissionUpdateTime = ctx.BlockTime()
+	k.updateIPALNode(ctx, node, updated)
+	return nil
+}
+
+// AllocateDelegatorRewards moves DelegatorRewardsShare of the fees and inflation collected by the fee
+// collector to the module account and shares it out among the ranked nodes by delegated coins. The
+// operator of each node keeps its commission, the rest is added to the rewards per share of the node.
+func (k Keeper) AllocateDelegatorRewards(ctx sdk.Context) {
+	share := k.GetDelegatorRewardsShare(ctx)
+	if !share.IsPositive() {
+		return
+	}
+
+	nodes := k.GetAllIPALNodes(ctx)
+	totalDelegated := sdk.ZeroInt()
+	for _, node := range nodes {
+		totalDelegated = totalDelegated.Add(node.Delegated.Amount)
+	}
+	if !totalDelegated.IsPositive() {
+		return
+	}
+
+	collected := k.supplyKeeper.GetModuleAccount(ctx, k.feeCollectorName).GetCoins()
+	rewards, _ := sdk.NewDecCoins(collected).MulDecTruncate(share).TruncateDecimal()
+	if rewards.IsZero() {
+		return
+	}
+
+	err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, k.feeCollectorName, types.ModuleName, rewards)
+	if err != nil {
+		panic(fmt.Sprintf("failed to allocate the ipal delegator rewards: %v", err))
+	}
+
+	for _, node := range nodes {
+		if !node.Delegated.IsPositive() {
+			continue
+		}
+
+		nodeRewards := sdk.NewDecCoins(rewards).MulDecTruncate(node.Delegated.Amount.ToDec().QuoTruncate(totalDelegated.ToDec()))
+		commission := nodeRewards.MulDecTruncate(node.Commission)
+
+		updated := node
+		updated.OutstandingCommission = node.OutstandingCommission.Add(commission)
+		updated.RewardsPerShare = node.RewardsPerShare.Add(nodeRewards.Sub(commission).QuoDecTruncate(node.Delegated.Amount.ToDec()))
+		k.updateIPALNode(ctx, node, updated)
+	}
+}
+
+// unbondDelegations withdraws the rewards of all the delegations to a node and sends them to the
+// unbonding queue, along with the outstanding commission, when the node is deleted
+func (k Keeper) unbondDelegations(ctx sdk.Context, node types.IPALNode) error {
+	for _, delegation := range k.GetNodeDelegations(ctx, node.OperatorAddress) {
+		if _, err := k.withdrawDelegationRewards(ctx, node, delegation); err != nil {
+			return err
+		}
+		k.toUnbondingQueue(ctx, delegation.Delegator, delegation.Amount)
+		k.removeDelegation(ctx, delegation)
+	}
+
+	_, err := k.withdrawCommission(ctx, node)
+	return err
+}
+
+// slashDelegations slashes fraction of all the delegations to a node, their pending rewards are
+// withdrawn first, and returns the total slashed amount
+func (k Keeper) slashDelegations(ctx sdk.Context, node types.IPALNode, fraction sdk.Dec) sdk.Coin {
+	slashed := sdk.NewCoin(sdk.NativeTokenName, sdk.ZeroInt())
+	for _, delegation := range k.GetNodeDelegations(ctx, node.OperatorAddress) {
+		if _, err := k.withdrawDelegationRewards(ctx, node, delegation); err != nil {
+			panic(fmt.Sprintf("failed to withdraw the rewards of the delegation of %s to the ipal node %s: %v",
+				delegation.Delegator, node.OperatorAddress, err))
+		}
+
+		amount := sdk.NewCoin(delegation.Amount.Denom, delegation.Amount.Amount.ToDec().Mul(fraction).TruncateInt())
+		slashed = slashed.Add(amount)
+
+		delegation.Amount = delegation.Amount.Sub(amount)
+		if delegation.Amount.IsZero() {
+			k.removeDelegation(ctx, delegation)
+		} else {
+			delegation.RewardsPerShare = node.RewardsPerShare
+			k.SetDelegation(ctx, delegation)
+		}
+	}
+	return slashed
+}
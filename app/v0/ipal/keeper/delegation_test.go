@@ -0,0 +1,142 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func pnch(amount int64) sdk.Coin {
+	return sdk.NewInt64Coin(sdk.NativeTokenName, amount)
+}
+
+func TestDelegate(t *testing.T) {
+	ctx, k, sk, _ := createTestInput(t)
+
+	require.True(t, types.ErrIPALNodeNotFound.Is(k.Delegate(ctx, delegator, operator, pnch(500))))
+	claim(t, ctx, k, 1000)
+
+	require.NoError(t, k.Delegate(ctx, delegator, operator, pnch(300)))
+	require.NoError(t, k.Delegate(ctx, delegator, operator, pnch(200)))
+	delegation, found := k.GetDelegation(ctx, delegator, operator)
+	require.True(t, found)
+	require.Equal(t, pnch(500), delegation.Amount)
+
+	// the delegated coins count in the ranking of the node
+	nodes := k.GetAllIPALNodes(ctx)
+	require.Len(t, nodes, 1)
+	require.Equal(t, pnch(500), nodes[0].Delegated)
+	require.Equal(t, pnch(1500), nodes[0].TotalBond())
+	require.Equal(t, pnch(1500), sk.GetModuleAccount(ctx, types.ModuleName).GetCoins()[0])
+
+	// the operator updating its node keeps the delegations
+	claim(t, ctx, k, 2000)
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.Equal(t, pnch(500), node.Delegated)
+
+	require.True(t, types.ErrInsufficientDelegation.Is(k.Undelegate(ctx, delegator, operator, pnch(600))))
+	require.True(t, types.ErrDelegationNotFound.Is(k.Undelegate(ctx, operator, operator, pnch(100))))
+	require.NoError(t, k.Undelegate(ctx, delegator, operator, pnch(200)))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, pnch(300), node.Delegated)
+
+	endTime := ctx.BlockHeader().Time.Add(time.Hour)
+	require.Equal(t, types.UnBondings{types.NewUnBonding(delegator, pnch(200), endTime)}, k.GetUnBondingQueueTimeSlice(ctx, endTime))
+
+	// the delegations are unbonded along with the bond when the node is deleted
+	claim(t, ctx, k, 0)
+	_, found = k.GetIPALNode(ctx, operator)
+	require.False(t, found)
+	require.Empty(t, k.GetNodeDelegations(ctx, operator))
+	require.Equal(t, types.UnBondings{
+		types.NewUnBonding(delegator, pnch(200), endTime),
+		types.NewUnBonding(delegator, pnch(300), endTime),
+		types.NewUnBonding(operator, pnch(2000), endTime),
+	}, k.GetUnBondingQueueTimeSlice(ctx, endTime))
+}
+
+func TestDelegateToJailedNode(t *testing.T) {
+	ctx, k, _, dk := createTestInput(t)
+	claim(t, ctx, k, 1000)
+	require.NoError(t, k.Delegate(ctx, delegator, operator, pnch(500)))
+
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	require.NoError(t, k.ReportAvailability(ctx, report(validator, false)))
+	k.TallyAvailability(ctx.WithBlockHeight(10))
+
+	// the delegations are slashed along with the bond
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.True(t, node.Jailed)
+	require.Equal(t, pnch(900), node.Bond)
+	require.Equal(t, pnch(450), node.Delegated)
+	delegation, _ := k.GetDelegation(ctx, delegator, operator)
+	require.Equal(t, pnch(450), delegation.Amount)
+	require.Equal(t, sdk.NewCoins(pnch(150)), dk.funded)
+
+	require.True(t, types.ErrIPALNodeJailed.Is(k.Delegate(ctx, delegator, operator, pnch(100))))
+	require.NoError(t, k.Undelegate(ctx, delegator, operator, pnch(450)))
+	_, found := k.GetDelegation(ctx, delegator, operator)
+	require.False(t, found)
+}
+
+func TestDelegatorRewards(t *testing.T) {
+	ctx, k, sk, _ := createTestInput(t)
+	claim(t, ctx, k, 1000)
+	require.NoError(t, k.Delegate(ctx, delegator, operator, pnch(500)))
+	require.NoError(t, k.SetCommission(ctx, operator, sdk.NewDecWithPrec(2, 1)))
+
+	// half of the collected fees goes to the delegators, the operator keeps a 20% commission
+	require.NoError(t, sk.SendCoinsFromAccountToModule(ctx, operator, auth.FeeCollectorName, sdk.NewCoins(pnch(1000))))
+	k.AllocateDelegatorRewards(ctx)
+	require.Equal(t, sdk.NewCoins(pnch(500)), sk.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins())
+
+	rewards, commission, err := k.GetPendingRewards(ctx, delegator, operator)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewCoins(pnch(400))), rewards)
+	require.Empty(t, commission)
+	_, commission, err = k.GetPendingRewards(ctx, operator, operator)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewCoins(pnch(100))), commission)
+
+	withdrawn, err := k.WithdrawRewards(ctx, delegator, operator)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(pnch(400)), withdrawn)
+	_, err = k.WithdrawRewards(ctx, delegator, operator)
+	require.True(t, types.ErrNoRewards.Is(err))
+
+	withdrawn, err = k.WithdrawRewards(ctx, operator, operator)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(pnch(100)), withdrawn)
+	require.Equal(t, sdk.NewCoins(pnch(1500)), sk.GetModuleAccount(ctx, types.ModuleName).GetCoins())
+
+	// a new delegation does not earn the rewards allocated before it
+	require.NoError(t, k.Delegate(ctx, operator, operator, pnch(500)))
+	rewards, _, err = k.GetPendingRewards(ctx, operator, operator)
+	require.NoError(t, err)
+	require.Empty(t, rewards)
+}
+
+func TestSetCommission(t *testing.T) {
+	ctx, k, _, _ := createTestInput(t)
+	claim(t, ctx, k, 1000)
+
+	// the commission is bounded by the max commission and the max change rate
+	require.True(t, types.ErrInvalidCommission.Is(k.SetCommission(ctx, operator, sdk.NewDecWithPrec(6, 1))))
+	require.True(t, types.ErrInvalidCommission.Is(k.SetCommission(ctx, operator, sdk.NewDecWithPrec(3, 1))))
+	require.NoError(t, k.SetCommission(ctx, operator, sdk.NewDecWithPrec(2, 1)))
+
+	// and is changed at most once every update interval
+	require.True(t, types.ErrInvalidCommission.Is(k.SetCommission(ctx, operator, sdk.NewDecWithPrec(3, 1))))
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(types.CommissionUpdateInterval))
+	require.NoError(t, k.SetCommission(ctx, operator, sdk.NewDecWithPrec(3, 1)))
+
+	node, found := k.GetIPALNode(ctx, operator)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(3, 1), node.Commission)
+	require.Equal(t, ctx.BlockTime(), node.CommissionUpdateTime)
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
@@ -12,26 +13,43 @@ import (
 
 // Keeper defines the ipal store
 type Keeper struct {
-	storeKey     sdk.StoreKey
-	cdc          *codec.Codec
-	supplyKeeper types.SupplyKeeper
-	paramstore   params.Subspace
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	supplyKeeper     types.SupplyKeeper
+	stakingKeeper    types.StakingKeeper
+	distrKeeper      types.DistributionKeeper
+	paramstore       params.Subspace
+	feeCollectorName string
+	hooks            types.IPALHooks
 }
 
 // NewKeeper creates a new ipal Keeper instance
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, supplyKeeper types.SupplyKeeper, paramstore params.Subspace) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, supplyKeeper types.SupplyKeeper, stakingKeeper types.StakingKeeper,
+	distrKeeper types.DistributionKeeper, paramstore params.Subspace, feeCollectorName string) Keeper {
 	if addr := supplyKeeper.GetModuleAddress(types.ModuleName); addr == nil {
 		panic(fmt.Sprintf("%s module account has not been set", types.ModuleName))
 	}
 
 	return Keeper{
-		storeKey:     storeKey,
-		cdc:          cdc,
-		supplyKeeper: supplyKeeper,
-		paramstore:   paramstore.WithKeyTable(ParamKeyTable()),
+		storeKey:         storeKey,
+		cdc:              cdc,
+		supplyKeeper:     supplyKeeper,
+		stakingKeeper:    stakingKeeper,
+		distrKeeper:      distrKeeper,
+		paramstore:       paramstore.WithKeyTable(ParamKeyTable()),
+		feeCollectorName: feeCollectorName,
 	}
 }
 
+// SetHooks sets the hooks called on the lifecycle events of the ipal nodes
+func (k *Keeper) SetHooks(h types.IPALHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set ipal hooks twice")
+	}
+	k.hooks = h
+	return k
+}
+
 // GetIPALNode returns a IPAL object by operator address
 func (k Keeper) GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj types.IPALNode, found bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -82,10 +100,12 @@ func (k Keeper) delIPALNodeByMonikerIndex(ctx sdk.Context, moniker string) {
 	store.Delete(types.GetIPALNodeByMonikerKey(moniker))
 }
 
-// CreateIPALNode sets a new IPAL object
+// CreateIPALNode sets a new IPAL object, a jailed node is left out of the ranking by bond
 func (k Keeper) CreateIPALNode(ctx sdk.Context, node types.IPALNode) {
 	k.setIPALNode(ctx, node)
-	k.setIPALNodeByBond(ctx, node)
+	if !node.Jailed {
+		k.setIPALNodeByBond(ctx, node)
+	}
 	k.setIPALNodeByMonikerIndex(ctx, node)
 }
 
@@ -93,7 +113,9 @@ func (k Keeper) updateIPALNode(ctx sdk.Context, old types.IPALNode, new types.IP
 	k.setIPALNode(ctx, new)
 
 	k.delIPALNodeByBond(ctx, old)
-	k.setIPALNodeByBond(ctx, new)
+	if !new.Jailed {
+		k.setIPALNodeByBond(ctx, new)
+	}
 
 	k.delIPALNodeByMonikerIndex(ctx, old.Moniker)
 	k.setIPALNodeByMonikerIndex(ctx, new)
@@ -103,12 +125,27 @@ func (k Keeper) deleteIPALNode(ctx sdk.Context, obj types.IPALNode) {
 	k.delIPALNode(ctx, obj.OperatorAddress)
 	k.delIPALNodeByBond(ctx, obj)
 	k.delIPALNodeByMonikerIndex(ctx, obj.Moniker)
+
+	if k.hooks != nil {
+		k.hooks.AfterIPALNodeRemoved(ctx, obj.OperatorAddress)
+	}
 }
 
 func (k Keeper) bond(ctx sdk.Context, aa sdk.AccAddress, amt sdk.Coin) error {
 	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, aa, types.ModuleName, sdk.Coins{amt})
 }
 
+// ValidateEndpoints checks the endpoints against the registered endpoint types
+func (k Keeper) ValidateEndpoints(ctx sdk.Context, endpoints types.Endpoints) error {
+	endpointTypes := k.GetEndpointTypes(ctx)
+	for _, e := range endpoints {
+		if err := endpointTypes.ValidateAddress(e.Type, e.Endpoint); err != nil {
+			return sdkerrors.Wrap(types.ErrEndpointsFormat, err.Error())
+		}
+	}
+	return nil
+}
+
 // DoIPALNodeClaim - updates ipal object and bond coins
 func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err error) {
 	minBond := k.GetMinBond(ctx)
@@ -124,9 +161,15 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 				k.toUnbondingQueue(ctx, m.OperatorAddress, n.Bond.Sub(m.Bond))
 			}
 
-			ipalNode := types.NewIPALNode(m.OperatorAddress, m.Moniker, m.Website, m.Details, m.Extension, m.Endpoints, m.Bond)
+			ipalNode := n
+			ipalNode.Moniker, ipalNode.Website, ipalNode.Details = m.Moniker, m.Website, m.Details
+			ipalNode.Extension, ipalNode.Endpoints, ipalNode.Bond = m.Extension, m.Endpoints, m.Bond
+			k.publishKeys(ctx, &ipalNode, m.PublicKeys)
 			k.updateIPALNode(ctx, n, ipalNode)
 		} else {
+			if err := k.unbondDelegations(ctx, n); err != nil {
+				return err
+			}
 			k.toUnbondingQueue(ctx, m.OperatorAddress, n.Bond)
 			k.deleteIPALNode(ctx, n)
 		}
@@ -138,6 +181,7 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 			}
 
 			ipalNode := types.NewIPALNode(m.OperatorAddress, m.Moniker, m.Website, m.Details, m.Extension, m.Endpoints, m.Bond)
+			k.publishKeys(ctx, &ipalNode, m.PublicKeys)
 			k.CreateIPALNode(ctx, ipalNode)
 		} else {
 			return sdkerrors.Wrapf(types.ErrBondInsufficient, "bond insufficient, min bond: %s, actual bond: %s", minBond.String(), m.Bond.String())
@@ -147,7 +191,7 @@ func (k Keeper) DoIPALNodeClaim(ctx sdk.Context, m types.MsgIPALNodeClaim) (err
 	return nil
 }
 
-// GetAllIPALNodes - lists all ipal objects
+// GetAllIPALNodes - lists all ipal objects ranked by bond, the jailed nodes are not ranked
 func (k Keeper) GetAllIPALNodes(ctx sdk.Context) (ipalNodes types.IPALNodes) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.IPALNodeByBondKey)
@@ -159,3 +203,86 @@ func (k Keeper) GetAllIPALNodes(ctx sdk.Context) (ipalNodes types.IPALNodes) {
 	}
 	return ipalNodes
 }
+
+// ListIPALNodes lists the ranked ipal objects ordered, filtered and paginated by params
+func (k Keeper) ListIPALNodes(ctx sdk.Context, params types.QueryIPALNodeListParams) (types.IPALNodes, error) {
+	store := ctx.KVStore(k.storeKey)
+	byMoniker := params.OrderBy == types.OrderByMoniker
+
+	var cursor types.IPALNode
+	if !params.After.Empty() {
+		node, found := k.GetIPALNode(ctx, params.After)
+		if !found {
+			return nil, sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "cursor operator: %s", params.After)
+		}
+		cursor = node
+	}
+
+	var start, end []byte
+	if byMoniker {
+		start = append(sdk.CopyBytes(types.IPALNodeByMonikerKey), params.MonikerPrefix...)
+		end = sdk.PrefixEndBytes(start)
+		if !params.After.Empty() {
+			start = append(types.GetIPALNodeByMonikerKey(cursor.Moniker), 0x00)
+		}
+	} else {
+		start, end = types.IPALNodeByBondKey, sdk.PrefixEndBytes(types.IPALNodeByBondKey)
+		if !params.After.Empty() {
+			end = types.GetIPALNodeByBondKey(cursor)
+		}
+	}
+
+	ipalNodes := types.IPALNodes{}
+	if bytes.Compare(start, end) >= 0 {
+		return ipalNodes, nil
+	}
+
+	var iterator sdk.Iterator
+	if byMoniker {
+		iterator = store.Iterator(start, end)
+	} else {
+		iterator = store.ReverseIterator(start, end)
+	}
+	defer iterator.Close()
+
+	skipped := 0
+	for ; iterator.Valid(); iterator.Next() {
+		var node types.IPALNode
+		if byMoniker {
+			n, found := k.GetIPALNode(ctx, iterator.Value())
+			if !found || n.Jailed {
+				continue
+			}
+			node = n
+		} else {
+			node = types.MustUnmarshalIPALNode(k.cdc, iterator.Value())
+		}
+
+		if !params.Matches(node) {
+			continue
+		}
+		if skipped < params.Offset {
+			skipped++
+			continue
+		}
+
+		ipalNodes = append(ipalNodes, node)
+		if params.Limit > 0 && len(ipalNodes) == params.Limit {
+			break
+		}
+	}
+	return ipalNodes, nil
+}
+
+// IterateIPALNodes iterates over all the ipal objects, including the jailed ones, until cb returns true
+func (k Keeper) IterateIPALNodes(ctx sdk.Context, cb func(node types.IPALNode) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.IPALNodeKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(types.MustUnmarshalIPALNode(k.cdc, iterator.Value())) {
+			break
+		}
+	}
+}
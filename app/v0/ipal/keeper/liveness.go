@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// IsReporter returns whether the account can report the availability of the ipal nodes, i.e. it
+// is the operator of a bonded validator or one of the reporters of the params
+func (k Keeper) IsReporter(ctx sdk.Context, addr sdk.AccAddress) bool {
+	for _, reporter := range k.GetReporters(ctx) {
+		if reporter.Equals(addr) {
+			return true
+		}
+	}
+
+	validator := k.stakingKeeper.Validator(ctx, sdk.ValAddress(addr))
+	return validator != nil && validator.IsBonded() && !validator.IsJailed()
+}
+
+// SetAvailabilityReport stores an availability report, it replaces the previous report of the
+// reporter about the same node in the current window
+func (k Keeper) SetAvailabilityReport(ctx sdk.Context, report types.AvailabilityReport) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(report.Available)
+	store.Set(types.GetAvailabilityReportKey(report.Operator, report.Reporter), bz)
+}
+
+// IterateAvailabilityReports iterates over the availability reports of the current window, grouped
+// by node, until cb returns true
+func (k Keeper) IterateAvailabilityReports(ctx sdk.Context, cb func(report types.AvailabilityReport) (stop bool)) {
+	k.iterateAvailabilityReports(ctx, types.AvailabilityKey, cb)
+}
+
+// GetNodeAvailabilityReports returns the availability reports of the current window about the node of operator
+func (k Keeper) GetNodeAvailabilityReports(ctx sdk.Context, operator sdk.AccAddress) (reports types.AvailabilityReports) {
+	k.iterateAvailabilityReports(ctx, append(types.AvailabilityKey, operator...), func(report types.AvailabilityReport) bool {
+		reports = append(reports, report)
+		return false
+	})
+	return reports
+}
+
+func (k Keeper) iterateAvailabilityReports(ctx sdk.Context, prefix []byte, cb func(report types.AvailabilityReport) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var available bool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &available)
+		operator, reporter := types.SplitAvailabilityReportKey(iterator.Key())
+		if cb(types.NewAvailabilityReport(operator, reporter, available)) {
+			break
+		}
+	}
+}
+
+// ReportAvailability stores the availability reports of a reporter, only the nodes that are not
+// jailed can be reported
+func (k Keeper) ReportAvailability(ctx sdk.Context, msg types.MsgIPALAvailabilityReport) error {
+	if !k.IsReporter(ctx, msg.Reporter) {
+		return sdkerrors.Wrapf(types.ErrNotReporter, "reporter: %s", msg.Reporter)
+	}
+
+	for _, report := range msg.Reports {
+		node, found := k.GetIPALNode(ctx, report.Operator)
+		if !found {
+			return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", report.Operator)
+		}
+		if node.Jailed {
+			return sdkerrors.Wrapf(types.ErrIPALNodeJailed, "operator: %s", report.Operator)
+		}
+
+		k.SetAvailabilityReport(ctx, types.NewAvailabilityReport(report.Operator, msg.Reporter, report.Available))
+	}
+
+	return nil
+}
+
+type nodeTally struct {
+	operator  sdk.AccAddress
+	available uint64
+	total     uint64
+}
+
+// TallyAvailability closes the report window at the last block of each window: the nodes reported
+// at least MinReports times with an availability below MinAvailability are slashed and jailed, then
+// all the reports are deleted
+func (k Keeper) TallyAvailability(ctx sdk.Context) {
+	if ctx.BlockHeight()%k.GetReportWindow(ctx) != 0 {
+		return
+	}
+
+	var tallies []*nodeTally
+	var keys [][]byte
+	k.IterateAvailabilityReports(ctx, func(report types.AvailabilityReport) bool {
+		if len(tallies) == 0 || !tallies[len(tallies)-1].operator.Equals(report.Operator) {
+			tallies = append(tallies, &nodeTally{operator: report.Operator})
+		}
+		tally := tallies[len(tallies)-1]
+		tally.total++
+		if report.Available {
+			tally.available++
+		}
+		keys = append(keys, types.GetAvailabilityReportKey(report.Operator, report.Reporter))
+		return false
+	})
+
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	minReports, minAvailability := k.GetMinReports(ctx), k.GetMinAvailability(ctx)
+	for _, tally := range tallies {
+		if tally.total < minReports {
+			continue
+		}
+
+		availability := sdk.NewDec(int64(tally.available)).QuoInt64(int64(tally.total))
+		if availability.GTE(minAvailability) {
+			continue
+		}
+
+		node, found := k.GetIPALNode(ctx, tally.operator)
+		if !found || node.Jailed {
+			continue
+		}
+		k.slashAndJail(ctx, node, availability)
+	}
+}
+
+// slashAndJail slashes SlashFraction of the bond of the node and of the delegations to it, sent to
+// the community pool or burned, and jails the node for JailDuration
+func (k Keeper) slashAndJail(ctx sdk.Context, node types.IPALNode, availability sdk.Dec) {
+	fraction := k.GetSlashFraction(ctx)
+	slashedBond := sdk.NewCoin(node.Bond.Denom, node.Bond.Amount.ToDec().Mul(fraction).TruncateInt())
+	slashedDelegations := k.slashDelegations(ctx, node, fraction)
+	slashed := slashedBond.Add(slashedDelegations)
+	if slashed.IsPositive() {
+		var err error
+		if k.GetSlashToCommunityPool(ctx) {
+			err = k.distrKeeper.FundCommunityPool(ctx, sdk.NewCoins(slashed), k.supplyKeeper.GetModuleAddress(types.ModuleName))
+		} else {
+			err = k.supplyKeeper.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(slashed))
+		}
+		if err != nil {
+			// the bond of the node is held by the module account
+			panic(fmt.Sprintf("failed to slash the bond of the ipal node %s: %v", node.OperatorAddress, err))
+		}
+	}
+
+	jailed := node
+	jailed.Bond = node.Bond.Sub(slashedBond)
+	jailed.Delegated = node.Delegated.Sub(slashedDelegations)
+	jailed.Jailed = true
+	jailed.JailedUntil = ctx.BlockHeader().Time.Add(k.GetJailDuration(ctx))
+	k.updateIPALNode(ctx, node, jailed)
+
+	ctx.Logger().Info(fmt.Sprintf("ipal node %s slashed %s and jailed until %s, availability %s",
+		node.OperatorAddress, slashed, jailed.JailedUntil, availability))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlash,
+			sdk.NewAttribute(types.AttributeKeyOperator, node.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyAvailability, availability.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, slashed.String()),
+			sdk.NewAttribute(types.AttributeKeyJailedUntil, jailed.JailedUntil.String()),
+		),
+	)
+}
+
+// Unjail puts a jailed node back in the ranking once its jail duration is over, its bond must be
+// at least MinBond
+func (k Keeper) Unjail(ctx sdk.Context, operator sdk.AccAddress) error {
+	node, found := k.GetIPALNode(ctx, operator)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotFound, "operator: %s", operator)
+	}
+	if !node.Jailed {
+		return sdkerrors.Wrapf(types.ErrIPALNodeNotJailed, "operator: %s", operator)
+	}
+	if ctx.BlockHeader().Time.Before(node.JailedUntil) {
+		return sdkerrors.Wrapf(types.ErrStillJailed, "jailed until %s", node.JailedUntil)
+	}
+	if minBond := k.GetMinBond(ctx); node.Bond.IsLT(minBond) {
+		return sdkerrors.Wrapf(types.ErrBondInsufficient, "bond insufficient, min bond: %s, actual bond: %s", minBond, node.Bond)
+	}
+
+	unjailed := node
+	unjailed.Jailed = false
+	unjailed.JailedUntil = time.Time{}
+	k.updateIPALNode(ctx, node, unjailed)
+	return nil
+}
@@ -0,0 +1,194 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	stakingexported "github.com/netcloth/netcloth-chain/app/v0/staking/exported"
+	"github.com/netcloth/netcloth-chain/app/v0/supply"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+var (
+	operator  = sdk.AccAddress("operator____________")
+	reporter  = sdk.AccAddress("reporter____________")
+	validator = sdk.AccAddress("validator___________")
+	delegator = sdk.AccAddress("delegator___________")
+)
+
+type bondedValidator struct {
+	stakingexported.ValidatorI
+}
+
+func (bondedValidator) IsBonded() bool { return true }
+func (bondedValidator) IsJailed() bool { return false }
+
+type mockStakingKeeper struct{}
+
+func (mockStakingKeeper) Validator(_ sdk.Context, addr sdk.ValAddress) stakingexported.ValidatorI {
+	if sdk.AccAddress(addr).Equals(validator) {
+		return bondedValidator{}
+	}
+	return nil
+}
+
+type mockDistrKeeper struct {
+	funded sdk.Coins
+}
+
+func (k *mockDistrKeeper) FundCommunityPool(_ sdk.Context, amount sdk.Coins, _ sdk.AccAddress) error {
+	k.funded = k.funded.Add(amount)
+	return nil
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, supply.Keeper, *mockDistrKeeper) {
+	keys := sdk.NewKVStoreKeys(auth.StoreKey, supply.StoreKey, params.StoreKey, types.StoreKey)
+	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	for _, key := range tkeys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeTransient, db)
+	}
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
+	ak := auth.NewAccountKeeper(cdc, keys[auth.StoreKey], pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), nil)
+	sk := supply.NewKeeper(cdc, keys[supply.StoreKey], ak, bk, map[string][]string{
+		types.ModuleName:      {supply.Burner},
+		auth.FeeCollectorName: nil,
+	})
+	sk.SetSupply(ctx, supply.NewSupply(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10000))))
+
+	dk := &mockDistrKeeper{}
+	k := NewKeeper(keys[types.StoreKey], cdc, sk, mockStakingKeeper{}, dk, pk.Subspace(DefaultParamspace), auth.FeeCollectorName)
+	k.SetParams(ctx, types.NewParams(time.Hour, sdk.NewInt64Coin(sdk.NativeTokenName, 100), 10, 2,
+		sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 1), time.Hour, true, []sdk.AccAddress{reporter}, sdk.NewDecWithPrec(5, 1), nil,
+		sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(2, 1)))
+
+	for _, addr := range []sdk.AccAddress{operator, delegator} {
+		acc := ak.NewAccountWithAddress(ctx, addr)
+		require.NoError(t, acc.SetCoins(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10000))))
+		ak.SetAccount(ctx, acc)
+	}
+
+	return ctx, k, sk, dk
+}
+
+func claim(t *testing.T, ctx sdk.Context, k Keeper, amount int64) {
+	endpoints := types.Endpoints{types.NewEndpoint(1, "http://1.1.1.1")}
+	msg := types.NewMsgIPALNodeClaim(operator, "moniker", "", "", "", endpoints, sdk.NewInt64Coin(sdk.NativeTokenName, amount), nil)
+	require.NoError(t, k.DoIPALNodeClaim(ctx, msg))
+}
+
+func report(reporter sdk.AccAddress, available bool) types.MsgIPALAvailabilityReport {
+	return types.NewMsgIPALAvailabilityReport(reporter, []types.NodeAvailability{types.NewNodeAvailability(operator, available)})
+}
+
+func TestReportAvailability(t *testing.T) {
+	ctx, k, _, _ := createTestInput(t)
+
+	require.True(t, types.ErrIPALNodeNotFound.Is(k.ReportAvailability(ctx, report(reporter, false))))
+	claim(t, ctx, k, 1000)
+
+	require.True(t, types.ErrNotReporter.Is(k.ReportAvailability(ctx, report(operator, false))))
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, true)))
+	require.NoError(t, k.ReportAvailability(ctx, report(validator, false)))
+
+	// a new report of a reporter replaces its previous one
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	reports := k.GetNodeAvailabilityReports(ctx, operator)
+	require.Len(t, reports, 2)
+	for _, report := range reports {
+		require.False(t, report.Available)
+	}
+}
+
+func TestTallyAvailability(t *testing.T) {
+	ctx, k, sk, dk := createTestInput(t)
+	claim(t, ctx, k, 1000)
+
+	// not enough reports to judge the node
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	k.TallyAvailability(ctx.WithBlockHeight(10))
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.False(t, node.Jailed)
+	require.Empty(t, k.GetNodeAvailabilityReports(ctx, operator))
+
+	// the reports are only tallied at the end of the window
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	require.NoError(t, k.ReportAvailability(ctx, report(validator, false)))
+	k.TallyAvailability(ctx.WithBlockHeight(11))
+	require.Len(t, k.GetNodeAvailabilityReports(ctx, operator), 2)
+
+	k.TallyAvailability(ctx.WithBlockHeight(20))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.Jailed)
+	require.Equal(t, sdk.NewInt64Coin(sdk.NativeTokenName, 900), node.Bond)
+	require.Equal(t, ctx.BlockHeader().Time.Add(time.Hour), node.JailedUntil)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100)), dk.funded)
+	require.Empty(t, k.GetAllIPALNodes(ctx))
+
+	// jailed nodes can not be reported
+	require.True(t, types.ErrIPALNodeJailed.Is(k.ReportAvailability(ctx, report(reporter, false))))
+
+	// the slashed bond is burned when it is not sent to the community pool
+	params := k.GetParams(ctx)
+	params.SlashToCommunityPool = false
+	k.SetParams(ctx, params)
+	require.NoError(t, k.Unjail(ctx.WithBlockTime(node.JailedUntil), operator))
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	require.NoError(t, k.ReportAvailability(ctx, report(validator, false)))
+	k.TallyAvailability(ctx.WithBlockHeight(30))
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.Equal(t, sdk.NewInt64Coin(sdk.NativeTokenName, 810), node.Bond)
+	// the mock distribution keeper left the first slash in the module account
+	require.Equal(t, sdk.NewInt64Coin(sdk.NativeTokenName, 910), sk.GetModuleAccount(ctx, types.ModuleName).GetCoins()[0])
+	require.Equal(t, sdk.NewInt(10000-90), sk.GetSupply(ctx).GetTotal().AmountOf(sdk.NativeTokenName))
+}
+
+func TestUnjail(t *testing.T) {
+	ctx, k, _, _ := createTestInput(t)
+	claim(t, ctx, k, 105)
+
+	require.True(t, types.ErrIPALNodeNotJailed.Is(k.Unjail(ctx, operator)))
+	require.NoError(t, k.ReportAvailability(ctx, report(reporter, false)))
+	require.NoError(t, k.ReportAvailability(ctx, report(validator, false)))
+	k.TallyAvailability(ctx.WithBlockHeight(10))
+
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.True(t, types.ErrStillJailed.Is(k.Unjail(ctx, operator)))
+	unjailCtx := ctx.WithBlockTime(node.JailedUntil)
+	require.True(t, types.ErrBondInsufficient.Is(k.Unjail(unjailCtx, operator)))
+
+	// the node stays jailed when its bond is topped up
+	claim(t, unjailCtx, k, 200)
+	node, _ = k.GetIPALNode(ctx, operator)
+	require.True(t, node.Jailed)
+	require.Empty(t, k.GetAllIPALNodes(ctx))
+
+	require.NoError(t, k.Unjail(unjailCtx, operator))
+	require.Len(t, k.GetAllIPALNodes(ctx), 1)
+}
@@ -34,10 +34,107 @@ func (k Keeper) SetMinBond(ctx sdk.Context, minBond sdk.Coin) {
 	k.paramstore.Set(ctx, types.KeyMinBond, minBond)
 }
 
+// GetReportWindow returns the number of blocks over which the availability of the ipal nodes is
+// tallied, the default window on the chains started before the param was added
+func (k Keeper) GetReportWindow(ctx sdk.Context) int64 {
+	res := types.DefaultReportWindow
+	k.paramstore.GetIfExists(ctx, types.KeyReportWindow, &res)
+	return res
+}
+
+// GetMinReports returns the min number of reports tallied for a node, the default on the chains
+// started before the param was added
+func (k Keeper) GetMinReports(ctx sdk.Context) uint64 {
+	res := types.DefaultMinReports
+	k.paramstore.GetIfExists(ctx, types.KeyMinReports, &res)
+	return res
+}
+
+// GetMinAvailability returns the min availability of a node, the default on the chains started
+// before the param was added
+func (k Keeper) GetMinAvailability(ctx sdk.Context) sdk.Dec {
+	res := types.DefaultMinAvailability
+	k.paramstore.GetIfExists(ctx, types.KeyMinAvailability, &res)
+	return res
+}
+
+// GetSlashFraction returns the fraction of the bond slashed from an unavailable node, the default
+// on the chains started before the param was added
+func (k Keeper) GetSlashFraction(ctx sdk.Context) sdk.Dec {
+	res := types.DefaultSlashFraction
+	k.paramstore.GetIfExists(ctx, types.KeySlashFraction, &res)
+	return res
+}
+
+// GetJailDuration returns how long an unavailable node is jailed, the default on the chains started
+// before the param was added
+func (k Keeper) GetJailDuration(ctx sdk.Context) time.Duration {
+	res := types.DefaultJailDuration
+	k.paramstore.GetIfExists(ctx, types.KeyJailDuration, &res)
+	return res
+}
+
+// GetSlashToCommunityPool returns whether the slashed bonds fund the community pool, the default on
+// the chains started before the param was added
+func (k Keeper) GetSlashToCommunityPool(ctx sdk.Context) bool {
+	res := types.DefaultSlashToCommunityPool
+	k.paramstore.GetIfExists(ctx, types.KeySlashToCommunityPool, &res)
+	return res
+}
+
+// GetReporters returns the accounts allowed to report the availability of the nodes besides the
+// bonded validators, none on the chains started before the param was added
+func (k Keeper) GetReporters(ctx sdk.Context) (res []sdk.AccAddress) {
+	k.paramstore.GetIfExists(ctx, types.KeyReporters, &res)
+	return
+}
+
+// GetDelegatorRewardsShare returns the share of the collected fees and inflation allocated to the
+// ipal delegators, none on the chains started before the param was added
+func (k Keeper) GetDelegatorRewardsShare(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
+	k.paramstore.GetIfExists(ctx, types.KeyDelegatorRewardsShare, &res)
+	return res
+}
+
+// GetEndpointTypes returns the registered endpoint types, none on the chains started before the param
+// was added
+func (k Keeper) GetEndpointTypes(ctx sdk.Context) (res types.TypeSpecs) {
+	k.paramstore.GetIfExists(ctx, types.KeyEndpointTypes, &res)
+	return
+}
+
+// GetMaxCommission returns the max commission of a node, the default on the chains started before the
+// param was added
+func (k Keeper) GetMaxCommission(ctx sdk.Context) sdk.Dec {
+	res := types.DefaultMaxCommission
+	k.paramstore.GetIfExists(ctx, types.KeyMaxCommission, &res)
+	return res
+}
+
+// GetMaxCommissionChangeRate returns the max change of the commission of a node at once, the default
+// on the chains started before the param was added
+func (k Keeper) GetMaxCommissionChangeRate(ctx sdk.Context) sdk.Dec {
+	res := types.DefaultMaxCommissionChangeRate
+	k.paramstore.GetIfExists(ctx, types.KeyMaxCommissionChangeRate, &res)
+	return res
+}
+
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	return types.NewParams(
 		k.GetUnbondingTime(ctx),
-		k.GetMinBond(ctx))
+		k.GetMinBond(ctx),
+		k.GetReportWindow(ctx),
+		k.GetMinReports(ctx),
+		k.GetMinAvailability(ctx),
+		k.GetSlashFraction(ctx),
+		k.GetJailDuration(ctx),
+		k.GetSlashToCommunityPool(ctx),
+		k.GetReporters(ctx),
+		k.GetDelegatorRewardsShare(ctx),
+		k.GetEndpointTypes(ctx),
+		k.GetMaxCommission(ctx),
+		k.GetMaxCommissionChangeRate(ctx))
 }
 
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// GetKeySets returns the versions of the public keys published by the node of operator, oldest first
+func (k Keeper) GetKeySets(ctx sdk.Context, operator sdk.AccAddress) (sets types.KeySets) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetKeySetsKey(operator))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		sets = append(sets, types.MustUnmarshalKeySet(k.cdc, iterator.Value()))
+	}
+	return sets
+}
+
+// SetKeySet stores a version of the public keys of a node
+func (k Keeper) SetKeySet(ctx sdk.Context, set types.KeySet) {
+	operator, err := sdk.AccAddressFromBech32(set.Owner)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetKeySetKey(operator, set.Version), types.MustMarshalKeySet(k.cdc, set))
+}
+
+// IterateAllKeySets iterates over the versions of the public keys of all the nodes until cb returns true
+func (k Keeper) IterateAllKeySets(ctx sdk.Context, cb func(set types.KeySet) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.KeySetKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(types.MustUnmarshalKeySet(k.cdc, iterator.Value())) {
+			break
+		}
+	}
+}
+
+// lastKeyVersion returns the last version of the public keys of the node of operator, 0 if none
+func (k Keeper) lastKeyVersion(ctx sdk.Context, operator sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.GetKeySetsKey(operator))
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return 0
+	}
+	return types.MustUnmarshalKeySet(k.cdc, iterator.Value()).Version
+}
+
+// publishKeys makes keys the current public keys of the node as a new version, the current keys are
+// kept when keys are empty or unchanged. The versions outlive the node so that a node claimed again
+// goes on numbering them.
+func (k Keeper) publishKeys(ctx sdk.Context, node *types.IPALNode, keys types.PublicKeys) {
+	if len(keys) == 0 || keys.Equal(node.PublicKeys) {
+		return
+	}
+
+	version := k.lastKeyVersion(ctx, node.OperatorAddress) + 1
+	k.SetKeySet(ctx, types.NewKeySet(node.OperatorAddress.String(), version, ctx.BlockHeight(), keys))
+	node.PublicKeys = keys
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePublishKeys,
+			sdk.NewAttribute(types.AttributeKeyOperator, node.OperatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyVersion, fmt.Sprintf("%d", version)),
+		),
+	)
+}
@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestPublishKeys(t *testing.T) {
+	ctx, k, _, _ := createTestInput(t)
+
+	claimWithKeys := func(height, amount int64, keys types.PublicKeys) {
+		endpoints := types.Endpoints{types.NewEndpoint(1, "http://1.1.1.1")}
+		msg := types.NewMsgIPALNodeClaim(operator, "moniker", "", "", "", endpoints, sdk.NewInt64Coin(sdk.NativeTokenName, amount), keys)
+		require.NoError(t, msg.ValidateBasic())
+		require.NoError(t, k.DoIPALNodeClaim(ctx.WithBlockHeight(height), msg))
+	}
+	identity := func(b byte) types.PublicKeys {
+		return types.PublicKeys{types.NewPublicKey(types.PublicKeyTypeX25519Identity, bytes.Repeat([]byte{b}, 32), nil)}
+	}
+
+	claimWithKeys(1, 1000, identity(1))
+	// the keys are kept when the claim has none or the same ones
+	claimWithKeys(2, 1000, nil)
+	claimWithKeys(3, 1000, identity(1))
+	claimWithKeys(4, 1000, identity(2))
+
+	node, _ := k.GetIPALNode(ctx, operator)
+	require.Equal(t, identity(2), node.PublicKeys)
+	sets := k.GetKeySets(ctx, operator)
+	require.Equal(t, types.KeySets{
+		types.NewKeySet(operator.String(), 1, 1, identity(1)),
+		types.NewKeySet(operator.String(), 2, 4, identity(2)),
+	}, sets)
+
+	// the history outlives the node, a node claimed again goes on numbering the versions
+	claimWithKeys(5, 1, nil)
+	_, found := k.GetIPALNode(ctx, operator)
+	require.False(t, found)
+	claimWithKeys(6, 1000, identity(3))
+	sets = k.GetKeySets(ctx, operator)
+	require.Len(t, sets, 3)
+	require.Equal(t, uint64(3), sets[2].Version)
+}
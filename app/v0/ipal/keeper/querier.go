@@ -14,11 +14,21 @@ func NewQuerier(k Keeper) sdk.Querier {
 		case types.QueryParameters:
 			return queryParameters(ctx, k)
 		case types.QueryIPALNodeList:
-			return queryIPALNodeList(ctx, k)
+			return queryIPALNodeList(ctx, req, k)
 		case types.QueryIPALNode:
 			return queryIPALNode(ctx, req, k)
 		case types.QueryIPALNodes:
 			return queryIPALNodes(ctx, req, k)
+		case types.QueryReports:
+			return queryAvailabilityReports(ctx, req, k)
+		case types.QueryDelegation:
+			return queryDelegation(ctx, req, k)
+		case types.QueryDelegations:
+			return queryDelegations(ctx, req, k)
+		case types.QueryRewards:
+			return queryRewards(ctx, req, k)
+		case types.QueryPublicKeys:
+			return queryPublicKeys(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown ipal query path: %s", path[0])
 		}
@@ -35,8 +45,24 @@ func queryParameters(ctx sdk.Context, k Keeper) ([]byte, error) {
 	return res, nil
 }
 
-func queryIPALNodeList(ctx sdk.Context, k Keeper) ([]byte, error) {
-	ipalNodes := k.GetAllIPALNodes(ctx)
+func queryIPALNodeList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryIPALNodeListParams
+	if len(req.Data) > 0 {
+		err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	ipalNodes, err := k.ListIPALNodes(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, ipalNodes)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
@@ -86,3 +112,103 @@ func queryIPALNodes(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 	}
 	return bz, nil
 }
+
+func queryAvailabilityReports(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryAvailabilityReportsParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	reports := k.GetNodeAvailabilityReports(ctx, params.Operator)
+	if reports == nil {
+		reports = types.AvailabilityReports{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, reports)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryDelegation(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegation, found := k.GetDelegation(ctx, params.Delegator, params.Operator)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrDelegationNotFound, "delegator: %s, operator: %s", params.Delegator, params.Operator)
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, delegation)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegations := k.GetNodeDelegations(ctx, params.Operator)
+	if delegations == nil {
+		delegations = types.Delegations{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, delegations)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryRewards(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegationParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	rewards, commission, err := k.GetPendingRewards(ctx, params.Delegator, params.Operator)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, types.QueryRewardsResponse{Rewards: rewards, Commission: commission})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryPublicKeys(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryIPALNodeParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	sets := k.GetKeySets(ctx, params.AccAddr)
+	if sets == nil {
+		sets = types.KeySets{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, sets)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestQueryIPALNodeList(t *testing.T) {
+	ctx, k, _, _ := createTestInput(t)
+	querier := NewQuerier(k)
+
+	chatting := types.Endpoints{types.NewEndpoint(1, "1.1.1.1:10000")}
+	storage := types.Endpoints{types.NewEndpoint(2, "2.2.2.2:20000")}
+	nodes := []types.IPALNode{
+		types.NewIPALNode(sdk.AccAddress("node1_______________"), "nch-b", "", "", "", chatting, pnch(300)),
+		types.NewIPALNode(sdk.AccAddress("node2_______________"), "nch-a", "", "", "", storage, pnch(100)),
+		types.NewIPALNode(sdk.AccAddress("node3_______________"), "other", "", "", "", chatting, pnch(200)),
+		types.NewIPALNode(sdk.AccAddress("node4_______________"), "nch-c", "", "", "", chatting, pnch(400)),
+	}
+	nodes[3].Jailed = true
+	for _, node := range nodes {
+		k.CreateIPALNode(ctx, node)
+	}
+
+	list := func(params types.QueryIPALNodeListParams) (monikers []string) {
+		bz, err := querier(ctx, []string{types.QueryIPALNodeList}, abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(params)})
+		require.NoError(t, err)
+
+		var res types.IPALNodes
+		types.ModuleCdc.MustUnmarshalJSON(bz, &res)
+		for _, node := range res {
+			monikers = append(monikers, node.Moniker)
+		}
+		return monikers
+	}
+
+	// the legacy query without params lists all the ranked nodes
+	bz, err := querier(ctx, []string{types.QueryIPALNodeList}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var all types.IPALNodes
+	types.ModuleCdc.MustUnmarshalJSON(bz, &all)
+	require.Len(t, all, 3)
+
+	require.Equal(t, []string{"nch-b", "other", "nch-a"}, list(types.QueryIPALNodeListParams{}))
+	require.Equal(t, []string{"other"}, list(types.QueryIPALNodeListParams{Offset: 1, Limit: 1}))
+	require.Equal(t, []string{"other", "nch-a"}, list(types.QueryIPALNodeListParams{After: nodes[0].OperatorAddress}))
+	require.Equal(t, []string{"nch-b", "other"}, list(types.QueryIPALNodeListParams{EndpointType: 1}))
+	require.Equal(t, []string{"nch-b", "other"}, list(types.QueryIPALNodeListParams{MinBond: 200}))
+
+	byMoniker := types.QueryIPALNodeListParams{OrderBy: types.OrderByMoniker, MonikerPrefix: "nch"}
+	require.Equal(t, []string{"nch-a", "nch-b"}, list(byMoniker))
+	byMoniker.After = nodes[1].OperatorAddress
+	require.Equal(t, []string{"nch-b"}, list(byMoniker))
+	byMoniker.After = nodes[0].OperatorAddress
+	require.Empty(t, list(byMoniker))
+
+	_, err = querier(ctx, []string{types.QueryIPALNodeList},
+		abci.RequestQuery{Data: types.ModuleCdc.MustMarshalJSON(types.QueryIPALNodeListParams{OrderBy: "endpoints"})})
+	require.Error(t, err)
+}
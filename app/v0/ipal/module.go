@@ -128,7 +128,8 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 }
 
 // BeginBlock returns the begin blocker for the ipal module.
-func (am AppModule) BeginBlock(sdk.Context, abci.RequestBeginBlock) {
+func (am AppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	BeginBlocker(ctx, am.keeper)
 }
 
 // EndBlock returns the end blocker for the ipal module. It returns no validator
@@ -64,7 +64,7 @@ func SimulateMsgCreateIpal(ak keeper.AccountKeeper, k keeper.Keeper) simtypes.Op
 		moniker, website, details, extension := simtypes.RandStringOfLength(r, 1000), simtypes.RandStringOfLength(r, 1000), simtypes.RandStringOfLength(r, 1000), simtypes.RandStringOfLength(r, 1000)
 
 		endPoint := types.NewEndpoint(r.Uint64(), "192.168.1.100:666")
-		msg := types.NewMsgIPALNodeClaim(acc.Address, moniker, website, details, extension, types.Endpoints{endPoint}, bond)
+		msg := types.NewMsgIPALNodeClaim(acc.Address, moniker, website, details, extension, types.Endpoints{endPoint}, bond, nil)
 
 		tx := helpers.GenTx(
 			[]sdk.Msg{msg},
@@ -7,6 +7,12 @@ import (
 // RegisterCodec - register the sdk message type
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgIPALNodeClaim{}, "nch/IPALClaim", nil)
+	cdc.RegisterConcrete(MsgIPALAvailabilityReport{}, "nch/IPALAvailabilityReport", nil)
+	cdc.RegisterConcrete(MsgIPALNodeUnjail{}, "nch/IPALUnjail", nil)
+	cdc.RegisterConcrete(MsgIPALDelegate{}, "nch/IPALDelegate", nil)
+	cdc.RegisterConcrete(MsgIPALUndelegate{}, "nch/IPALUndelegate", nil)
+	cdc.RegisterConcrete(MsgIPALWithdrawRewards{}, "nch/IPALWithdrawRewards", nil)
+	cdc.RegisterConcrete(MsgIPALSetCommission{}, "nch/IPALSetCommission", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout module
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// Delegation is the pnch bonded by Delegator to the ipal node of Operator, RewardsPerShare is the
+// rewards per share of the node when the rewards of the delegation were last withdrawn
+type Delegation struct {
+	Delegator       sdk.AccAddress `json:"delegator" yaml:"delegator"`
+	Operator        sdk.AccAddress `json:"operator" yaml:"operator"`
+	Amount          sdk.Coin       `json:"amount" yaml:"amount"`
+	RewardsPerShare sdk.DecCoins   `json:"rewards_per_share" yaml:"rewards_per_share"`
+}
+
+func NewDelegation(delegator, operator sdk.AccAddress, amount sdk.Coin, rewardsPerShare sdk.DecCoins) Delegation {
+	return Delegation{
+		Delegator:       delegator,
+		Operator:        operator,
+		Amount:          amount,
+		RewardsPerShare: rewardsPerShare,
+	}
+}
+
+// PendingRewards returns the rewards the delegation earned since they were last withdrawn, out of
+// the current rewards per share of its node
+func (d Delegation) PendingRewards(rewardsPerShare sdk.DecCoins) sdk.DecCoins {
+	return rewardsPerShare.Sub(d.RewardsPerShare).MulDec(d.Amount.Amount.ToDec())
+}
+
+func (d Delegation) String() string {
+	return fmt.Sprintf(`Delegation:
+  Delegator:         %s
+  Operator:          %s
+  Amount:            %s
+  Rewards Per Share: %s`, d.Delegator, d.Operator, d.Amount, d.RewardsPerShare)
+}
+
+type Delegations []Delegation
+
+func (d Delegations) String() string {
+	out := make([]string, len(d))
+	for i, delegation := range d {
+		out[i] = delegation.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+func MustMarshalDelegation(cdc *codec.Codec, delegation Delegation) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(delegation)
+}
+
+func MustUnmarshalDelegation(cdc *codec.Codec, value []byte) (delegation Delegation) {
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &delegation)
+	return delegation
+}
@@ -5,13 +5,24 @@ import (
 )
 
 var (
-	ErrEmptyInputs        = sdkerrors.New(ModuleName, 1, "input empty")
-	ErrBadDenom           = sdkerrors.New(ModuleName, 2, "bad denom")
-	ErrBondInsufficient   = sdkerrors.New(ModuleName, 3, "bond insufficient")
-	ErrMonikerExist       = sdkerrors.New(ModuleName, 4, "moniker exists")
-	ErrEndpointsEmpty     = sdkerrors.New(ModuleName, 6, "no endpoints")
-	ErrEndpointsDuplicate = sdkerrors.New(ModuleName, 7, "endpoints duplicate")
-	ErrEndpointsFormat    = sdkerrors.New(ModuleName, 8, "endpoints format error")
+	ErrEmptyInputs            = sdkerrors.New(ModuleName, 1, "input empty")
+	ErrBadDenom               = sdkerrors.New(ModuleName, 2, "bad denom")
+	ErrBondInsufficient       = sdkerrors.New(ModuleName, 3, "bond insufficient")
+	ErrMonikerExist           = sdkerrors.New(ModuleName, 4, "moniker exists")
+	ErrEndpointsEmpty         = sdkerrors.New(ModuleName, 6, "no endpoints")
+	ErrEndpointsDuplicate     = sdkerrors.New(ModuleName, 7, "endpoints duplicate")
+	ErrEndpointsFormat        = sdkerrors.New(ModuleName, 8, "endpoints format error")
+	ErrNotReporter            = sdkerrors.New(ModuleName, 9, "not an availability reporter")
+	ErrIPALNodeNotFound       = sdkerrors.New(ModuleName, 10, "ipal node not found")
+	ErrIPALNodeJailed         = sdkerrors.New(ModuleName, 11, "ipal node jailed")
+	ErrIPALNodeNotJailed      = sdkerrors.New(ModuleName, 12, "ipal node not jailed")
+	ErrStillJailed            = sdkerrors.New(ModuleName, 13, "ipal node still jailed")
+	ErrDuplicateReport        = sdkerrors.New(ModuleName, 14, "duplicate availability report")
+	ErrDelegationNotFound     = sdkerrors.New(ModuleName, 15, "delegation not found")
+	ErrInsufficientDelegation = sdkerrors.New(ModuleName, 16, "insufficient delegation")
+	ErrInvalidCommission      = sdkerrors.New(ModuleName, 17, "invalid commission")
+	ErrNoRewards              = sdkerrors.New(ModuleName, 18, "no rewards to withdraw")
+	ErrInvalidPublicKeys      = sdkerrors.New(ModuleName, 19, "invalid public keys")
 )
 
 type EndpointDuplicateErrDetector struct {
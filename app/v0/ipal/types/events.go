@@ -1,5 +1,25 @@
 package types
 
+const (
+	EventTypeAvailabilityReport = "ipal_availability_report"
+	EventTypeSlash              = "ipal_slash"
+	EventTypeUnjail             = "ipal_unjail"
+	EventTypeDelegate           = "ipal_delegate"
+	EventTypeUndelegate         = "ipal_undelegate"
+	EventTypeWithdrawRewards    = "ipal_withdraw_rewards"
+	EventTypeSetCommission      = "ipal_set_commission"
+	EventTypePublishKeys        = "ipal_publish_keys"
+
+	AttributeKeyReporter     = "reporter"
+	AttributeKeyOperator     = "operator"
+	AttributeKeyAvailability = "availability"
+	AttributeKeyAmount       = "amount"
+	AttributeKeyJailedUntil  = "jailed_until"
+	AttributeKeyDelegator    = "delegator"
+	AttributeKeyCommission   = "commission"
+	AttributeKeyVersion      = "version"
+)
+
 var (
 	AttributeValueCategory = ModuleName
 )
@@ -1,6 +1,7 @@
 package types
 
 import (
+	stakingexported "github.com/netcloth/netcloth-chain/app/v0/staking/exported"
 	supplyexported "github.com/netcloth/netcloth-chain/app/v0/supply/exported"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
@@ -13,4 +14,22 @@ type SupplyKeeper interface {
 
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, name string, amt sdk.Coins) error
+}
+
+// StakingKeeper is used to accept the availability reports of the bonded validators
+type StakingKeeper interface {
+	Validator(ctx sdk.Context, address sdk.ValAddress) stakingexported.ValidatorI
+}
+
+// DistributionKeeper receives the slashed bonds in the community pool
+type DistributionKeeper interface {
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error
+}
+
+// IPALHooks are called by the ipal keeper on the lifecycle events of the ipal nodes
+type IPALHooks interface {
+	// AfterIPALNodeRemoved is called once a node is deleted, its bond having dropped below MinBond
+	AfterIPALNodeRemoved(ctx sdk.Context, operator sdk.AccAddress)
 }
@@ -1,8 +1,11 @@
 package types
 
 type GenesisState struct {
-	Params    Params    `json:"params" yaml:"params"`
-	IPALNodes IPALNodes `json:"ipal_nodes" yaml:"ipal_nodes"`
+	Params              Params              `json:"params" yaml:"params"`
+	IPALNodes           IPALNodes           `json:"ipal_nodes" yaml:"ipal_nodes"`
+	AvailabilityReports AvailabilityReports `json:"availability_reports" yaml:"availability_reports"`
+	Delegations         Delegations         `json:"delegations" yaml:"delegations"`
+	KeySets             KeySets             `json:"key_sets" yaml:"key_sets"`
 }
 
 func DefaultGenesisState() GenesisState {
@@ -11,9 +14,13 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-func NewGenesisState(params Params, ipalNodes IPALNodes) GenesisState {
+func NewGenesisState(params Params, ipalNodes IPALNodes, reports AvailabilityReports, delegations Delegations,
+	keySets KeySets) GenesisState {
 	return GenesisState{
-		Params:    params,
-		IPALNodes: ipalNodes,
+		Params:              params,
+		IPALNodes:           ipalNodes,
+		AvailabilityReports: reports,
+		Delegations:         delegations,
+		KeySets:             keySets,
 	}
 }
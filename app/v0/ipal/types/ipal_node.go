@@ -3,6 +3,7 @@ package types
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -62,6 +63,16 @@ type IPALNode struct {
 	Extension       string         `json:"extension" yaml:"extension"`
 	Endpoints       Endpoints      `json:"endpoints" yaml:"endpoints"`
 	Bond            sdk.Coin       `json:"bond" yaml:"bond"`
+	Jailed          bool           `json:"jailed" yaml:"jailed"`             // jailed nodes are out of the ranking
+	JailedUntil     time.Time      `json:"jailed_until" yaml:"jailed_until"` // time the node can be unjailed from
+
+	Delegated             sdk.Coin     `json:"delegated" yaml:"delegated"`                           // coins delegated to the node, ranked with the bond
+	Commission            sdk.Dec      `json:"commission" yaml:"commission"`                         // share of the delegator rewards kept by the operator
+	CommissionUpdateTime  time.Time    `json:"commission_update_time" yaml:"commission_update_time"` // last time the commission was changed
+	RewardsPerShare       sdk.DecCoins `json:"rewards_per_share" yaml:"rewards_per_share"`           // accumulated delegator rewards per delegated pnch
+	OutstandingCommission sdk.DecCoins `json:"outstanding_commission" yaml:"outstanding_commission"` // commission not withdrawn by the operator yet
+
+	PublicKeys PublicKeys `json:"public_keys" yaml:"public_keys"` // current messaging public keys, the previous versions are kept apart
 }
 
 type IPALNodes []IPALNode
@@ -82,7 +93,19 @@ func NewIPALNode(operator sdk.AccAddress, moniker, website, details, extension s
 		Extension:       extension,
 		Endpoints:       endpoints,
 		Bond:            amount,
+		Delegated:       sdk.NewCoin(sdk.NativeTokenName, sdk.ZeroInt()),
+		Commission:      sdk.ZeroDec(),
+	}
+}
+
+// TotalBond returns the bond of the operator plus the coins delegated to the node, the nodes are
+// ranked by their total bond. The nodes stored before the delegations were added have no delegated
+// coins.
+func (obj IPALNode) TotalBond() sdk.Coin {
+	if obj.Delegated.Denom == "" {
+		return obj.Bond
 	}
+	return obj.Bond.Add(obj.Delegated)
 }
 
 func (obj IPALNode) MarshalYAML() (interface{}, error) {
@@ -94,6 +117,14 @@ func (obj IPALNode) MarshalYAML() (interface{}, error) {
 		Details         string
 		Extension       string
 		Bond            sdk.Coin
+		Jailed          bool
+		JailedUntil     time.Time
+		Delegated       sdk.Coin
+		Commission      sdk.Dec
+		CommissionTime  time.Time
+		RewardsPerShare sdk.DecCoins
+		Outstanding     sdk.DecCoins
+		PublicKeys      PublicKeys
 	}{
 		OperatorAddress: obj.OperatorAddress,
 		Moniker:         obj.Moniker,
@@ -102,6 +133,14 @@ func (obj IPALNode) MarshalYAML() (interface{}, error) {
 		Details:         obj.Details,
 		Extension:       obj.Extension,
 		Bond:            obj.Bond,
+		Jailed:          obj.Jailed,
+		JailedUntil:     obj.JailedUntil,
+		Delegated:       obj.Delegated,
+		Commission:      obj.Commission,
+		CommissionTime:  obj.CommissionUpdateTime,
+		RewardsPerShare: obj.RewardsPerShare,
+		Outstanding:     obj.OutstandingCommission,
+		PublicKeys:      obj.PublicKeys,
 	})
 
 	if err != nil {
@@ -123,9 +162,21 @@ func MustUnmarshalIPALNode(cdc *codec.Codec, value []byte) IPALNode {
 	return obj
 }
 
+// UnmarshalIPALNode decodes a node, the nodes stored before the delegations were added get no
+// delegated coins and no commission
 func UnmarshalIPALNode(cdc *codec.Codec, value []byte) (obj IPALNode, err error) {
 	err = cdc.UnmarshalBinaryLengthPrefixed(value, &obj)
-	return obj, err
+	if err != nil {
+		return obj, err
+	}
+
+	if obj.Delegated.Denom == "" {
+		obj.Delegated = sdk.NewCoin(sdk.NativeTokenName, sdk.ZeroInt())
+	}
+	if obj.Commission.Int == nil {
+		obj.Commission = sdk.ZeroDec()
+	}
+	return obj, nil
 }
 
 func (obj IPALNode) String() string {
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// legacyIPALNode is a node as stored before the delegations were added
+type legacyIPALNode struct {
+	OperatorAddress sdk.AccAddress
+	Moniker         string
+	Website         string
+	Details         string
+	Extension       string
+	Endpoints       Endpoints
+	Bond            sdk.Coin
+}
+
+func TestIPALNodeByBondKeyWithoutDelegated(t *testing.T) {
+	operator := sdk.AccAddress("operator____________")
+	bond := sdk.NewInt64Coin(sdk.NativeTokenName, 1000)
+
+	// the ranking key of a node without delegated coins is built from its bond
+	node := IPALNode{OperatorAddress: operator, Bond: bond}
+	require.Equal(t, bond, node.TotalBond())
+	key := GetIPALNodeByBondKey(node)
+	require.Equal(t, uint64(1000), binary.BigEndian.Uint64(key[1:9]))
+
+	// a node stored without delegated coins gets none, and no commission, when read back
+	cdc := codec.New()
+	value := cdc.MustMarshalBinaryLengthPrefixed(legacyIPALNode{OperatorAddress: operator, Moniker: "moniker", Bond: bond})
+	node = MustUnmarshalIPALNode(cdc, value)
+	require.Equal(t, sdk.NewInt64Coin(sdk.NativeTokenName, 0), node.Delegated)
+	require.True(t, node.Commission.IsZero())
+	require.Equal(t, key, GetIPALNodeByBondKey(node))
+
+	node.Delegated = node.Delegated.Add(sdk.NewInt64Coin(sdk.NativeTokenName, 500))
+	require.Equal(t, sdk.NewInt64Coin(sdk.NativeTokenName, 1500), node.TotalBond())
+}
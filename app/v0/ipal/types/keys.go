@@ -20,6 +20,9 @@ var (
 	IPALNodeByBondKey    = []byte{0x11}
 	IPALNodeByMonikerKey = []byte{0x12}
 	UnBondingKey         = []byte{0x13}
+	AvailabilityKey      = []byte{0x14}
+	DelegationKey        = []byte{0x15}
+	KeySetKey            = []byte{0x16}
 )
 
 func GetIPALNodeKey(addr sdk.AccAddress) []byte {
@@ -27,7 +30,7 @@ func GetIPALNodeKey(addr sdk.AccAddress) []byte {
 }
 
 func GetIPALNodeByBondKey(obj IPALNode) []byte {
-	bond := obj.Bond.Amount.Int64()
+	bond := obj.TotalBond().Amount.Int64()
 	bondBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(bondBytes, uint64(bond))
 
@@ -54,3 +57,34 @@ func GetUnBondingKey(timestamp time.Time) []byte {
 	v := sdk.FormatTimeBytes(timestamp)
 	return append(UnBondingKey, v...)
 }
+
+// GetAvailabilityReportKey returns the key of the availability report of the node of operator by reporter
+func GetAvailabilityReportKey(operator, reporter sdk.AccAddress) []byte {
+	return append(append(AvailabilityKey, operator...), reporter...)
+}
+
+// SplitAvailabilityReportKey returns the node operator and the reporter of an availability report key
+func SplitAvailabilityReportKey(key []byte) (operator, reporter sdk.AccAddress) {
+	key = key[len(AvailabilityKey):]
+	return key[:sdk.AddrLen], key[sdk.AddrLen:]
+}
+
+// GetDelegationKey returns the key of the delegation of delegator to the node of operator
+func GetDelegationKey(operator, delegator sdk.AccAddress) []byte {
+	return append(GetNodeDelegationsKey(operator), delegator...)
+}
+
+// GetNodeDelegationsKey returns the prefix of the keys of the delegations to the node of operator
+func GetNodeDelegationsKey(operator sdk.AccAddress) []byte {
+	return append(sdk.CopyBytes(DelegationKey), operator...)
+}
+
+// GetKeySetKey returns the key of a version of the public keys of the node of operator
+func GetKeySetKey(operator sdk.AccAddress, version uint64) []byte {
+	return append(GetKeySetsKey(operator), sdk.Uint64ToBigEndian(version)...)
+}
+
+// GetKeySetsKey returns the prefix of the versions of the public keys of the node of operator
+func GetKeySetsKey(operator sdk.AccAddress) []byte {
+	return append(sdk.CopyBytes(KeySetKey), operator.Bytes()...)
+}
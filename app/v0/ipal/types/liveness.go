@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// NodeAvailability is whether the endpoints of the node of Operator were reachable
+type NodeAvailability struct {
+	Operator  sdk.AccAddress `json:"operator" yaml:"operator"`
+	Available bool           `json:"available" yaml:"available"`
+}
+
+func NewNodeAvailability(operator sdk.AccAddress, available bool) NodeAvailability {
+	return NodeAvailability{
+		Operator:  operator,
+		Available: available,
+	}
+}
+
+// AvailabilityReport is the latest availability of the node of Operator reported by Reporter in
+// the current report window
+type AvailabilityReport struct {
+	Operator  sdk.AccAddress `json:"operator" yaml:"operator"`
+	Reporter  sdk.AccAddress `json:"reporter" yaml:"reporter"`
+	Available bool           `json:"available" yaml:"available"`
+}
+
+func NewAvailabilityReport(operator, reporter sdk.AccAddress, available bool) AvailabilityReport {
+	return AvailabilityReport{
+		Operator:  operator,
+		Reporter:  reporter,
+		Available: available,
+	}
+}
+
+func (r AvailabilityReport) String() string {
+	return fmt.Sprintf(`AvailabilityReport:
+  Operator:  %s
+  Reporter:  %s
+  Available: %t`, r.Operator, r.Reporter, r.Available)
+}
+
+type AvailabilityReports []AvailabilityReport
+
+func (r AvailabilityReports) String() string {
+	out := make([]string, len(r))
+	for i, report := range r {
+		out[i] = report.String()
+	}
+	return strings.Join(out, "\n")
+}
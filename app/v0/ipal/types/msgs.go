@@ -11,9 +11,23 @@ import (
 
 var (
 	_ sdk.Msg = MsgIPALNodeClaim{}
+	_ sdk.Msg = MsgIPALAvailabilityReport{}
+	_ sdk.Msg = MsgIPALNodeUnjail{}
+	_ sdk.Msg = MsgIPALDelegate{}
+	_ sdk.Msg = MsgIPALUndelegate{}
+	_ sdk.Msg = MsgIPALWithdrawRewards{}
+	_ sdk.Msg = MsgIPALSetCommission{}
 )
 
-const TypeMsgIPALNodeClaim = "ipalNodeClaim"
+const (
+	TypeMsgIPALNodeClaim          = "ipalNodeClaim"
+	TypeMsgIPALAvailabilityReport = "ipalAvailabilityReport"
+	TypeMsgIPALNodeUnjail         = "ipalNodeUnjail"
+	TypeMsgIPALDelegate           = "ipalDelegate"
+	TypeMsgIPALUndelegate         = "ipalUndelegate"
+	TypeMsgIPALWithdrawRewards    = "ipalWithdrawRewards"
+	TypeMsgIPALSetCommission      = "ipalSetCommission"
+)
 
 type Endpoint struct {
 	Type     uint64 `json:"type" yaml:"type"`
@@ -48,9 +62,11 @@ type MsgIPALNodeClaim struct {
 	Extension       string         `json:"extension" yaml:"extension"`               // for future extension
 	Endpoints       Endpoints      `json:"endpoints" yaml:"endpoints"`               // server endpoint for app client
 	Bond            sdk.Coin       `json:"bond" yaml:"bond"`                         // bond coin for ranking
+	PublicKeys      PublicKeys     `json:"public_keys,omitempty" yaml:"public_keys"` // messaging public keys, the published keys are kept when empty
 }
 
-func NewMsgIPALNodeClaim(operator sdk.AccAddress, moniker, website, details, extension string, endpoints Endpoints, bond sdk.Coin) MsgIPALNodeClaim {
+func NewMsgIPALNodeClaim(operator sdk.AccAddress, moniker, website, details, extension string, endpoints Endpoints,
+	bond sdk.Coin, publicKeys PublicKeys) MsgIPALNodeClaim {
 	return MsgIPALNodeClaim{
 		OperatorAddress: operator,
 		Moniker:         moniker,
@@ -59,6 +75,7 @@ func NewMsgIPALNodeClaim(operator sdk.AccAddress, moniker, website, details, ext
 		Extension:       extension,
 		Endpoints:       endpoints,
 		Bond:            bond,
+		PublicKeys:      publicKeys,
 	}
 }
 
@@ -90,7 +107,7 @@ func (msg MsgIPALNodeClaim) ValidateBasic() error {
 		return err
 	}
 
-	return nil
+	return msg.PublicKeys.Validate()
 }
 
 func (msg *MsgIPALNodeClaim) TrimSpace() {
@@ -108,3 +125,242 @@ func (msg MsgIPALNodeClaim) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(msg)
 	return sdk.MustSortJSON(bz)
 }
+
+// MsgIPALAvailabilityReport reports whether the endpoints of some ipal nodes are reachable, it is
+// signed by a bonded validator operator or one of the reporters of the params
+type MsgIPALAvailabilityReport struct {
+	Reporter sdk.AccAddress     `json:"reporter" yaml:"reporter"`
+	Reports  []NodeAvailability `json:"reports" yaml:"reports"`
+}
+
+func NewMsgIPALAvailabilityReport(reporter sdk.AccAddress, reports []NodeAvailability) MsgIPALAvailabilityReport {
+	return MsgIPALAvailabilityReport{
+		Reporter: reporter,
+		Reports:  reports,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALAvailabilityReport) Route() string { return RouterKey }
+func (msg MsgIPALAvailabilityReport) Type() string  { return TypeMsgIPALAvailabilityReport }
+func (msg MsgIPALAvailabilityReport) ValidateBasic() error {
+	if msg.Reporter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing reporter address")
+	}
+
+	if len(msg.Reports) == 0 {
+		return sdkerrors.Wrap(ErrEmptyInputs, "no availability report")
+	}
+
+	operators := make(map[string]bool, len(msg.Reports))
+	for _, report := range msg.Reports {
+		if report.Operator.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+		}
+		if operators[report.Operator.String()] {
+			return sdkerrors.Wrapf(ErrDuplicateReport, "operator: %s", report.Operator)
+		}
+		operators[report.Operator.String()] = true
+	}
+
+	return nil
+}
+
+func (msg MsgIPALAvailabilityReport) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Reporter}
+}
+
+func (msg MsgIPALAvailabilityReport) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALNodeUnjail puts a jailed ipal node back in the ranking once its jail duration is over
+type MsgIPALNodeUnjail struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+}
+
+func NewMsgIPALNodeUnjail(operator sdk.AccAddress) MsgIPALNodeUnjail {
+	return MsgIPALNodeUnjail{
+		OperatorAddress: operator,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALNodeUnjail) Route() string { return RouterKey }
+func (msg MsgIPALNodeUnjail) Type() string  { return TypeMsgIPALNodeUnjail }
+func (msg MsgIPALNodeUnjail) ValidateBasic() error {
+	if msg.OperatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	return nil
+}
+
+func (msg MsgIPALNodeUnjail) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OperatorAddress}
+}
+
+func (msg MsgIPALNodeUnjail) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALDelegate bonds Amount of the delegator to the ipal node of Operator, the delegated coins
+// count in the ranking of the node
+type MsgIPALDelegate struct {
+	Delegator sdk.AccAddress `json:"delegator" yaml:"delegator"`
+	Operator  sdk.AccAddress `json:"operator" yaml:"operator"`
+	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+func NewMsgIPALDelegate(delegator, operator sdk.AccAddress, amount sdk.Coin) MsgIPALDelegate {
+	return MsgIPALDelegate{
+		Delegator: delegator,
+		Operator:  operator,
+		Amount:    amount,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALDelegate) Route() string { return RouterKey }
+func (msg MsgIPALDelegate) Type() string  { return TypeMsgIPALDelegate }
+func (msg MsgIPALDelegate) ValidateBasic() error {
+	return validateDelegationMsg(msg.Delegator, msg.Operator, msg.Amount)
+}
+
+func (msg MsgIPALDelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+func (msg MsgIPALDelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALUndelegate unbonds Amount of the delegation of the delegator to the ipal node of Operator,
+// the coins are sent back to the delegator after the unbonding time
+type MsgIPALUndelegate struct {
+	Delegator sdk.AccAddress `json:"delegator" yaml:"delegator"`
+	Operator  sdk.AccAddress `json:"operator" yaml:"operator"`
+	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+func NewMsgIPALUndelegate(delegator, operator sdk.AccAddress, amount sdk.Coin) MsgIPALUndelegate {
+	return MsgIPALUndelegate{
+		Delegator: delegator,
+		Operator:  operator,
+		Amount:    amount,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALUndelegate) Route() string { return RouterKey }
+func (msg MsgIPALUndelegate) Type() string  { return TypeMsgIPALUndelegate }
+func (msg MsgIPALUndelegate) ValidateBasic() error {
+	return validateDelegationMsg(msg.Delegator, msg.Operator, msg.Amount)
+}
+
+func (msg MsgIPALUndelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+func (msg MsgIPALUndelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func validateDelegationMsg(delegator, operator sdk.AccAddress, amount sdk.Coin) error {
+	if delegator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing delegator address")
+	}
+
+	if operator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	if amount.Denom != sdk.NativeTokenName {
+		return sdkerrors.Wrapf(ErrBadDenom, "delegation denom must be %s", sdk.NativeTokenName)
+	}
+
+	if !amount.IsPositive() {
+		return sdkerrors.Wrap(ErrEmptyInputs, "delegation amount must > 0")
+	}
+
+	return nil
+}
+
+// MsgIPALWithdrawRewards withdraws the rewards of the delegation of the delegator to the ipal node
+// of Operator, the operator of the node withdraws its commission as well
+type MsgIPALWithdrawRewards struct {
+	Delegator sdk.AccAddress `json:"delegator" yaml:"delegator"`
+	Operator  sdk.AccAddress `json:"operator" yaml:"operator"`
+}
+
+func NewMsgIPALWithdrawRewards(delegator, operator sdk.AccAddress) MsgIPALWithdrawRewards {
+	return MsgIPALWithdrawRewards{
+		Delegator: delegator,
+		Operator:  operator,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALWithdrawRewards) Route() string { return RouterKey }
+func (msg MsgIPALWithdrawRewards) Type() string  { return TypeMsgIPALWithdrawRewards }
+func (msg MsgIPALWithdrawRewards) ValidateBasic() error {
+	if msg.Delegator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing delegator address")
+	}
+
+	if msg.Operator.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	return nil
+}
+
+func (msg MsgIPALWithdrawRewards) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+func (msg MsgIPALWithdrawRewards) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// MsgIPALSetCommission sets the share of the delegator rewards of the ipal node kept by its operator
+type MsgIPALSetCommission struct {
+	OperatorAddress sdk.AccAddress `json:"operator_address" yaml:"operator_address"`
+	Commission      sdk.Dec        `json:"commission" yaml:"commission"`
+}
+
+func NewMsgIPALSetCommission(operator sdk.AccAddress, commission sdk.Dec) MsgIPALSetCommission {
+	return MsgIPALSetCommission{
+		OperatorAddress: operator,
+		Commission:      commission,
+	}
+}
+
+// Implements Msg
+func (msg MsgIPALSetCommission) Route() string { return RouterKey }
+func (msg MsgIPALSetCommission) Type() string  { return TypeMsgIPALSetCommission }
+func (msg MsgIPALSetCommission) ValidateBasic() error {
+	if msg.OperatorAddress.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing operator address")
+	}
+
+	if msg.Commission.IsNil() || msg.Commission.IsNegative() || msg.Commission.GT(sdk.OneDec()) {
+		return sdkerrors.Wrapf(ErrInvalidCommission, "commission must be between 0 and 1: %s", msg.Commission)
+	}
+
+	return nil
+}
+
+func (msg MsgIPALSetCommission) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OperatorAddress}
+}
+
+func (msg MsgIPALSetCommission) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
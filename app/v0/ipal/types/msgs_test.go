@@ -20,7 +20,7 @@ var (
 )
 
 func TestMsgIPALNodeClaimRoute(t *testing.T) {
-	var msg = NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond)
+	var msg = NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond, nil)
 
 	require.Equal(t, msg.Route(), RouterKey)
 	require.Equal(t, msg.Type(), TypeMsgIPALNodeClaim)
@@ -42,14 +42,14 @@ func TestMsgIPALNodeClaimValidation(t *testing.T) {
 		valid bool
 		tx    MsgIPALNodeClaim
 	}{
-		{true, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond)}, // valid
-
-		{false, NewMsgIPALNodeClaim(emptyAddr, moniker, website, details, extension, endpoints, bond)}, // empty from addr
-		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, bond)},          // empty moniker
-		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, xnchCoin)},      //  other bond coins
-		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, negativeCoin)},  //  negative coins
-		{false, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, Endpoints{}, bond)},   // empty endpoints
-		{false, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, dupEndPoints, bond)},  // duplicate endpoints
+		{true, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond, nil)}, // valid
+
+		{false, NewMsgIPALNodeClaim(emptyAddr, moniker, website, details, extension, endpoints, bond, nil)}, // empty from addr
+		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, bond, nil)},          // empty moniker
+		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, xnchCoin, nil)},      //  other bond coins
+		{false, NewMsgIPALNodeClaim(addr1, "", website, details, extension, endpoints, negativeCoin, nil)},  //  negative coins
+		{false, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, Endpoints{}, bond, nil)},   // empty endpoints
+		{false, NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, dupEndPoints, bond, nil)},  // duplicate endpoints
 	}
 
 	for _, tc := range cases {
@@ -63,7 +63,7 @@ func TestMsgIPALNodeClaimValidation(t *testing.T) {
 }
 
 func TestMsgIPALNodeClaimGetSignBytes(t *testing.T) {
-	var msg = NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond)
+	var msg = NewMsgIPALNodeClaim(addr1, moniker, website, details, extension, endpoints, bond, nil)
 	res := msg.GetSignBytes()
 
 	expected := `{"type":"nch/IPALClaim","value":{"bond":{"amount":"1000000000000","denom":"pnch"},"details":"details","endpoints":[{"endpoint":"http://1.1.1.1","type":"1"},{"endpoint":"http://2.2.2.2","type":"3"}],"extension":"","moniker":"moniker","operator_address":"nch1veex7mg3k0xqr","website":"website"}}`
@@ -71,8 +71,81 @@ func TestMsgIPALNodeClaimGetSignBytes(t *testing.T) {
 }
 
 func TestMsgIPALNodeClaimGetSigners(t *testing.T) {
-	var msg = NewMsgIPALNodeClaim(sdk.AccAddress([]byte("input1")), moniker, website, details, extension, endpoints, bond)
+	var msg = NewMsgIPALNodeClaim(sdk.AccAddress([]byte("input1")), moniker, website, details, extension, endpoints, bond, nil)
 	res := msg.GetSigners()
 
 	require.Equal(t, fmt.Sprintf("%v", res), "[696E70757431]")
 }
+
+func TestMsgIPALAvailabilityReportValidation(t *testing.T) {
+	var emptyAddr sdk.AccAddress
+	operator := sdk.AccAddress([]byte("operator"))
+	available := NewNodeAvailability(operator, true)
+
+	cases := []struct {
+		valid bool
+		tx    MsgIPALAvailabilityReport
+	}{
+		{true, NewMsgIPALAvailabilityReport(addr1, []NodeAvailability{available})},                                        // valid
+		{false, NewMsgIPALAvailabilityReport(emptyAddr, []NodeAvailability{available})},                                   // empty reporter
+		{false, NewMsgIPALAvailabilityReport(addr1, nil)},                                                                 // no report
+		{false, NewMsgIPALAvailabilityReport(addr1, []NodeAvailability{NewNodeAvailability(emptyAddr, true)})},            // empty operator
+		{false, NewMsgIPALAvailabilityReport(addr1, []NodeAvailability{available, NewNodeAvailability(operator, false)})}, // duplicate operator
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+
+	require.Equal(t, []sdk.AccAddress{addr1}, NewMsgIPALAvailabilityReport(addr1, nil).GetSigners())
+}
+
+func TestMsgIPALNodeUnjailValidation(t *testing.T) {
+	require.Nil(t, NewMsgIPALNodeUnjail(addr1).ValidateBasic())
+	require.NotNil(t, NewMsgIPALNodeUnjail(nil).ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{addr1}, NewMsgIPALNodeUnjail(addr1).GetSigners())
+}
+
+func TestMsgIPALDelegateValidation(t *testing.T) {
+	var emptyAddr sdk.AccAddress
+	operator := sdk.AccAddress([]byte("operator"))
+	amount := sdk.NewInt64Coin(sdk.NativeTokenName, 1000)
+
+	cases := []struct {
+		valid bool
+		tx    MsgIPALDelegate
+	}{
+		{true, NewMsgIPALDelegate(addr1, operator, amount)},                                    // valid
+		{false, NewMsgIPALDelegate(emptyAddr, operator, amount)},                               // empty delegator
+		{false, NewMsgIPALDelegate(addr1, emptyAddr, amount)},                                  // empty operator
+		{false, NewMsgIPALDelegate(addr1, operator, sdk.NewInt64Coin("stake", 1000))},          // bad denom
+		{false, NewMsgIPALDelegate(addr1, operator, sdk.NewInt64Coin(sdk.NativeTokenName, 0))}, // zero amount
+	}
+
+	for _, tc := range cases {
+		err := tc.tx.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+
+	require.Equal(t, []sdk.AccAddress{addr1}, NewMsgIPALUndelegate(addr1, operator, amount).GetSigners())
+	require.NotNil(t, NewMsgIPALUndelegate(addr1, operator, sdk.NewInt64Coin(sdk.NativeTokenName, 0)).ValidateBasic())
+	require.Nil(t, NewMsgIPALWithdrawRewards(addr1, operator).ValidateBasic())
+	require.NotNil(t, NewMsgIPALWithdrawRewards(addr1, emptyAddr).ValidateBasic())
+}
+
+func TestMsgIPALSetCommissionValidation(t *testing.T) {
+	require.Nil(t, NewMsgIPALSetCommission(addr1, sdk.NewDecWithPrec(1, 1)).ValidateBasic())
+	require.Nil(t, NewMsgIPALSetCommission(addr1, sdk.OneDec()).ValidateBasic())
+	require.NotNil(t, NewMsgIPALSetCommission(addr1, sdk.NewDecWithPrec(11, 1)).ValidateBasic())
+	require.NotNil(t, NewMsgIPALSetCommission(addr1, sdk.NewDec(-1)).ValidateBasic())
+	require.NotNil(t, NewMsgIPALSetCommission(nil, sdk.ZeroDec()).ValidateBasic())
+}
@@ -9,29 +9,90 @@ import (
 )
 
 const (
-	DefaultUnbondingTime = time.Hour * 24 * 7
+	DefaultUnbondingTime        = time.Hour * 24 * 7
+	DefaultReportWindow         = int64(1000)
+	DefaultMinReports           = uint64(3)
+	DefaultJailDuration         = time.Hour * 24
+	DefaultSlashToCommunityPool = true
+
+	// CommissionUpdateInterval is the min time between two changes of the commission of a node
+	CommissionUpdateInterval = time.Hour * 24
 )
 
 var (
-	DefaultMinBond = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))
+	DefaultMinBond               = sdk.NewCoin(sdk.NativeTokenName, sdk.NewInt(sdk.NativeTokenFraction))
+	DefaultMinAvailability       = sdk.NewDecWithPrec(5, 1)
+	DefaultSlashFraction         = sdk.NewDecWithPrec(1, 2)
+	DefaultDelegatorRewardsShare = sdk.ZeroDec()
+
+	DefaultMaxCommission           = sdk.NewDecWithPrec(2, 1)
+	DefaultMaxCommissionChangeRate = sdk.NewDecWithPrec(1, 2)
 )
 
 var (
-	KeyUnbondingTime = []byte("UnbondingTime")
-	KeyMinBond       = []byte("MinBond")
+	KeyUnbondingTime         = []byte("UnbondingTime")
+	KeyMinBond               = []byte("MinBond")
+	KeyReportWindow          = []byte("ReportWindow")
+	KeyMinReports            = []byte("MinReports")
+	KeyMinAvailability       = []byte("MinAvailability")
+	KeySlashFraction         = []byte("SlashFraction")
+	KeyJailDuration          = []byte("JailDuration")
+	KeySlashToCommunityPool  = []byte("SlashToCommunityPool")
+	KeyReporters             = []byte("Reporters")
+	KeyDelegatorRewardsShare = []byte("DelegatorRewardsShare")
+	KeyEndpointTypes         = []byte("EndpointTypes")
+
+	KeyMaxCommission           = []byte("MaxCommission")
+	KeyMaxCommissionChangeRate = []byte("MaxCommissionChangeRate")
 )
 
+// Params of the ipal module. The availability of the nodes is tallied every ReportWindow blocks out
+// of the reports of the bonded validators and of the Reporters, a node reported at least MinReports
+// times with an availability below MinAvailability is jailed for JailDuration and SlashFraction of
+// its bond is slashed, sent to the community pool or burned. DelegatorRewardsShare of the fees and
+// inflation collected each block is shared out among the nodes by delegated coins, the operator of
+// each node keeps its commission and the rest goes to the delegators. The commission of a node is at
+// most MaxCommission, and is changed by at most MaxCommissionChangeRate once every
+// CommissionUpdateInterval. The endpoints of the nodes must
+// be of the EndpointTypes when some are registered.
 type Params struct {
-	UnbondingTime time.Duration `json:"unbonding_time" yaml:"unbonding_time"`
-	MinBond       sdk.Coin      `json:"min_bond" yaml:"min_bond"`
+	UnbondingTime         time.Duration    `json:"unbonding_time" yaml:"unbonding_time"`
+	MinBond               sdk.Coin         `json:"min_bond" yaml:"min_bond"`
+	ReportWindow          int64            `json:"report_window" yaml:"report_window"`
+	MinReports            uint64           `json:"min_reports" yaml:"min_reports"`
+	MinAvailability       sdk.Dec          `json:"min_availability" yaml:"min_availability"`
+	SlashFraction         sdk.Dec          `json:"slash_fraction" yaml:"slash_fraction"`
+	JailDuration          time.Duration    `json:"jail_duration" yaml:"jail_duration"`
+	SlashToCommunityPool  bool             `json:"slash_to_community_pool" yaml:"slash_to_community_pool"`
+	Reporters             []sdk.AccAddress `json:"reporters" yaml:"reporters"`
+	DelegatorRewardsShare sdk.Dec          `json:"delegator_rewards_share" yaml:"delegator_rewards_share"`
+	EndpointTypes         TypeSpecs        `json:"endpoint_types" yaml:"endpoint_types"`
+
+	MaxCommission           sdk.Dec `json:"max_commission" yaml:"max_commission"`
+	MaxCommissionChangeRate sdk.Dec `json:"max_commission_change_rate" yaml:"max_commission_change_rate"`
 }
 
 var _ params.ParamSet = (*Params)(nil)
 
-func NewParams(unbondingTime time.Duration, minBond sdk.Coin) Params {
+func NewParams(unbondingTime time.Duration, minBond sdk.Coin, reportWindow int64, minReports uint64,
+	minAvailability, slashFraction sdk.Dec, jailDuration time.Duration, slashToCommunityPool bool,
+	reporters []sdk.AccAddress, delegatorRewardsShare sdk.Dec, endpointTypes TypeSpecs,
+	maxCommission, maxCommissionChangeRate sdk.Dec) Params {
 	return Params{
-		UnbondingTime: unbondingTime,
-		MinBond:       minBond,
+		UnbondingTime:         unbondingTime,
+		MinBond:               minBond,
+		ReportWindow:          reportWindow,
+		MinReports:            minReports,
+		MinAvailability:       minAvailability,
+		SlashFraction:         slashFraction,
+		JailDuration:          jailDuration,
+		SlashToCommunityPool:  slashToCommunityPool,
+		Reporters:             reporters,
+		DelegatorRewardsShare: delegatorRewardsShare,
+		EndpointTypes:         endpointTypes,
+
+		MaxCommission:           maxCommission,
+		MaxCommissionChangeRate: maxCommissionChangeRate,
 	}
 }
 
@@ -39,6 +100,17 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(KeyUnbondingTime, &p.UnbondingTime, validateUnbondingTime),
 		params.NewParamSetPair(KeyMinBond, &p.MinBond, validateMinBond),
+		params.NewParamSetPair(KeyReportWindow, &p.ReportWindow, validateReportWindow),
+		params.NewParamSetPair(KeyMinReports, &p.MinReports, validateMinReports),
+		params.NewParamSetPair(KeyMinAvailability, &p.MinAvailability, validateFraction),
+		params.NewParamSetPair(KeySlashFraction, &p.SlashFraction, validateFraction),
+		params.NewParamSetPair(KeyJailDuration, &p.JailDuration, validateJailDuration),
+		params.NewParamSetPair(KeySlashToCommunityPool, &p.SlashToCommunityPool, validateSlashToCommunityPool),
+		params.NewParamSetPair(KeyReporters, &p.Reporters, validateReporters),
+		params.NewParamSetPair(KeyDelegatorRewardsShare, &p.DelegatorRewardsShare, validateFraction),
+		params.NewParamSetPair(KeyEndpointTypes, &p.EndpointTypes, validateTypeSpecs),
+		params.NewParamSetPair(KeyMaxCommission, &p.MaxCommission, validateFraction),
+		params.NewParamSetPair(KeyMaxCommissionChangeRate, &p.MaxCommissionChangeRate, validateFraction),
 	}
 }
 
@@ -46,15 +118,48 @@ func DefaultParams() Params {
 	return NewParams(
 		DefaultUnbondingTime,
 		DefaultMinBond,
+		DefaultReportWindow,
+		DefaultMinReports,
+		DefaultMinAvailability,
+		DefaultSlashFraction,
+		DefaultJailDuration,
+		DefaultSlashToCommunityPool,
+		nil,
+		DefaultDelegatorRewardsShare,
+		nil,
+		DefaultMaxCommission,
+		DefaultMaxCommissionChangeRate,
 	)
 }
 
 func (p Params) String() string {
 	return fmt.Sprintf(`Params:
   Unbonding Time    : %s
-  Min Bond   : %v`,
+  Min Bond   : %v
+  Report Window   : %d
+  Min Reports   : %d
+  Min Availability   : %s
+  Slash Fraction   : %s
+  Jail Duration   : %s
+  Slash To Community Pool   : %t
+  Reporters   : %v
+  Delegator Rewards Share   : %s
+  Endpoint Types   : %v
+  Max Commission   : %s
+  Max Commission Change Rate   : %s`,
 		p.UnbondingTime,
-		p.MinBond)
+		p.MinBond,
+		p.ReportWindow,
+		p.MinReports,
+		p.MinAvailability,
+		p.SlashFraction,
+		p.JailDuration,
+		p.SlashToCommunityPool,
+		p.Reporters,
+		p.DelegatorRewardsShare,
+		p.EndpointTypes,
+		p.MaxCommission,
+		p.MaxCommissionChangeRate)
 }
 
 func validateUnbondingTime(i interface{}) error {
@@ -74,3 +179,88 @@ func validateMinBond(i interface{}) error {
 	// TODO
 	return nil
 }
+
+func validateReportWindow(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("report window must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMinReports(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("min reports must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("fraction must be between 0 and 1: %s", v)
+	}
+
+	return nil
+}
+
+func validateJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("jail duration must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateSlashToCommunityPool(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateReporters(i interface{}) error {
+	v, ok := i.([]sdk.AccAddress)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, reporter := range v {
+		if reporter.Empty() {
+			return fmt.Errorf("empty reporter address")
+		}
+	}
+
+	return nil
+}
+
+func validateTypeSpecs(i interface{}) error {
+	v, ok := i.(TypeSpecs)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return v.Validate()
+}
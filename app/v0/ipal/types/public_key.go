@@ -0,0 +1,192 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// The types of the messaging public keys, the x25519 keys are 32 bytes long
+const (
+	PublicKeyTypeX25519Identity     = "x25519_identity"
+	PublicKeyTypeX25519SignedPrekey = "x25519_signed_prekey"
+
+	x25519KeySize = 32
+)
+
+// The size limits of the published public keys
+const (
+	MaxPublicKeys             = 8
+	MaxPublicKeyTypeLength    = 32
+	MaxPublicKeySize          = 256
+	MaxPublicKeySignatureSize = 128
+)
+
+// PublicKey is a messaging public key published by an ipal node or a cipal user, a signed prekey
+// carries its signature by the identity key
+type PublicKey struct {
+	Type      string `json:"type" yaml:"type"`
+	Key       []byte `json:"key" yaml:"key"`
+	Signature []byte `json:"signature,omitempty" yaml:"signature"`
+}
+
+func NewPublicKey(keyType string, key, signature []byte) PublicKey {
+	return PublicKey{
+		Type:      keyType,
+		Key:       key,
+		Signature: signature,
+	}
+}
+
+func (k PublicKey) String() string {
+	if len(k.Signature) == 0 {
+		return fmt.Sprintf("%s %X", k.Type, k.Key)
+	}
+	return fmt.Sprintf("%s %X signature %X", k.Type, k.Key, k.Signature)
+}
+
+// Equal returns whether both keys are the same
+func (k PublicKey) Equal(other PublicKey) bool {
+	return k.Type == other.Type && bytes.Equal(k.Key, other.Key) && bytes.Equal(k.Signature, other.Signature)
+}
+
+type PublicKeys []PublicKey
+
+// Validate checks the number, the types and the sizes of the keys, each type is published once
+func (keys PublicKeys) Validate() error {
+	if len(keys) > MaxPublicKeys {
+		return sdkerrors.Wrapf(ErrInvalidPublicKeys, "too many public keys: %d, max %d", len(keys), MaxPublicKeys)
+	}
+
+	types := make(map[string]bool)
+	for _, k := range keys {
+		if k.Type == "" || len(k.Type) > MaxPublicKeyTypeLength {
+			return sdkerrors.Wrapf(ErrInvalidPublicKeys, "public key type empty or too long: %s", k.Type)
+		}
+		if types[k.Type] {
+			return sdkerrors.Wrapf(ErrInvalidPublicKeys, "duplicate public key type: %s", k.Type)
+		}
+		types[k.Type] = true
+
+		if len(k.Key) == 0 || len(k.Key) > MaxPublicKeySize {
+			return sdkerrors.Wrapf(ErrInvalidPublicKeys, "%s public key size %d, max %d", k.Type, len(k.Key), MaxPublicKeySize)
+		}
+		if len(k.Signature) > MaxPublicKeySignatureSize {
+			return sdkerrors.Wrapf(ErrInvalidPublicKeys, "%s signature size %d, max %d", k.Type, len(k.Signature), MaxPublicKeySignatureSize)
+		}
+
+		switch k.Type {
+		case PublicKeyTypeX25519Identity, PublicKeyTypeX25519SignedPrekey:
+			if len(k.Key) != x25519KeySize {
+				return sdkerrors.Wrapf(ErrInvalidPublicKeys, "%s public key must be %d bytes long", k.Type, x25519KeySize)
+			}
+		}
+		if k.Type == PublicKeyTypeX25519SignedPrekey && len(k.Signature) == 0 {
+			return sdkerrors.Wrapf(ErrInvalidPublicKeys, "%s without signature", k.Type)
+		}
+	}
+
+	return nil
+}
+
+// Equal returns whether both key sets hold the same keys in the same order
+func (keys PublicKeys) Equal(other PublicKeys) bool {
+	if len(keys) != len(other) {
+		return false
+	}
+	for i := range keys {
+		if !keys[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (keys PublicKeys) String() (out string) {
+	for _, k := range keys {
+		out += k.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// PublicKeysFromString parses keys in format: type=hexKey[:hexSignature],type=hexKey[:hexSignature]
+func PublicKeysFromString(s string) (keys PublicKeys, err error) {
+	for _, keyString := range strings.Split(s, ",") {
+		keyString = strings.TrimSpace(keyString)
+		if keyString == "" {
+			continue
+		}
+
+		typeAndKey := strings.SplitN(keyString, "=", 2)
+		if len(typeAndKey) != 2 {
+			return nil, sdkerrors.Wrapf(ErrInvalidPublicKeys, "should be in format: type=hexKey[:hexSignature], got %s", keyString)
+		}
+
+		keyAndSig := strings.SplitN(typeAndKey[1], ":", 2)
+		key, err := hex.DecodeString(keyAndSig[0])
+		if err != nil {
+			return nil, sdkerrors.Wrapf(ErrInvalidPublicKeys, "invalid hex key of %s: %v", typeAndKey[0], err)
+		}
+
+		var sig []byte
+		if len(keyAndSig) == 2 {
+			if sig, err = hex.DecodeString(keyAndSig[1]); err != nil {
+				return nil, sdkerrors.Wrapf(ErrInvalidPublicKeys, "invalid hex signature of %s: %v", typeAndKey[0], err)
+			}
+		}
+
+		keys = append(keys, NewPublicKey(strings.TrimSpace(typeAndKey[0]), key, sig))
+	}
+
+	return keys, keys.Validate()
+}
+
+// KeySet is a version of the public keys published by Owner, an ipal node operator or a cipal user,
+// at Height. The versions are numbered from 1, each change of the keys publishes a new version.
+type KeySet struct {
+	Owner   string     `json:"owner" yaml:"owner"`
+	Version uint64     `json:"version" yaml:"version"`
+	Height  int64      `json:"height" yaml:"height"`
+	Keys    PublicKeys `json:"keys" yaml:"keys"`
+}
+
+func NewKeySet(owner string, version uint64, height int64, keys PublicKeys) KeySet {
+	return KeySet{
+		Owner:   owner,
+		Version: version,
+		Height:  height,
+		Keys:    keys,
+	}
+}
+
+func (s KeySet) String() string {
+	return fmt.Sprintf(`KeySet:
+  Owner:   %s
+  Version: %d
+  Height:  %d
+  Keys:
+%s`, s.Owner, s.Version, s.Height, s.Keys)
+}
+
+// KeySets are the versions of the keys of an owner, the last one is the current one
+type KeySets []KeySet
+
+func (s KeySets) String() (out string) {
+	for _, set := range s {
+		out += set.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func MustMarshalKeySet(cdc *codec.Codec, set KeySet) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(set)
+}
+
+func MustUnmarshalKeySet(cdc *codec.Codec, value []byte) (set KeySet) {
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &set)
+	return set
+}
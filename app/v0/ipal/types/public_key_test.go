@@ -0,0 +1,33 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKeysValidate(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	sig := bytes.Repeat([]byte{2}, 64)
+
+	require.NoError(t, PublicKeys{
+		NewPublicKey(PublicKeyTypeX25519Identity, key, nil),
+		NewPublicKey(PublicKeyTypeX25519SignedPrekey, key, sig),
+	}.Validate())
+
+	for _, keys := range []PublicKeys{
+		{NewPublicKey(PublicKeyTypeX25519Identity, key[:31], nil)},
+		{NewPublicKey(PublicKeyTypeX25519SignedPrekey, key, nil)},
+		{NewPublicKey(PublicKeyTypeX25519Identity, key, nil), NewPublicKey(PublicKeyTypeX25519Identity, key, nil)},
+		{NewPublicKey("", key, nil)},
+		{NewPublicKey("ed25519", make([]byte, MaxPublicKeySize+1), nil)},
+		{NewPublicKey("ed25519", key, make([]byte, MaxPublicKeySignatureSize+1))},
+	} {
+		require.True(t, ErrInvalidPublicKeys.Is(keys.Validate()), "%v", keys)
+	}
+
+	keys, err := PublicKeysFromString("x25519_identity=0101010101010101010101010101010101010101010101010101010101010101")
+	require.NoError(t, err)
+	require.Equal(t, PublicKeys{NewPublicKey(PublicKeyTypeX25519Identity, key, nil)}, keys)
+}
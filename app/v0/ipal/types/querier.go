@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -9,8 +12,82 @@ const (
 	QueryIPALNode     = "node"
 	QueryIPALNodes    = "nodes"
 	QueryParameters   = "params"
+	QueryReports      = "reports"
+	QueryDelegation   = "delegation"
+	QueryDelegations  = "delegations"
+	QueryRewards      = "rewards"
+	QueryPublicKeys   = "public_keys"
 )
 
+const (
+	OrderByBond    = "bond"
+	OrderByMoniker = "moniker"
+)
+
+// QueryIPALNodeListParams are the params of the query of the ranked ipal nodes. The nodes are
+// ordered by total bond, highest first, or by moniker, filtered by EndpointType, MinBond and
+// MonikerPrefix when they are set, then Offset nodes are skipped and at most Limit nodes are
+// returned, Limit 0 returns all of them. After is a cursor: the listing resumes after the node of
+// this operator, usually the last node of the previous page.
+type QueryIPALNodeListParams struct {
+	Offset        int            `json:"offset"`
+	Limit         int            `json:"limit"`
+	After         sdk.AccAddress `json:"after"`
+	EndpointType  uint64         `json:"endpoint_type"`
+	MinBond       int64          `json:"min_bond"`
+	MonikerPrefix string         `json:"moniker_prefix"`
+	OrderBy       string         `json:"order_by"`
+}
+
+func NewQueryIPALNodeListParams(offset, limit int, after sdk.AccAddress, endpointType uint64, minBond int64,
+	monikerPrefix, orderBy string) QueryIPALNodeListParams {
+	return QueryIPALNodeListParams{
+		Offset:        offset,
+		Limit:         limit,
+		After:         after,
+		EndpointType:  endpointType,
+		MinBond:       minBond,
+		MonikerPrefix: monikerPrefix,
+		OrderBy:       orderBy,
+	}
+}
+
+// Validate checks the pagination and the order of the params
+func (p QueryIPALNodeListParams) Validate() error {
+	if p.Offset < 0 || p.Limit < 0 {
+		return fmt.Errorf("offset and limit must not be negative: %d, %d", p.Offset, p.Limit)
+	}
+
+	switch p.OrderBy {
+	case "", OrderByBond, OrderByMoniker:
+		return nil
+	default:
+		return fmt.Errorf("invalid order: %s, expected %s or %s", p.OrderBy, OrderByBond, OrderByMoniker)
+	}
+}
+
+// Matches returns whether the node passes the filters of the params
+func (p QueryIPALNodeListParams) Matches(node IPALNode) bool {
+	if p.EndpointType != 0 {
+		found := false
+		for _, endpoint := range node.Endpoints {
+			if endpoint.Type == p.EndpointType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if node.TotalBond().Amount.LT(sdk.NewInt(p.MinBond)) {
+		return false
+	}
+
+	return strings.HasPrefix(node.Moniker, p.MonikerPrefix)
+}
+
 type QueryIPALNodeParams struct {
 	AccAddr sdk.AccAddress
 }
@@ -24,3 +101,42 @@ func NewQueryIPALNodeParams(accAddr sdk.AccAddress) QueryIPALNodeParams {
 		AccAddr: accAddr,
 	}
 }
+
+// QueryAvailabilityReportsParams are the params of the query of the availability reports of the
+// current window about the node of Operator
+type QueryAvailabilityReportsParams struct {
+	Operator sdk.AccAddress `json:"operator"`
+}
+
+func NewQueryAvailabilityReportsParams(operator sdk.AccAddress) QueryAvailabilityReportsParams {
+	return QueryAvailabilityReportsParams{
+		Operator: operator,
+	}
+}
+
+// QueryDelegationParams are the params of the queries of the delegation of Delegator to the node
+// of Operator and of its rewards, only Operator is used by the query of the delegations to a node
+type QueryDelegationParams struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Operator  sdk.AccAddress `json:"operator"`
+}
+
+func NewQueryDelegationParams(delegator, operator sdk.AccAddress) QueryDelegationParams {
+	return QueryDelegationParams{
+		Delegator: delegator,
+		Operator:  operator,
+	}
+}
+
+// QueryRewardsResponse is the response of the rewards query, Commission is only set for the
+// operator of the node
+type QueryRewardsResponse struct {
+	Rewards    sdk.DecCoins `json:"rewards" yaml:"rewards"`
+	Commission sdk.DecCoins `json:"commission" yaml:"commission"`
+}
+
+func (r QueryRewardsResponse) String() string {
+	return fmt.Sprintf(`Rewards:
+  Rewards:    %s
+  Commission: %s`, r.Rewards, r.Commission)
+}
@@ -0,0 +1,188 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// The address formats of the registered types
+const (
+	AddressFormatAny       = ""
+	AddressFormatURL       = "url"
+	AddressFormatHostPort  = "host_port"
+	AddressFormatMultiaddr = "multiaddr"
+	AddressFormatBech32    = "bech32"
+)
+
+// TypeSpec registers the type ID of an ipal endpoint or a cipal service, with its human name and
+// the format of its addresses
+type TypeSpec struct {
+	ID            uint64 `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	AddressFormat string `json:"address_format" yaml:"address_format"`
+}
+
+func NewTypeSpec(id uint64, name, addressFormat string) TypeSpec {
+	return TypeSpec{
+		ID:            id,
+		Name:          name,
+		AddressFormat: addressFormat,
+	}
+}
+
+func (s TypeSpec) String() string {
+	return fmt.Sprintf("%d %s %s", s.ID, s.Name, s.AddressFormat)
+}
+
+// ValidateAddress checks that address is in the format of the type
+func (s TypeSpec) ValidateAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("empty %s address", s.Name)
+	}
+
+	var err error
+	switch s.AddressFormat {
+	case AddressFormatAny:
+	case AddressFormatURL:
+		var u *url.URL
+		if u, err = url.Parse(address); err == nil && (u.Scheme == "" || u.Host == "") {
+			err = fmt.Errorf("scheme and host required")
+		}
+	case AddressFormatHostPort:
+		err = validateHostPort(address)
+	case AddressFormatMultiaddr:
+		err = validateMultiaddr(address)
+	case AddressFormatBech32:
+		_, err = sdk.AccAddressFromBech32(address)
+	default:
+		err = fmt.Errorf("unknown address format %s", s.AddressFormat)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s address %s, expected %s format: %v", s.Name, address, s.AddressFormat, err)
+	}
+	return nil
+}
+
+// TypeSpecs is a registry of types, an empty registry accepts all the types and addresses
+type TypeSpecs []TypeSpec
+
+// Get returns the spec of the type id
+func (specs TypeSpecs) Get(id uint64) (TypeSpec, bool) {
+	for _, s := range specs {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return TypeSpec{}, false
+}
+
+// ValidateAddress checks that the type id is registered and address is in its format
+func (specs TypeSpecs) ValidateAddress(id uint64, address string) error {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	s, found := specs.Get(id)
+	if !found {
+		return fmt.Errorf("unregistered type %d", id)
+	}
+	return s.ValidateAddress(address)
+}
+
+// Validate checks that the ids and names are unique and the address formats known
+func (specs TypeSpecs) Validate() error {
+	ids := make(map[uint64]bool)
+	names := make(map[string]bool)
+	for _, s := range specs {
+		if s.ID == 0 {
+			return fmt.Errorf("type id 0 is reserved")
+		}
+		if ids[s.ID] {
+			return fmt.Errorf("duplicate type id %d", s.ID)
+		}
+		if s.Name == "" || names[s.Name] {
+			return fmt.Errorf("empty or duplicate type name %s", s.Name)
+		}
+		ids[s.ID], names[s.Name] = true, true
+
+		switch s.AddressFormat {
+		case AddressFormatAny, AddressFormatURL, AddressFormatHostPort, AddressFormatMultiaddr, AddressFormatBech32:
+		default:
+			return fmt.Errorf("unknown address format %s of type %d", s.AddressFormat, s.ID)
+		}
+	}
+	return nil
+}
+
+func (specs TypeSpecs) String() (out string) {
+	for _, s := range specs {
+		out += s.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func validateHostPort(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("empty host")
+	}
+	return validatePort(port)
+}
+
+func validatePort(port string) error {
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return fmt.Errorf("invalid port %s", port)
+	}
+	return nil
+}
+
+// validateMultiaddr checks the textual form of a multiaddr made of the usual transport protocols,
+// e.g. /ip4/127.0.0.1/tcp/4001/p2p/<peer id>
+func validateMultiaddr(address string) error {
+	if !strings.HasPrefix(address, "/") {
+		return fmt.Errorf("must start with /")
+	}
+
+	parts := strings.Split(address[1:], "/")
+	for i := 0; i < len(parts); i++ {
+		protocol := parts[i]
+		switch protocol {
+		case "quic", "ws", "wss", "http", "https":
+			continue
+		}
+
+		if i+1 >= len(parts) || parts[i+1] == "" {
+			return fmt.Errorf("missing value of %s", protocol)
+		}
+		i++
+		value := parts[i]
+
+		switch protocol {
+		case "ip4":
+			if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+				return fmt.Errorf("invalid ip4 %s", value)
+			}
+		case "ip6":
+			if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+				return fmt.Errorf("invalid ip6 %s", value)
+			}
+		case "dns", "dns4", "dns6", "dnsaddr", "p2p", "ipfs":
+		case "tcp", "udp":
+			if err := validatePort(value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported protocol %s", protocol)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeSpecValidateAddress(t *testing.T) {
+	tests := []struct {
+		format  string
+		address string
+		valid   bool
+	}{
+		{AddressFormatAny, "anything", true},
+		{AddressFormatAny, "", false},
+		{AddressFormatURL, "https://chat.example.com:8443/im", true},
+		{AddressFormatURL, "chat.example.com", false},
+		{AddressFormatHostPort, "192.168.1.100:666", true},
+		{AddressFormatHostPort, "[::1]:443", true},
+		{AddressFormatHostPort, "chat.example.com", false},
+		{AddressFormatHostPort, "chat.example.com:0", false},
+		{AddressFormatMultiaddr, "/ip4/127.0.0.1/tcp/4001/p2p/QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC", true},
+		{AddressFormatMultiaddr, "/dns4/chat.example.com/tcp/443/wss", true},
+		{AddressFormatMultiaddr, "/ip4/::1/tcp/4001", false},
+		{AddressFormatMultiaddr, "/ip4/127.0.0.1/tcp", false},
+		{AddressFormatMultiaddr, "ip4/127.0.0.1", false},
+		{AddressFormatBech32, "nch1khneh5rr978lv6rz2f55aj6u83s5efrky37477", true},
+		{AddressFormatBech32, "nch1invalid", false},
+	}
+
+	for _, tt := range tests {
+		err := NewTypeSpec(1, "test", tt.format).ValidateAddress(tt.address)
+		require.Equal(t, tt.valid, err == nil, "%s %s: %v", tt.format, tt.address, err)
+	}
+}
+
+func TestTypeSpecs(t *testing.T) {
+	specs := TypeSpecs{NewTypeSpec(1, "server", AddressFormatHostPort), NewTypeSpec(2, "web", AddressFormatURL)}
+	require.NoError(t, specs.Validate())
+
+	require.NoError(t, specs.ValidateAddress(1, "192.168.1.100:666"))
+	require.Error(t, specs.ValidateAddress(2, "192.168.1.100:666"))
+	require.Error(t, specs.ValidateAddress(3, "192.168.1.100:666"))
+
+	// an empty registry accepts all the types
+	require.NoError(t, TypeSpecs{}.ValidateAddress(3, "192.168.1.100:666"))
+
+	require.Error(t, TypeSpecs{NewTypeSpec(0, "zero", AddressFormatAny)}.Validate())
+	require.Error(t, append(specs, NewTypeSpec(1, "other", AddressFormatAny)).Validate())
+	require.Error(t, append(specs, NewTypeSpec(3, "web", AddressFormatAny)).Validate())
+	require.Error(t, append(specs, NewTypeSpec(3, "other", "email")).Validate())
+}
@@ -2,17 +2,20 @@ package v0
 
 import (
 	"github.com/netcloth/netcloth-chain/app/protocol"
+	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/auth/ante"
+	"github.com/netcloth/netcloth-chain/app/v0/authz"
 	"github.com/netcloth/netcloth-chain/app/v0/bank"
 	"github.com/netcloth/netcloth-chain/app/v0/cipal"
 	"github.com/netcloth/netcloth-chain/app/v0/crisis"
 	distr "github.com/netcloth/netcloth-chain/app/v0/distribution"
 	distrclient "github.com/netcloth/netcloth-chain/app/v0/distribution/client"
+	"github.com/netcloth/netcloth-chain/app/v0/feegrant"
 	"github.com/netcloth/netcloth-chain/app/v0/genaccounts"
 	"github.com/netcloth/netcloth-chain/app/v0/genutil"
 	"github.com/netcloth/netcloth-chain/app/v0/gov"
@@ -21,6 +24,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/mint"
 	"github.com/netcloth/netcloth-chain/app/v0/params"
 	paramsclient "github.com/netcloth/netcloth-chain/app/v0/params/client"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler"
 	"github.com/netcloth/netcloth-chain/app/v0/slashing"
 	"github.com/netcloth/netcloth-chain/app/v0/staking"
 	"github.com/netcloth/netcloth-chain/app/v0/supply"
@@ -28,6 +32,7 @@ import (
 	"github.com/netcloth/netcloth-chain/app/v0/upgrade/types"
 	"github.com/netcloth/netcloth-chain/app/v0/vm"
 	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/server"
 	sdk "github.com/netcloth/netcloth-chain/types"
 	"github.com/netcloth/netcloth-chain/types/module"
 )
@@ -55,6 +60,9 @@ var ModuleBasics = module.NewBasicManager(
 	vm.AppModuleBasic{},
 	upgrade.AppModuleBasic{},
 	guardian.AppModuleBasic{},
+	feegrant.AppModuleBasic{},
+	scheduler.AppModuleBasic{},
+	authz.AppModuleBasic{},
 )
 
 var maccPerms = map[string][]string{
@@ -64,7 +72,8 @@ var maccPerms = map[string][]string{
 	staking.BondedPoolName:    {supply.Burner, supply.Staking},
 	staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 	gov.ModuleName:            {supply.Burner},
-	ipal.ModuleName:           {supply.Staking},
+	ipal.ModuleName:           {supply.Staking, supply.Burner},
+	scheduler.ModuleName:      nil,
 }
 
 // ProtocolV0 is the struct of the original protocol
@@ -76,23 +85,26 @@ type ProtocolV0 struct {
 	moduleManager *module.Manager
 	simManager    *module.SimulationManager
 
-	accountKeeper  auth.AccountKeeper
-	refundKeeper   auth.RefundKeeper
-	bankKeeper     bank.Keeper
-	slashingKeeper slashing.Keeper
-	mintKeeper     mint.Keeper
-	distrKeeper    distr.Keeper
-	protocolKeeper sdk.ProtocolKeeper
-	govKeeper      gov.Keeper
-	crisisKeeper   crisis.Keeper
-	paramsKeeper   params.Keeper
-	supplyKeeper   supply.Keeper
-	stakingKeeper  staking.Keeper
-	ipalKeeper     ipal.Keeper
-	cipalKeeper    cipal.Keeper
-	vmKeeper       vm.Keeper
-	upgradeKeeper  upgrade.Keeper
-	guardianKeeper guardian.Keeper
+	accountKeeper   auth.AccountKeeper
+	refundKeeper    auth.RefundKeeper
+	bankKeeper      bank.Keeper
+	slashingKeeper  slashing.Keeper
+	mintKeeper      mint.Keeper
+	distrKeeper     distr.Keeper
+	protocolKeeper  sdk.ProtocolKeeper
+	govKeeper       gov.Keeper
+	crisisKeeper    crisis.Keeper
+	paramsKeeper    params.Keeper
+	supplyKeeper    supply.Keeper
+	stakingKeeper   staking.Keeper
+	ipalKeeper      ipal.Keeper
+	cipalKeeper     cipal.Keeper
+	vmKeeper        vm.Keeper
+	upgradeKeeper   upgrade.Keeper
+	guardianKeeper  guardian.Keeper
+	feegrantKeeper  feegrant.Keeper
+	schedulerKeeper scheduler.Keeper
+	authzKeeper     authz.Keeper
 
 	router      sdk.Router
 	queryRouter sdk.QueryRouter
@@ -222,6 +234,7 @@ func (p *ProtocolV0) configKeepers() {
 	cipalSubspace := p.paramsKeeper.Subspace(cipal.DefaultParamspace)
 	ipalSubspace := p.paramsKeeper.Subspace(ipal.DefaultParamspace)
 	vmSubspace := p.paramsKeeper.Subspace(vm.DefaultParamspace)
+	schedulerSubspace := p.paramsKeeper.Subspace(scheduler.DefaultParamspace)
 
 	p.accountKeeper = auth.NewAccountKeeper(p.cdc, protocol.Keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
 	p.refundKeeper = auth.NewRefundKeeper(p.cdc, protocol.Keys[auth.RefundKey])
@@ -237,18 +250,25 @@ func (p *ProtocolV0) configKeepers() {
 		p.cdc, protocol.Keys[slashing.StoreKey], &stakingKeeper, slashingSubspace)
 	p.crisisKeeper = crisis.NewKeeper(crisisSubspace, p.invCheckPeriod, p.supplyKeeper, auth.FeeCollectorName)
 
+	ipalKeeper := ipal.NewKeeper(
+		protocol.Keys[ipal.StoreKey],
+		p.cdc,
+		p.supplyKeeper,
+		&stakingKeeper,
+		p.distrKeeper,
+		ipalSubspace,
+		auth.FeeCollectorName,
+	)
+
 	p.cipalKeeper = cipal.NewKeeper(
 		protocol.Keys[cipal.StoreKey],
 		p.cdc,
+		ipalKeeper,
 		cipalSubspace,
 	)
 
-	p.ipalKeeper = ipal.NewKeeper(
-		protocol.Keys[ipal.StoreKey],
-		p.cdc,
-		p.supplyKeeper,
-		ipalSubspace,
-	)
+	// the cipal bindings to an ipal node are removed with the node
+	p.ipalKeeper = *ipalKeeper.SetHooks(p.cipalKeeper.Hooks())
 
 	p.vmKeeper = vm.NewKeeper(
 		p.cdc,
@@ -256,8 +276,31 @@ func (p *ProtocolV0) configKeepers() {
 		vmSubspace,
 		p.accountKeeper,
 	)
+	if maxLogsBlockRange := viper.GetUint64(server.FlagMaxLogsBlockRange); maxLogsBlockRange > 0 {
+		p.vmKeeper.SetMaxLogsBlockRange(maxLogsBlockRange)
+	}
+	p.vmKeeper.SetNativeKeepers(vm.NativeKeepers{
+		BankKeeper:    p.bankKeeper,
+		StakingKeeper: &stakingKeeper,
+		IPALKeeper:    p.ipalKeeper,
+		CIPALKeeper:   p.cipalKeeper,
+	})
 
 	p.guardianKeeper = guardian.NewKeeper(p.cdc, protocol.Keys[protocol.GuardianStoreKey])
+	p.feegrantKeeper = feegrant.NewKeeper(protocol.Keys[feegrant.StoreKey], p.cdc)
+
+	// scheduled msgs are executed through the router of the protocol
+	p.schedulerKeeper = scheduler.NewKeeper(
+		protocol.Keys[scheduler.StoreKey],
+		p.cdc,
+		schedulerSubspace,
+		p.supplyKeeper,
+		p.router,
+		auth.FeeCollectorName,
+	)
+
+	// authorized msgs are executed through the router of the protocol
+	p.authzKeeper = authz.NewKeeper(protocol.Keys[authz.StoreKey], p.cdc, p.router)
 
 	p.govKeeper = gov.NewKeeper(
 		p.cdc, protocol.Keys[gov.StoreKey], govSubspace, p.supplyKeeper,
@@ -267,7 +310,7 @@ func (p *ProtocolV0) configKeepers() {
 	govRouter := gov.NewRouter()
 	govRouter.
 		AddRoute(gov.RouterKey, gov.NewGovProposalHandler(p.govKeeper)).
-		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(p.paramsKeeper)).
+		AddRoute(params.RouterKey, vm.NewParamChangeProposalHandler(p.vmKeeper, params.NewParamChangeProposalHandler(p.paramsKeeper))).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(p.distrKeeper))
 
 	p.govKeeper.SetRouter(govRouter)
@@ -301,18 +344,25 @@ func (p *ProtocolV0) configModuleManager() {
 		vm.NewAppModule(p.vmKeeper),
 		upgrade.NewAppModule(p.upgradeKeeper),
 		guardian.NewAppModule(p.guardianKeeper),
+		feegrant.NewAppModule(p.feegrantKeeper),
+		scheduler.NewAppModule(p.schedulerKeeper),
+		authz.NewAppModule(p.authzKeeper),
 	)
 
 	moduleManager.SetOrderBeginBlockers(
 		mint.ModuleName,
+		ipal.ModuleName,
 		distr.ModuleName,
-		slashing.ModuleName)
+		slashing.ModuleName,
+		vm.ModuleName)
 
 	moduleManager.SetOrderEndBlockers(
 		crisis.ModuleName,
 		gov.ModuleName,
 		staking.ModuleName,
 		ipal.ModuleName,
+		scheduler.ModuleName,
+		feegrant.ModuleName,
 		vm.ModuleName,
 		upgrade.ModuleName,
 	)
@@ -337,6 +387,9 @@ func (p *ProtocolV0) configModuleManager() {
 		types.ModuleName,
 		guardian.ModuleName,
 		upgrade.ModuleName,
+		feegrant.ModuleName,
+		scheduler.ModuleName,
+		authz.ModuleName,
 	)
 
 	p.moduleManager = moduleManager
@@ -380,6 +433,9 @@ func (p *ProtocolV0) configSimulationManager() {
 
 func (p *ProtocolV0) configRouters() {
 	p.moduleManager.RegisterRoutes(p.router, p.queryRouter)
+
+	// meta txs dispatch their relayed msgs through the router of the protocol
+	p.router.AddRoute(auth.RouterKey, auth.NewHandler(p.accountKeeper, p.router, auth.DefaultTxDecoder(p.cdc)))
 }
 
 // InitChainer initializes application state at genesis as a hook
@@ -401,7 +457,7 @@ func (p *ProtocolV0) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.
 }
 
 func (p *ProtocolV0) configFeeHandlers() {
-	p.anteHandler = ante.NewAnteHandler(p.accountKeeper, p.supplyKeeper, ante.DefaultSigVerificationGasConsumer)
+	p.anteHandler = vm.NewAnteHandler(p.accountKeeper, p.supplyKeeper, p.feegrantKeeper, ante.DefaultSigVerificationGasConsumer)
 	p.feeRefundHandler = auth.NewFeeRefundHandler(p.accountKeeper, p.supplyKeeper, p.refundKeeper)
 }
 
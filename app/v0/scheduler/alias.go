@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	StoreKey          = types.StoreKey
+	RouterKey         = types.RouterKey
+	QuerierRoute      = types.QuerierRoute
+	DefaultParamspace = types.DefaultParamspace
+)
+
+var (
+	RegisterCodec          = types.RegisterCodec
+	NewKeeper              = keeper.NewKeeper
+	NewQuerier             = keeper.NewQuerier
+	NewSchedule            = types.NewSchedule
+	NewMsgCreateSchedule   = types.NewMsgCreateSchedule
+	NewMsgCancelSchedule   = types.NewMsgCancelSchedule
+	NewParams              = types.NewParams
+	DefaultParams          = types.DefaultParams
+	NewGenesisState        = types.NewGenesisState
+	DefaultGenesisState    = types.DefaultGenesisState
+	ValidateGenesis        = types.ValidateGenesis
+	ModuleCdc              = types.ModuleCdc
+	AttributeValueCategory = types.AttributeValueCategory
+	ErrInvalidSchedule     = types.ErrInvalidSchedule
+	ErrUnschedulableMsg    = types.ErrUnschedulableMsg
+	ErrScheduleNotFound    = types.ErrScheduleNotFound
+	ErrNotScheduleOwner    = types.ErrNotScheduleOwner
+	ErrInsufficientFee     = types.ErrInsufficientFee
+)
+
+type (
+	Keeper            = keeper.Keeper
+	GenesisState      = types.GenesisState
+	Params            = types.Params
+	Schedule          = types.Schedule
+	Schedules         = types.Schedules
+	ExecutionFailure  = types.ExecutionFailure
+	ExecutionFailures = types.ExecutionFailures
+	MsgCreateSchedule = types.MsgCreateSchedule
+	MsgCancelSchedule = types.MsgCancelSchedule
+)
@@ -0,0 +1,10 @@
+package cli
+
+const (
+	flagStartHeight   = "start-height"
+	flagStartTime     = "start-time"
+	flagInterval      = "interval"
+	flagMaxExecutions = "max-executions"
+	flagExecGas       = "exec-gas"
+	flagExecFee       = "exec-fee"
+)
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+// GetQueryCmd returns the root query command for the scheduler module.
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	schedulerQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the scheduler module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	schedulerQueryCmd.AddCommand(client.GetCommands(
+		GetCmdQuerySchedule(cdc),
+		GetCmdQuerySchedules(cdc),
+		GetCmdQueryExecutionFailures(cdc),
+		GetCmdQueryParams(cdc),
+	)...)
+
+	return schedulerQueryCmd
+}
+
+// GetCmdQuerySchedule returns the command querying a schedule
+func GetCmdQuerySchedule(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schedule [schedule-id]",
+		Short: "Query a schedule",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query a schedule by id.
+Example:
+$ %s query scheduler schedule 1
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("schedule id %s not a valid uint", args[0])
+			}
+
+			res, err := queryWithParams(cliCtx, cdc, types.QuerySchedule, types.NewQueryScheduleParams(id))
+			if err != nil {
+				return err
+			}
+
+			var schedule types.Schedule
+			cdc.MustUnmarshalJSON(res, &schedule)
+			return cliCtx.PrintOutput(schedule)
+		},
+	}
+}
+
+// GetCmdQuerySchedules returns the command querying the schedules of an owner
+func GetCmdQuerySchedules(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schedules [owner]",
+		Short: "Query the schedules of an owner",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the schedules of an owner.
+Example:
+$ %s query scheduler schedules nch1...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryWithParams(cliCtx, cdc, types.QuerySchedules, types.NewQuerySchedulesParams(owner))
+			if err != nil {
+				return err
+			}
+
+			var schedules types.Schedules
+			cdc.MustUnmarshalJSON(res, &schedules)
+			return cliCtx.PrintOutput(schedules)
+		},
+	}
+}
+
+// GetCmdQueryExecutionFailures returns the command querying the failed executions of a schedule
+func GetCmdQueryExecutionFailures(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "failures [schedule-id]",
+		Short: "Query the failed executions of a schedule",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the failed executions of a schedule, with their height and error.
+Example:
+$ %s query scheduler failures 1
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("schedule id %s not a valid uint", args[0])
+			}
+
+			res, err := queryWithParams(cliCtx, cdc, types.QueryExecutionFailures, types.NewQueryScheduleParams(id))
+			if err != nil {
+				return err
+			}
+
+			var failures types.ExecutionFailures
+			cdc.MustUnmarshalJSON(res, &failures)
+			return cliCtx.PrintOutput(failures)
+		},
+	}
+}
+
+// GetCmdQueryParams returns the command querying the scheduler params
+func GetCmdQueryParams(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "params",
+		Short:   "Query the scheduler params",
+		Example: fmt.Sprintf("%s query scheduler params", version.ClientName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryParameters), nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			cdc.MustUnmarshalJSON(res, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
+func queryWithParams(cliCtx context.CLIContext, cdc *codec.Codec, query string, params interface{}) ([]byte, error) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, query), bz)
+	return res, err
+}
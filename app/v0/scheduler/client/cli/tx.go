@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	"github.com/netcloth/netcloth-chain/client"
+	"github.com/netcloth/netcloth-chain/client/context"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/version"
+)
+
+// GetTxCmd returns the transaction commands for the scheduler module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Scheduler transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		GetCmdCreateSchedule(cdc),
+		GetCmdCancelSchedule(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdCreateSchedule returns the command scheduling a msg
+func GetCmdCreateSchedule(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [msg-file]",
+		Short: "Schedule a msg executed by the chain at a future height or time",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Schedule a msg signed by the sender, read as JSON from a file. The msg is executed
+at --start-height or from the first block at or after --start-time, then every --interval blocks
+up to --max-executions times. Each execution runs with at most --exec-gas gas and pays --exec-fee,
+the fees of all the executions are escrowed until they are executed or the schedule is cancelled.
+
+Where msg.json contains:
+
+{
+  "type": "nch/MsgSend",
+  "value": {
+    "from_address": "nch1...",
+    "to_address": "nch1...",
+    "amount": [{"denom": "pnch", "amount": "1000000"}]
+  }
+}
+
+Example:
+$ %s tx scheduler create msg.json --start-height=100000 --interval=100 --max-executions=10 --exec-gas=100000 --exec-fee=100000pnch --from=<key-name>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var scheduled sdk.Msg
+			if err := cdc.UnmarshalJSON(bz, &scheduled); err != nil {
+				return err
+			}
+
+			var startTime time.Time
+			if s := viper.GetString(flagStartTime); s != "" {
+				startTime, err = time.Parse(time.RFC3339, s)
+				if err != nil {
+					return err
+				}
+			}
+
+			fee, err := sdk.ParseCoins(viper.GetString(flagExecFee))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateSchedule(cliCtx.GetFromAddress(), scheduled, viper.GetInt64(flagStartHeight), startTime,
+				viper.GetInt64(flagInterval), viper.GetUint64(flagMaxExecutions), viper.GetUint64(flagExecGas), fee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Int64(flagStartHeight, 0, "Height of the first execution")
+	cmd.Flags().String(flagStartTime, "", "RFC3339 time of the first execution, instead of a start height")
+	cmd.Flags().Int64(flagInterval, 0, "Number of blocks between two executions, the msg is executed once if zero")
+	cmd.Flags().Uint64(flagMaxExecutions, 1, "Max number of executions")
+	cmd.Flags().Uint64(flagExecGas, 200000, "Gas limit of each execution")
+	cmd.Flags().String(flagExecFee, "", "Fee paid by each execution")
+
+	return cmd
+}
+
+// GetCmdCancelSchedule returns the command cancelling a schedule
+func GetCmdCancelSchedule(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cancel [schedule-id]",
+		Short:   "Cancel a schedule and refund the escrowed fees of its remaining executions",
+		Example: fmt.Sprintf("%s tx scheduler cancel 1 --from=<key-name>", version.ClientName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("schedule id %s not a valid uint", args[0])
+			}
+
+			msg := types.NewMsgCancelSchedule(cliCtx.GetFromAddress(), id)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	"github.com/netcloth/netcloth-chain/types/rest"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/scheduler/schedules", createScheduleHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/scheduler/schedule/{scheduleID}/cancel", cancelScheduleHandlerFn(cliCtx)).Methods("POST")
+}
+
+// CreateScheduleReq defines the properties of a schedule creation request's body
+type CreateScheduleReq struct {
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Msg           sdk.Msg      `json:"msg" yaml:"msg"`
+	StartHeight   int64        `json:"start_height" yaml:"start_height"`
+	StartTime     time.Time    `json:"start_time" yaml:"start_time"`
+	Interval      int64        `json:"interval" yaml:"interval"`
+	MaxExecutions uint64       `json:"max_executions" yaml:"max_executions"`
+	GasLimit      uint64       `json:"gas_limit" yaml:"gas_limit"`
+	Fee           sdk.Coins    `json:"fee" yaml:"fee"`
+}
+
+// CancelScheduleReq defines the properties of a schedule cancellation request's body
+type CancelScheduleReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+}
+
+func createScheduleHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateScheduleReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCreateSchedule(owner, req.Msg, req.StartHeight, req.StartTime, req.Interval,
+			req.MaxExecutions, req.GasLimit, req.Fee)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func cancelScheduleHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(mux.Vars(r)["scheduleID"], 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var req CancelScheduleReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		owner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCancelSchedule(owner, id)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
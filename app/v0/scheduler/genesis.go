@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"fmt"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// InitGenesis stores the schedules of the genesis and queues the unfinished ones
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	k.SetParams(ctx, data.Params)
+	k.SetNextScheduleID(ctx, data.NextScheduleID)
+
+	// check if the escrow account exists
+	moduleAcc := k.GetSchedulerAccount(ctx)
+	if moduleAcc == nil {
+		panic(fmt.Sprintf("%s module account has not been set", ModuleName))
+	}
+
+	var totalEscrow sdk.Coins
+	for _, schedule := range data.Schedules {
+		k.SetSchedule(ctx, schedule)
+		totalEscrow = totalEscrow.Add(schedule.Escrow)
+
+		switch {
+		case schedule.Finished():
+		case schedule.NextHeight != 0:
+			k.InsertHeightQueue(ctx, schedule.NextHeight, schedule.ID)
+		default:
+			k.InsertTimeQueue(ctx, schedule.StartTime, schedule.ID)
+		}
+	}
+
+	for _, failure := range data.ExecutionFailures {
+		k.SetExecutionFailure(ctx, failure)
+	}
+
+	// add coins if not provided on genesis
+	if moduleAcc.GetCoins().IsZero() {
+		if err := moduleAcc.SetCoins(totalEscrow); err != nil {
+			panic(err)
+		}
+		k.SetSchedulerAccount(ctx, moduleAcc)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper.
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	schedules := Schedules{}
+	k.IterateSchedules(ctx, func(schedule Schedule) bool {
+		schedules = append(schedules, schedule)
+		return false
+	})
+
+	failures := ExecutionFailures{}
+	k.IterateExecutionFailures(ctx, 0, func(failure ExecutionFailure) bool {
+		failures = append(failures, failure)
+		return false
+	})
+
+	return NewGenesisState(k.GetParams(ctx), k.GetNextScheduleID(ctx), schedules, failures)
+}
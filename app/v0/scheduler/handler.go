@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NewHandler returns a handler for "scheduler" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		switch msg := msg.(type) {
+		case MsgCreateSchedule:
+			return handleMsgCreateSchedule(ctx, k, msg)
+		case MsgCancelSchedule:
+			return handleMsgCancelSchedule(ctx, k, msg)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+	}
+}
+
+func handleMsgCreateSchedule(ctx sdk.Context, k Keeper, msg MsgCreateSchedule) (*sdk.Result, error) {
+	id, err := k.CreateSchedule(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCreateSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Data: sdk.Uint64ToBigEndian(id), Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgCancelSchedule(ctx sdk.Context, k Keeper, msg MsgCancelSchedule) (*sdk.Result, error) {
+	if err := k.CancelSchedule(ctx, msg.Owner, msg.ScheduleID); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCancelSchedule,
+			sdk.NewAttribute(types.AttributeKeyScheduleID, fmt.Sprintf("%d", msg.ScheduleID)),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// EndBlocker executes the schedules due at the current height within the gas budget of the block
+func EndBlocker(ctx sdk.Context, k Keeper) []abci.ValidatorUpdate {
+	k.ExecuteSchedules(ctx)
+	return []abci.ValidatorUpdate{}
+}
@@ -0,0 +1,20 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	h := NewHandler(Keeper{})
+
+	res, err := h(sdk.NewContext(nil, abci.Header{}, false, nil), sdk.NewTestMsg())
+	require.Nil(t, res)
+	require.NotNil(t, err)
+	require.True(t, strings.Contains(err.Error(), "unrecognized scheduler message type"))
+}
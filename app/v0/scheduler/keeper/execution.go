@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// ExecuteSchedules queues the schedules whose start time has been reached, then executes the
+// schedules queued up to the current height whose gas limit fits in what is left of the gas budget
+// of the block. The schedules left in the queue are executed in the next blocks, the schedules whose
+// gas limit exceeds the whole budget, after the max_block_gas param was lowered, are expired.
+func (k Keeper) ExecuteSchedules(ctx sdk.Context) {
+	k.queueStartedSchedules(ctx)
+
+	store := ctx.KVStore(k.storeKey)
+	var queued [][]byte
+	iterator := k.HeightQueueIterator(ctx, ctx.BlockHeight())
+	for ; iterator.Valid(); iterator.Next() {
+		queued = append(queued, iterator.Key())
+	}
+	iterator.Close()
+
+	budget := k.GetParams(ctx).MaxBlockGas
+	var gasUsed uint64
+	for _, key := range queued {
+		schedule, found := k.GetSchedule(ctx, types.SplitQueueKey(key))
+		if !found {
+			store.Delete(key)
+			continue
+		}
+		if schedule.GasLimit > budget {
+			store.Delete(key)
+			k.expireSchedule(ctx, schedule, budget)
+			continue
+		}
+		if gasUsed+schedule.GasLimit > budget {
+			continue
+		}
+
+		store.Delete(key)
+		gasUsed += k.executeSchedule(ctx, schedule)
+	}
+}
+
+// queueStartedSchedules moves the schedules whose start time has been reached to the queue of the
+// current height
+func (k Keeper) queueStartedSchedules(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	var started [][]byte
+	iterator := k.TimeQueueIterator(ctx, ctx.BlockHeader().Time)
+	for ; iterator.Valid(); iterator.Next() {
+		started = append(started, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range started {
+		store.Delete(key)
+		schedule, found := k.GetSchedule(ctx, types.SplitQueueKey(key))
+		if !found {
+			continue
+		}
+
+		schedule.NextHeight = ctx.BlockHeight()
+		k.SetSchedule(ctx, schedule)
+		k.InsertHeightQueue(ctx, schedule.NextHeight, schedule.ID)
+	}
+}
+
+// expireSchedule deletes a schedule whose gas limit exceeds the gas budget of a block, so that it can
+// never be executed, and refunds its remaining escrow to its owner
+func (k Keeper) expireSchedule(ctx sdk.Context, schedule types.Schedule, budget uint64) {
+	if !schedule.Escrow.Empty() {
+		err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, schedule.Owner, schedule.Escrow)
+		if err != nil {
+			panic(fmt.Sprintf("failed to refund the escrow of the expired schedule %d: %v", schedule.ID, err))
+		}
+	}
+	k.deleteSchedule(ctx, schedule)
+
+	err := sdkerrors.Wrapf(types.ErrExecutionGasExceeded, "gas limit %d, block gas budget %d", schedule.GasLimit, budget)
+	k.Logger(ctx).Info(fmt.Sprintf("schedule %d expired: %s", schedule.ID, err))
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExpireSchedule,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, fmt.Sprintf("%d", schedule.ID)),
+		sdk.NewAttribute(types.AttributeKeyOwner, schedule.Owner.String()),
+		sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+	))
+}
+
+// executeSchedule pays the fee of an execution of a schedule and runs its msg, the failures are
+// recorded. The schedule is queued for its next execution unless it is finished.
+func (k Keeper) executeSchedule(ctx sdk.Context, schedule types.Schedule) (gasUsed uint64) {
+	schedule.Executions++
+
+	var err error
+	if !schedule.Fee.Empty() {
+		err = k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName, schedule.Fee)
+	}
+	if err == nil {
+		schedule.Escrow = schedule.Escrow.Sub(schedule.Fee)
+		gasUsed, err = k.runMsg(ctx, schedule)
+	}
+
+	success := err == nil
+	if !success {
+		schedule.Failures++
+		k.SetExecutionFailure(ctx, types.NewExecutionFailure(schedule.ID, schedule.Executions, ctx.BlockHeight(), err))
+		k.Logger(ctx).Info(fmt.Sprintf("schedule %d execution %d failed: %s", schedule.ID, schedule.Executions, err))
+	}
+
+	if schedule.Finished() {
+		schedule.NextHeight = 0
+	} else {
+		schedule.NextHeight = ctx.BlockHeight() + schedule.Interval
+		k.InsertHeightQueue(ctx, schedule.NextHeight, schedule.ID)
+	}
+	k.SetSchedule(ctx, schedule)
+
+	event := sdk.NewEvent(
+		types.EventTypeExecuteSchedule,
+		sdk.NewAttribute(types.AttributeKeyScheduleID, fmt.Sprintf("%d", schedule.ID)),
+		sdk.NewAttribute(types.AttributeKeyExecution, fmt.Sprintf("%d", schedule.Executions)),
+		sdk.NewAttribute(types.AttributeKeySuccess, fmt.Sprintf("%t", success)),
+	)
+	if !success {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+	}
+	ctx.EventManager().EmitEvent(event)
+
+	return gasUsed
+}
+
+// runMsg runs the msg of a schedule with the gas limit of the schedule, its state changes are
+// only written when it succeeds
+func (k Keeper) runMsg(ctx sdk.Context, schedule types.Schedule) (gasUsed uint64, err error) {
+	handler := k.router.Route(ctx, schedule.Msg.Route())
+	if handler == nil {
+		return 0, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized scheduled message route: %s", schedule.Msg.Route())
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(schedule.GasLimit)).WithTxBytes(schedule.ExecutionTxBytes())
+
+	defer func() {
+		gasUsed = cacheCtx.GasMeter().GasConsumedToLimit()
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "out of gas in location: %v; gasWanted: %d", rType.Descriptor, schedule.GasLimit)
+			default:
+				err = sdkerrors.Wrapf(sdkerrors.ErrPanic, "recovered: %v", r)
+			}
+		}
+	}()
+
+	res, err := handler(cacheCtx, schedule.Msg)
+	if err != nil {
+		return 0, err
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(res.Events)
+	return 0, nil
+}
@@ -0,0 +1,261 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	supplyexported "github.com/netcloth/netcloth-chain/app/v0/supply/exported"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// Keeper defines the scheduler store, the scheduled msgs are executed through the router
+type Keeper struct {
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	paramstore       params.Subspace
+	supplyKeeper     types.SupplyKeeper
+	router           sdk.Router
+	feeCollectorName string
+}
+
+// NewKeeper creates a new scheduler Keeper instance
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramstore params.Subspace, supplyKeeper types.SupplyKeeper,
+	router sdk.Router, feeCollectorName string) Keeper {
+	if addr := supplyKeeper.GetModuleAddress(types.ModuleName); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.ModuleName))
+	}
+
+	return Keeper{
+		storeKey:         storeKey,
+		cdc:              cdc,
+		paramstore:       paramstore.WithKeyTable(ParamKeyTable()),
+		supplyKeeper:     supplyKeeper,
+		router:           router,
+		feeCollectorName: feeCollectorName,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GetSchedulerAccount returns the scheduler module account, escrowing the fees of the schedules
+func (k Keeper) GetSchedulerAccount(ctx sdk.Context) supplyexported.ModuleAccountI {
+	return k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName)
+}
+
+// SetSchedulerAccount sets the scheduler module account
+func (k Keeper) SetSchedulerAccount(ctx sdk.Context, acc supplyexported.ModuleAccountI) {
+	k.supplyKeeper.SetModuleAccount(ctx, acc)
+}
+
+// GetNextScheduleID returns the id of the next schedule
+func (k Keeper) GetNextScheduleID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.NextScheduleIDKey)
+	if bz == nil {
+		return 1
+	}
+
+	var id uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &id)
+	return id
+}
+
+// SetNextScheduleID sets the id of the next schedule
+func (k Keeper) SetNextScheduleID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextScheduleIDKey, k.cdc.MustMarshalBinaryLengthPrefixed(id))
+}
+
+// GetSchedule returns a schedule by id
+func (k Keeper) GetSchedule(ctx sdk.Context, id uint64) (schedule types.Schedule, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetScheduleKey(id))
+	if bz == nil {
+		return schedule, false
+	}
+
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &schedule)
+	return schedule, true
+}
+
+// SetSchedule stores a schedule and indexes it by owner
+func (k Keeper) SetSchedule(ctx sdk.Context, schedule types.Schedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetScheduleKey(schedule.ID), k.cdc.MustMarshalBinaryLengthPrefixed(schedule))
+	store.Set(types.GetScheduleByOwnerKey(schedule.Owner, schedule.ID), []byte{})
+}
+
+func (k Keeper) deleteSchedule(ctx sdk.Context, schedule types.Schedule) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetScheduleKey(schedule.ID))
+	store.Delete(types.GetScheduleByOwnerKey(schedule.Owner, schedule.ID))
+
+	iterator := sdk.KVStorePrefixIterator(store, types.GetExecutionFailuresKey(schedule.ID))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		store.Delete(iterator.Key())
+	}
+}
+
+// IterateSchedules iterates over all the schedules, stopping when cb returns true
+func (k Keeper) IterateSchedules(ctx sdk.Context, cb func(schedule types.Schedule) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ScheduleKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var schedule types.Schedule
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &schedule)
+		if cb(schedule) {
+			break
+		}
+	}
+}
+
+// GetSchedulesByOwner returns the schedules of owner
+func (k Keeper) GetSchedulesByOwner(ctx sdk.Context, owner sdk.AccAddress) types.Schedules {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetSchedulesByOwnerKey(owner))
+	defer iterator.Close()
+
+	schedules := types.Schedules{}
+	for ; iterator.Valid(); iterator.Next() {
+		if schedule, found := k.GetSchedule(ctx, types.SplitQueueKey(iterator.Key())); found {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules
+}
+
+// SetExecutionFailure records the failure of an execution of a schedule
+func (k Keeper) SetExecutionFailure(ctx sdk.Context, failure types.ExecutionFailure) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetExecutionFailureKey(failure.ScheduleID, failure.Execution), k.cdc.MustMarshalBinaryLengthPrefixed(failure))
+}
+
+// IterateExecutionFailures iterates over the execution failures of all the schedules, or of the
+// schedule with the given id when it is not zero
+func (k Keeper) IterateExecutionFailures(ctx sdk.Context, id uint64, cb func(failure types.ExecutionFailure) (stop bool)) {
+	prefix := types.ExecutionFailureKey
+	if id != 0 {
+		prefix = types.GetExecutionFailuresKey(id)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var failure types.ExecutionFailure
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &failure)
+		if cb(failure) {
+			break
+		}
+	}
+}
+
+// GetExecutionFailures returns the execution failures of a schedule
+func (k Keeper) GetExecutionFailures(ctx sdk.Context, id uint64) types.ExecutionFailures {
+	failures := types.ExecutionFailures{}
+	k.IterateExecutionFailures(ctx, id, func(failure types.ExecutionFailure) bool {
+		failures = append(failures, failure)
+		return false
+	})
+	return failures
+}
+
+// InsertHeightQueue queues a schedule for execution at height
+func (k Keeper) InsertHeightQueue(ctx sdk.Context, height int64, id uint64) {
+	ctx.KVStore(k.storeKey).Set(types.GetHeightQueueKey(height, id), []byte{})
+}
+
+// InsertTimeQueue queues a schedule starting at t
+func (k Keeper) InsertTimeQueue(ctx sdk.Context, t time.Time, id uint64) {
+	ctx.KVStore(k.storeKey).Set(types.GetTimeQueueKey(t, id), []byte{})
+}
+
+func (k Keeper) removeFromQueues(ctx sdk.Context, schedule types.Schedule) {
+	store := ctx.KVStore(k.storeKey)
+	if schedule.NextHeight != 0 {
+		store.Delete(types.GetHeightQueueKey(schedule.NextHeight, schedule.ID))
+	} else if !schedule.StartTime.IsZero() {
+		store.Delete(types.GetTimeQueueKey(schedule.StartTime, schedule.ID))
+	}
+}
+
+// HeightQueueIterator returns an iterator over the schedules queued up to height
+func (k Keeper) HeightQueueIterator(ctx sdk.Context, height int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.HeightQueueKey, sdk.PrefixEndBytes(types.GetHeightQueuePrefix(height)))
+}
+
+// TimeQueueIterator returns an iterator over the schedules starting up to t
+func (k Keeper) TimeQueueIterator(ctx sdk.Context, t time.Time) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.TimeQueueKey, sdk.PrefixEndBytes(types.GetTimeQueuePrefix(t)))
+}
+
+// CreateSchedule escrows the fees of all the executions of a new schedule, and queues it
+func (k Keeper) CreateSchedule(ctx sdk.Context, msg types.MsgCreateSchedule) (uint64, error) {
+	params := k.GetParams(ctx)
+	if msg.StartHeight != 0 && msg.StartHeight <= ctx.BlockHeight() {
+		return 0, sdkerrors.Wrapf(types.ErrInvalidSchedule, "start height %d is not in the future", msg.StartHeight)
+	}
+	if !msg.StartTime.IsZero() && !msg.StartTime.After(ctx.BlockHeader().Time) {
+		return 0, sdkerrors.Wrapf(types.ErrInvalidSchedule, "start time %s is not in the future", msg.StartTime)
+	}
+	if msg.MaxExecutions > params.MaxExecutions {
+		return 0, sdkerrors.Wrapf(types.ErrTooManyExecutions, "max executions %d, allowed %d", msg.MaxExecutions, params.MaxExecutions)
+	}
+	if msg.GasLimit > params.MaxBlockGas {
+		return 0, sdkerrors.Wrapf(types.ErrExecutionGasExceeded, "gas limit %d, block gas budget %d", msg.GasLimit, params.MaxBlockGas)
+	}
+	if minFee := params.MinFee(msg.GasLimit); !minFee.Empty() && !msg.Fee.IsAnyGTE(minFee) {
+		return 0, sdkerrors.Wrapf(types.ErrInsufficientFee, "got: %s required: %s", msg.Fee, minFee)
+	}
+
+	id := k.GetNextScheduleID(ctx)
+	schedule := types.NewSchedule(id, msg)
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, schedule.Owner, types.ModuleName, schedule.Escrow); err != nil {
+		return 0, err
+	}
+
+	k.SetSchedule(ctx, schedule)
+	k.SetNextScheduleID(ctx, id+1)
+	if schedule.NextHeight != 0 {
+		k.InsertHeightQueue(ctx, schedule.NextHeight, id)
+	} else {
+		k.InsertTimeQueue(ctx, schedule.StartTime, id)
+	}
+	return id, nil
+}
+
+// CancelSchedule deletes a schedule of owner and refunds its remaining escrow
+func (k Keeper) CancelSchedule(ctx sdk.Context, owner sdk.AccAddress, id uint64) error {
+	schedule, found := k.GetSchedule(ctx, id)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrScheduleNotFound, "schedule %d", id)
+	}
+	if !schedule.Owner.Equals(owner) {
+		return sdkerrors.Wrapf(types.ErrNotScheduleOwner, "schedule %d", id)
+	}
+
+	if !schedule.Escrow.Empty() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, schedule.Escrow); err != nil {
+			return err
+		}
+	}
+
+	k.removeFromQueues(ctx, schedule)
+	k.deleteSchedule(ctx, schedule)
+	return nil
+}
@@ -0,0 +1,296 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	"github.com/netcloth/netcloth-chain/app/v0/supply"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+var (
+	owner     = sdk.AccAddress("owner_______________")
+	recipient = sdk.AccAddress("recipient___________")
+)
+
+type testInput struct {
+	ctx sdk.Context
+	ak  auth.AccountKeeper
+	sk  supply.Keeper
+	pk  params.Keeper
+	k   Keeper
+
+	// txs are the tx bytes of the executed msgs
+	txs *[][]byte
+}
+
+func createTestInput(t *testing.T) testInput {
+	keys := sdk.NewKVStoreKeys(auth.StoreKey, supply.StoreKey, params.StoreKey, types.StoreKey)
+	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	for _, key := range keys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	}
+	for _, key := range tkeys {
+		ms.MountStoreWithDB(key, sdk.StoreTypeTransient, db)
+	}
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	bank.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
+	ak := auth.NewAccountKeeper(cdc, keys[auth.StoreKey], pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), nil)
+	bk.SetSendEnabled(ctx, true)
+	sk := supply.NewKeeper(cdc, keys[supply.StoreKey], ak, bk, map[string][]string{
+		auth.FeeCollectorName: nil,
+		types.ModuleName:      nil,
+	})
+	sk.SetSupply(ctx, supply.NewSupply(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10000000))))
+
+	txs := new([][]byte)
+	bankHandler := bank.NewHandler(bk)
+	router := protocol.NewRouter()
+	router.AddRoute(bank.RouterKey, func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		*txs = append(*txs, ctx.TxBytes())
+		return bankHandler(ctx, msg)
+	})
+
+	k := NewKeeper(keys[types.StoreKey], cdc, pk.Subspace(types.DefaultParamspace), sk, router, auth.FeeCollectorName)
+	k.SetParams(ctx, types.NewParams(100000, 10, sdk.DecCoins{sdk.NewDecCoinFromDec(sdk.NativeTokenName, sdk.OneDec())}))
+	k.GetSchedulerAccount(ctx)
+
+	acc := ak.NewAccountWithAddress(ctx, owner)
+	require.NoError(t, acc.SetCoins(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10000000))))
+	ak.SetAccount(ctx, acc)
+
+	return testInput{ctx: ctx, ak: ak, sk: sk, pk: pk, k: k, txs: txs}
+}
+
+func (in testInput) balance(addr sdk.AccAddress) int64 {
+	acc := in.ak.GetAccount(in.ctx, addr)
+	if acc == nil {
+		return 0
+	}
+	return acc.GetCoins().AmountOf(sdk.NativeTokenName).Int64()
+}
+
+func (in testInput) endBlock(height int64) testInput {
+	in.ctx = in.ctx.WithBlockHeight(height).WithBlockTime(in.ctx.BlockHeader().Time.Add(5 * time.Second))
+	in.k.ExecuteSchedules(in.ctx)
+	return in
+}
+
+func newSend(amount int64) sdk.Msg {
+	return bank.NewMsgSend(owner, recipient, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, amount)))
+}
+
+func TestCreateSchedule(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 50000))
+
+	_, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 1, time.Time{}, 0, 1, 50000, fee))
+	require.True(t, types.ErrInvalidSchedule.Is(err))
+	_, err = in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 0, time.Unix(1000, 0), 0, 1, 50000, fee))
+	require.True(t, types.ErrInvalidSchedule.Is(err))
+	_, err = in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 1, 11, 50000, fee))
+	require.True(t, types.ErrTooManyExecutions.Is(err))
+	_, err = in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 100001, fee))
+	require.True(t, types.ErrExecutionGasExceeded.Is(err))
+	_, err = in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 50001, fee))
+	require.True(t, types.ErrInsufficientFee.Is(err))
+
+	id, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 5, 3, 50000, fee))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), id)
+	require.Equal(t, int64(10000000-150000), in.balance(owner))
+	require.Equal(t, int64(150000), in.balance(in.sk.GetModuleAddress(types.ModuleName)))
+
+	schedule, found := in.k.GetSchedule(in.ctx, id)
+	require.True(t, found)
+	require.Equal(t, int64(2), schedule.NextHeight)
+	require.Len(t, in.k.GetSchedulesByOwner(in.ctx, owner), 1)
+	require.Empty(t, in.k.GetSchedulesByOwner(in.ctx, recipient))
+}
+
+func TestGetParamsNotInitialised(t *testing.T) {
+	in := createTestInput(t)
+
+	// the params of a chain started before the module was added are the default ones
+	k := in.k
+	k.paramstore = in.pk.Subspace("uninitialised").WithKeyTable(ParamKeyTable())
+	require.Equal(t, types.DefaultParams(), k.GetParams(in.ctx))
+	require.NotPanics(t, func() { k.ExecuteSchedules(in.ctx) })
+}
+
+func TestExecuteSchedules(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 50000))
+
+	id, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(100), 2, time.Time{}, 2, 3, 50000, fee))
+	require.NoError(t, err)
+
+	// executed at heights 2, 4 and 6
+	for height := int64(2); height <= 7; height++ {
+		in = in.endBlock(height)
+	}
+	require.Equal(t, int64(300), in.balance(recipient))
+	require.Equal(t, int64(150000), in.balance(in.sk.GetModuleAddress(auth.FeeCollectorName)))
+	require.Equal(t, int64(0), in.balance(in.sk.GetModuleAddress(types.ModuleName)))
+
+	schedule, _ := in.k.GetSchedule(in.ctx, id)
+	require.True(t, schedule.Finished())
+	require.Equal(t, uint64(3), schedule.Executions)
+	require.Equal(t, int64(0), schedule.NextHeight)
+	require.True(t, schedule.Escrow.Empty())
+	require.Empty(t, in.k.GetExecutionFailures(in.ctx, id))
+
+	// each execution runs with its own tx hash
+	require.Len(t, *in.txs, 3)
+	require.NotEqual(t, (*in.txs)[0], (*in.txs)[1])
+	require.NotEqual(t, (*in.txs)[1], (*in.txs)[2])
+}
+
+func TestExecuteSchedulesFailure(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 50000))
+
+	// the owner can only afford the first send
+	id, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(6000000), 2, time.Time{}, 1, 2, 50000, fee))
+	require.NoError(t, err)
+
+	in = in.endBlock(2).endBlock(3)
+	require.Equal(t, int64(6000000), in.balance(recipient))
+
+	failures := in.k.GetExecutionFailures(in.ctx, id)
+	require.Len(t, failures, 1)
+	require.Equal(t, uint64(2), failures[0].Execution)
+	require.Equal(t, int64(3), failures[0].Height)
+	require.Contains(t, failures[0].Error, "insufficient")
+
+	schedule, _ := in.k.GetSchedule(in.ctx, id)
+	require.Equal(t, uint64(1), schedule.Failures)
+	require.True(t, schedule.Finished())
+
+	// finished schedules are removed by their owner
+	require.True(t, types.ErrNotScheduleOwner.Is(in.k.CancelSchedule(in.ctx, recipient, id)))
+	require.NoError(t, in.k.CancelSchedule(in.ctx, owner, id))
+	_, found := in.k.GetSchedule(in.ctx, id)
+	require.False(t, found)
+	require.Empty(t, in.k.GetExecutionFailures(in.ctx, id))
+}
+
+func TestExecuteSchedulesGasBudget(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100000))
+
+	// the gas limit of an execution takes the whole budget of a block
+	first, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 100000, fee))
+	require.NoError(t, err)
+	second, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 100000, fee))
+	require.NoError(t, err)
+
+	in = in.endBlock(2)
+	s, _ := in.k.GetSchedule(in.ctx, first)
+	require.True(t, s.Finished())
+	s, _ = in.k.GetSchedule(in.ctx, second)
+	require.False(t, s.Finished())
+
+	in = in.endBlock(3)
+	s, _ = in.k.GetSchedule(in.ctx, second)
+	require.True(t, s.Finished())
+	require.Equal(t, int64(2), in.balance(recipient))
+}
+
+func TestExecuteSchedulesOverBudget(t *testing.T) {
+	in := createTestInput(t)
+	fee := func(gas int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, gas)) }
+
+	first, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 100000, fee(100000)))
+	require.NoError(t, err)
+	second, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 100000, fee(100000)))
+	require.NoError(t, err)
+	third, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 30000, fee(30000)))
+	require.NoError(t, err)
+
+	// a schedule not fitting in what is left of the budget does not hold up the next ones
+	in = in.endBlock(2)
+	s, _ := in.k.GetSchedule(in.ctx, first)
+	require.True(t, s.Finished())
+	s, _ = in.k.GetSchedule(in.ctx, second)
+	require.False(t, s.Finished())
+	s, _ = in.k.GetSchedule(in.ctx, third)
+	require.True(t, s.Finished())
+
+	// a schedule whose gas limit exceeds the whole budget is expired and refunded
+	params := in.k.GetParams(in.ctx)
+	params.MaxBlockGas = 50000
+	in.k.SetParams(in.ctx, params)
+	before := in.balance(owner)
+	in = in.endBlock(3)
+	_, found := in.k.GetSchedule(in.ctx, second)
+	require.False(t, found)
+	require.Equal(t, before+100000, in.balance(owner))
+	require.Equal(t, int64(2), in.balance(recipient))
+	require.Equal(t, int64(0), in.balance(in.sk.GetModuleAddress(types.ModuleName)))
+}
+
+func TestStartTimeAndCancel(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 50000))
+
+	start := in.ctx.BlockHeader().Time.Add(8 * time.Second)
+	id, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 0, start, 1, 3, 50000, fee))
+	require.NoError(t, err)
+
+	in = in.endBlock(2)
+	require.Equal(t, int64(0), in.balance(recipient))
+	in = in.endBlock(3)
+	require.Equal(t, int64(1), in.balance(recipient))
+
+	before := in.balance(owner)
+	require.NoError(t, in.k.CancelSchedule(in.ctx, owner, id))
+	require.Equal(t, before+100000, in.balance(owner))
+	require.True(t, types.ErrScheduleNotFound.Is(in.k.CancelSchedule(in.ctx, owner, id)))
+
+	in = in.endBlock(4)
+	require.Equal(t, int64(1), in.balance(recipient))
+}
+
+func TestExecuteSchedulesOutOfGas(t *testing.T) {
+	in := createTestInput(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 10))
+
+	id, err := in.k.CreateSchedule(in.ctx, types.NewMsgCreateSchedule(owner, newSend(1), 2, time.Time{}, 0, 1, 10, fee))
+	require.NoError(t, err)
+
+	in = in.endBlock(2)
+	require.Equal(t, int64(0), in.balance(recipient))
+	require.Equal(t, int64(10), in.balance(in.sk.GetModuleAddress(auth.FeeCollectorName)))
+
+	failures := in.k.GetExecutionFailures(in.ctx, id)
+	require.Len(t, failures, 1)
+	require.Contains(t, failures[0].Error, "out of gas")
+}
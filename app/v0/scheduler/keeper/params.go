@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&types.Params{})
+}
+
+// GetMaxBlockGas returns the gas budget of the executions of a block, the default on the chains
+// started before the module was added
+func (k Keeper) GetMaxBlockGas(ctx sdk.Context) uint64 {
+	res := types.DefaultMaxBlockGas
+	k.paramstore.GetIfExists(ctx, types.KeyMaxBlockGas, &res)
+	return res
+}
+
+// GetMaxExecutions returns the max executions of a schedule, the default on the chains started
+// before the module was added
+func (k Keeper) GetMaxExecutions(ctx sdk.Context) uint64 {
+	res := types.DefaultMaxExecutions
+	k.paramstore.GetIfExists(ctx, types.KeyMaxExecutions, &res)
+	return res
+}
+
+// GetMinGasPrices returns the min gas prices of the fee paid by each execution, the default on the
+// chains started before the module was added
+func (k Keeper) GetMinGasPrices(ctx sdk.Context) (res sdk.DecCoins) {
+	if !k.paramstore.Has(ctx, types.KeyMinGasPrices) {
+		return types.DefaultMinGasPrices
+	}
+	k.paramstore.Get(ctx, types.KeyMinGasPrices, &res)
+	return
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	return types.NewParams(k.GetMaxBlockGas(ctx), k.GetMaxExecutions(ctx), k.GetMinGasPrices(ctx))
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramstore.SetParamSet(ctx, &params)
+}
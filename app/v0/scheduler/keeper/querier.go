@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/scheduler/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case types.QuerySchedule:
+			return querySchedule(ctx, req, k)
+		case types.QuerySchedules:
+			return querySchedules(ctx, req, k)
+		case types.QueryExecutionFailures:
+			return queryExecutionFailures(ctx, req, k)
+		case types.QueryParameters:
+			return marshalJSON(k.GetParams(ctx))
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
+		}
+	}
+}
+
+func querySchedule(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryScheduleParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	schedule, found := k.GetSchedule(ctx, params.ScheduleID)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrScheduleNotFound, "schedule %d", params.ScheduleID)
+	}
+	return marshalJSON(schedule)
+}
+
+func querySchedules(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QuerySchedulesParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	return marshalJSON(k.GetSchedulesByOwner(ctx, params.Owner))
+}
+
+func queryExecutionFailures(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryScheduleParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if _, found := k.GetSchedule(ctx, params.ScheduleID); !found {
+		return nil, sdkerrors.Wrapf(types.ErrScheduleNotFound, "schedule %d", params.ScheduleID)
+	}
+	return marshalJSON(k.GetExecutionFailures(ctx, params.ScheduleID))
+}
+
+func marshalJSON(o interface{}) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, o)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
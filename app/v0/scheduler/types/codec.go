@@ -0,0 +1,29 @@
+package types
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	vmtypes "github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreateSchedule{}, "nch/MsgCreateSchedule", nil)
+	cdc.RegisterConcrete(MsgCancelSchedule{}, "nch/MsgCancelSchedule", nil)
+}
+
+// ModuleCdc generic sealed codec to be used throughout module, it registers the msgs
+// which can be scheduled
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	sdk.RegisterCodec(ModuleCdc)
+	bank.RegisterCodec(ModuleCdc)
+	vmtypes.RegisterCodec(ModuleCdc)
+	cipaltypes.RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
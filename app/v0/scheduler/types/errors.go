@@ -0,0 +1,15 @@
+package types
+
+import (
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+var (
+	ErrInvalidSchedule      = sdkerrors.New(ModuleName, 1, "invalid schedule")
+	ErrUnschedulableMsg     = sdkerrors.New(ModuleName, 2, "msg can not be scheduled")
+	ErrScheduleNotFound     = sdkerrors.New(ModuleName, 3, "schedule not found")
+	ErrNotScheduleOwner     = sdkerrors.New(ModuleName, 4, "not the owner of the schedule")
+	ErrInsufficientFee      = sdkerrors.New(ModuleName, 5, "insufficient execution fee")
+	ErrExecutionGasExceeded = sdkerrors.New(ModuleName, 6, "execution gas limit exceeds the block gas budget")
+	ErrTooManyExecutions    = sdkerrors.New(ModuleName, 7, "too many executions")
+)
@@ -0,0 +1,18 @@
+package types
+
+const (
+	EventTypeCreateSchedule  = "create_schedule"
+	EventTypeCancelSchedule  = "cancel_schedule"
+	EventTypeExecuteSchedule = "execute_schedule"
+	EventTypeExpireSchedule  = "expire_schedule"
+
+	AttributeKeyScheduleID = "schedule_id"
+	AttributeKeyOwner      = "owner"
+	AttributeKeyExecution  = "execution"
+	AttributeKeySuccess    = "success"
+	AttributeKeyError      = "error"
+)
+
+var (
+	AttributeValueCategory = ModuleName
+)
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+)
+
+// GenesisState defines the scheduler genesis state
+type GenesisState struct {
+	Params            Params            `json:"params" yaml:"params"`
+	NextScheduleID    uint64            `json:"next_schedule_id" yaml:"next_schedule_id"`
+	Schedules         Schedules         `json:"schedules" yaml:"schedules"`
+	ExecutionFailures ExecutionFailures `json:"execution_failures" yaml:"execution_failures"`
+}
+
+func NewGenesisState(params Params, nextScheduleID uint64, schedules Schedules, failures ExecutionFailures) GenesisState {
+	return GenesisState{
+		Params:            params,
+		NextScheduleID:    nextScheduleID,
+		Schedules:         schedules,
+		ExecutionFailures: failures,
+	}
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:         DefaultParams(),
+		NextScheduleID: 1,
+	}
+}
+
+// ValidateGenesis checks the params, and that the schedules have unique ids lower than the next schedule id
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	ids := make(map[uint64]bool, len(data.Schedules))
+	for _, s := range data.Schedules {
+		if s.ID == 0 || s.ID >= data.NextScheduleID {
+			return fmt.Errorf("invalid schedule id %d, next schedule id %d", s.ID, data.NextScheduleID)
+		}
+		if ids[s.ID] {
+			return fmt.Errorf("duplicate schedule id %d", s.ID)
+		}
+		if s.Owner.Empty() || s.Msg == nil {
+			return fmt.Errorf("invalid schedule %d", s.ID)
+		}
+		ids[s.ID] = true
+	}
+
+	for _, f := range data.ExecutionFailures {
+		if !ids[f.ScheduleID] {
+			return fmt.Errorf("execution failure of unknown schedule %d", f.ScheduleID)
+		}
+	}
+	return nil
+}
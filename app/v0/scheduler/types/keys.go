@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/protocol"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	ModuleName   = protocol.SchedulerModuleName
+	StoreKey     = ModuleName
+	RouterKey    = ModuleName
+	QuerierRoute = ModuleName
+)
+
+var (
+	NextScheduleIDKey   = []byte{0x00}
+	ScheduleKey         = []byte{0x01}
+	ScheduleByOwnerKey  = []byte{0x02}
+	HeightQueueKey      = []byte{0x03}
+	TimeQueueKey        = []byte{0x04}
+	ExecutionFailureKey = []byte{0x05}
+)
+
+// GetScheduleKey returns the key of the schedule with the given id
+func GetScheduleKey(id uint64) []byte {
+	return append(ScheduleKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetScheduleByOwnerKey returns the key indexing the schedule with the given id by its owner
+func GetScheduleByOwnerKey(owner sdk.AccAddress, id uint64) []byte {
+	return append(GetSchedulesByOwnerKey(owner), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetSchedulesByOwnerKey returns the prefix of the keys indexing the schedules of owner
+func GetSchedulesByOwnerKey(owner sdk.AccAddress) []byte {
+	return append(ScheduleByOwnerKey, owner.Bytes()...)
+}
+
+// GetHeightQueueKey returns the key of the schedule with the given id in the queue of the
+// schedules to execute at height
+func GetHeightQueueKey(height int64, id uint64) []byte {
+	return append(GetHeightQueuePrefix(height), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHeightQueuePrefix returns the prefix of the keys of the schedules to execute at height
+func GetHeightQueuePrefix(height int64) []byte {
+	return append(HeightQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetTimeQueueKey returns the key of the schedule with the given id in the queue of the
+// schedules starting at t
+func GetTimeQueueKey(t time.Time, id uint64) []byte {
+	return append(GetTimeQueuePrefix(t), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTimeQueuePrefix returns the prefix of the keys of the schedules starting at t
+func GetTimeQueuePrefix(t time.Time) []byte {
+	return append(TimeQueueKey, sdk.FormatTimeBytes(t)...)
+}
+
+// GetExecutionFailureKey returns the key of the failure of the given execution of a schedule
+func GetExecutionFailureKey(id, execution uint64) []byte {
+	return append(GetExecutionFailuresKey(id), sdk.Uint64ToBigEndian(execution)...)
+}
+
+// GetExecutionFailuresKey returns the prefix of the keys of the failures of a schedule
+func GetExecutionFailuresKey(id uint64) []byte {
+	return append(ExecutionFailureKey, sdk.Uint64ToBigEndian(id)...)
+}
+
+// SplitQueueKey returns the schedule id of a height or time queue key
+func SplitQueueKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
@@ -0,0 +1,180 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	TypeMsgCreateSchedule = "create_schedule"
+	TypeMsgCancelSchedule = "cancel_schedule"
+)
+
+var (
+	_ sdk.Msg = MsgCreateSchedule{}
+	_ sdk.Msg = MsgCancelSchedule{}
+)
+
+// MsgCreateSchedule schedules a msg signed by Owner, executed from StartHeight or StartTime then
+// every Interval blocks, up to MaxExecutions times. A one-off schedule has a zero Interval. The Fee
+// paid by each execution is escrowed for all the executions when the schedule is created.
+type MsgCreateSchedule struct {
+	Owner         sdk.AccAddress `json:"owner" yaml:"owner"`
+	Msg           sdk.Msg        `json:"msg" yaml:"msg"`
+	StartHeight   int64          `json:"start_height" yaml:"start_height"`
+	StartTime     time.Time      `json:"start_time" yaml:"start_time"`
+	Interval      int64          `json:"interval" yaml:"interval"`
+	MaxExecutions uint64         `json:"max_executions" yaml:"max_executions"`
+	GasLimit      uint64         `json:"gas_limit" yaml:"gas_limit"`
+	Fee           sdk.Coins      `json:"fee" yaml:"fee"`
+}
+
+// NewMsgCreateSchedule creates a new MsgCreateSchedule instance
+func NewMsgCreateSchedule(owner sdk.AccAddress, msg sdk.Msg, startHeight int64, startTime time.Time,
+	interval int64, maxExecutions, gasLimit uint64, fee sdk.Coins) MsgCreateSchedule {
+	return MsgCreateSchedule{
+		Owner:         owner,
+		Msg:           msg,
+		StartHeight:   startHeight,
+		StartTime:     startTime,
+		Interval:      interval,
+		MaxExecutions: maxExecutions,
+		GasLimit:      gasLimit,
+		Fee:           fee,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgCreateSchedule) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgCreateSchedule) Type() string { return TypeMsgCreateSchedule }
+
+// ValidateBasic Implements Msg.
+func (msg MsgCreateSchedule) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing owner address")
+	}
+	if msg.Msg == nil {
+		return sdkerrors.Wrap(ErrUnschedulableMsg, "missing scheduled msg")
+	}
+	if msg.Msg.Route() == RouterKey {
+		return sdkerrors.Wrap(ErrUnschedulableMsg, "schedules can not be nested")
+	}
+	// only the msgs registered on ModuleCdc can be scheduled, so that the schedules can be exported
+	if _, err := ModuleCdc.MarshalBinaryBare(struct{ Msg sdk.Msg }{msg.Msg}); err != nil {
+		return sdkerrors.Wrapf(ErrUnschedulableMsg, "%s/%s", msg.Msg.Route(), msg.Msg.Type())
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return err
+	}
+	signers := msg.Msg.GetSigners()
+	if len(signers) != 1 || !signers[0].Equals(msg.Owner) {
+		return sdkerrors.Wrap(ErrUnschedulableMsg, "the owner must be the only signer of the scheduled msg")
+	}
+
+	if msg.StartHeight < 0 {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "negative start height: %d", msg.StartHeight)
+	}
+	if (msg.StartHeight == 0) == msg.StartTime.IsZero() {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "either a start height or a start time must be set")
+	}
+	if msg.Interval < 0 {
+		return sdkerrors.Wrapf(ErrInvalidSchedule, "negative interval: %d", msg.Interval)
+	}
+	if msg.MaxExecutions == 0 {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "max executions must be positive")
+	}
+	if msg.Interval == 0 && msg.MaxExecutions != 1 {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "a schedule without interval is executed once")
+	}
+	if msg.GasLimit == 0 {
+		return sdkerrors.Wrap(ErrInvalidSchedule, "gas limit must be positive")
+	}
+	if !msg.Fee.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Fee.String())
+	}
+	return nil
+}
+
+// Escrow returns the fees of all the executions of the schedule
+func (msg MsgCreateSchedule) Escrow() sdk.Coins {
+	escrow := sdk.Coins{}
+	for _, coin := range msg.Fee {
+		escrow = escrow.Add(sdk.NewCoins(sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(msg.MaxExecutions)))))
+	}
+	return escrow
+}
+
+type createScheduleSignDoc struct {
+	Owner         sdk.AccAddress  `json:"owner"`
+	Msg           json.RawMessage `json:"msg"`
+	StartHeight   int64           `json:"start_height"`
+	StartTime     time.Time       `json:"start_time"`
+	Interval      int64           `json:"interval"`
+	MaxExecutions uint64          `json:"max_executions"`
+	GasLimit      uint64          `json:"gas_limit"`
+	Fee           sdk.Coins       `json:"fee"`
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCreateSchedule) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(createScheduleSignDoc{
+		Owner:         msg.Owner,
+		Msg:           msg.Msg.GetSignBytes(),
+		StartHeight:   msg.StartHeight,
+		StartTime:     msg.StartTime,
+		Interval:      msg.Interval,
+		MaxExecutions: msg.MaxExecutions,
+		GasLimit:      msg.GasLimit,
+		Fee:           msg.Fee,
+	})
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCreateSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgCancelSchedule cancels a schedule, the escrowed fees of its remaining executions are
+// refunded to its owner
+type MsgCancelSchedule struct {
+	Owner      sdk.AccAddress `json:"owner" yaml:"owner"`
+	ScheduleID uint64         `json:"schedule_id" yaml:"schedule_id"`
+}
+
+// NewMsgCancelSchedule creates a new MsgCancelSchedule instance
+func NewMsgCancelSchedule(owner sdk.AccAddress, id uint64) MsgCancelSchedule {
+	return MsgCancelSchedule{
+		Owner:      owner,
+		ScheduleID: id,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgCancelSchedule) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgCancelSchedule) Type() string { return TypeMsgCancelSchedule }
+
+// ValidateBasic Implements Msg.
+func (msg MsgCancelSchedule) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing owner address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCancelSchedule) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCancelSchedule) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/bank"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMsgCreateScheduleValidateBasic(t *testing.T) {
+	owner, other := sdk.AccAddress("owner_______________"), sdk.AccAddress("other_______________")
+	send := bank.NewMsgSend(owner, other, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 1)))
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 1000))
+	start := time.Unix(1000, 0)
+
+	tests := []struct {
+		name string
+		msg  MsgCreateSchedule
+		err  bool
+	}{
+		{"at height", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 0, 1, 100000, fee), false},
+		{"recurring from time", NewMsgCreateSchedule(owner, send, 0, start, 10, 5, 100000, fee), false},
+		{"no fee", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 0, 1, 100000, nil), false},
+		{"no owner", NewMsgCreateSchedule(nil, send, 10, time.Time{}, 0, 1, 100000, fee), true},
+		{"no msg", NewMsgCreateSchedule(owner, nil, 10, time.Time{}, 0, 1, 100000, fee), true},
+		{"msg of another signer", NewMsgCreateSchedule(other, send, 10, time.Time{}, 0, 1, 100000, fee), true},
+		{"nested schedule", NewMsgCreateSchedule(owner, NewMsgCancelSchedule(owner, 1), 10, time.Time{}, 0, 1, 100000, fee), true},
+		{"unschedulable msg", NewMsgCreateSchedule(owner, sdk.NewTestMsg(owner), 10, time.Time{}, 0, 1, 100000, fee), true},
+		{"no start", NewMsgCreateSchedule(owner, send, 0, time.Time{}, 0, 1, 100000, fee), true},
+		{"height and time", NewMsgCreateSchedule(owner, send, 10, start, 0, 1, 100000, fee), true},
+		{"negative height", NewMsgCreateSchedule(owner, send, -1, time.Time{}, 0, 1, 100000, fee), true},
+		{"negative interval", NewMsgCreateSchedule(owner, send, 10, time.Time{}, -1, 1, 100000, fee), true},
+		{"no execution", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 1, 0, 100000, fee), true},
+		{"repeated without interval", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 0, 2, 100000, fee), true},
+		{"no gas", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 0, 1, 0, fee), true},
+		{"invalid fee", NewMsgCreateSchedule(owner, send, 10, time.Time{}, 0, 1, 100000, sdk.Coins{sdk.Coin{Denom: "pnch", Amount: sdk.NewInt(-1)}}), true},
+	}
+
+	for _, tc := range tests {
+		err := tc.msg.ValidateBasic()
+		if tc.err {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestMsgCreateScheduleEscrow(t *testing.T) {
+	owner := sdk.AccAddress("owner_______________")
+	fee := sdk.NewCoins(sdk.NewInt64Coin("foo", 3), sdk.NewInt64Coin(sdk.NativeTokenName, 1000))
+	msg := NewMsgCreateSchedule(owner, nil, 10, time.Time{}, 5, 4, 100000, fee)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("foo", 12), sdk.NewInt64Coin(sdk.NativeTokenName, 4000)), msg.Escrow())
+}
+
+func TestMsgCancelScheduleValidateBasic(t *testing.T) {
+	require.NoError(t, NewMsgCancelSchedule(sdk.AccAddress("owner_______________"), 1).ValidateBasic())
+	require.Error(t, NewMsgCancelSchedule(nil, 1).ValidateBasic())
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	DefaultParamspace = ModuleName
+
+	DefaultMaxBlockGas   uint64 = 10000000
+	DefaultMaxExecutions uint64 = 1000
+)
+
+var (
+	DefaultMinGasPrices = sdk.DecCoins{sdk.NewDecCoinFromDec(sdk.NativeTokenName, sdk.OneDec())}
+)
+
+var (
+	KeyMaxBlockGas   = []byte("MaxBlockGas")
+	KeyMaxExecutions = []byte("MaxExecutions")
+	KeyMinGasPrices  = []byte("MinGasPrices")
+)
+
+// Params defines the scheduler params: the gas budget of the executions of a block, the max
+// executions of a schedule and the min gas prices of the fee paid by each execution
+type Params struct {
+	MaxBlockGas   uint64       `json:"max_block_gas" yaml:"max_block_gas"`
+	MaxExecutions uint64       `json:"max_executions" yaml:"max_executions"`
+	MinGasPrices  sdk.DecCoins `json:"min_gas_prices" yaml:"min_gas_prices"`
+}
+
+var _ params.ParamSet = (*Params)(nil)
+
+func NewParams(maxBlockGas, maxExecutions uint64, minGasPrices sdk.DecCoins) Params {
+	return Params{
+		MaxBlockGas:   maxBlockGas,
+		MaxExecutions: maxExecutions,
+		MinGasPrices:  minGasPrices,
+	}
+}
+
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyMaxBlockGas, &p.MaxBlockGas, validatePositiveUint64),
+		params.NewParamSetPair(KeyMaxExecutions, &p.MaxExecutions, validatePositiveUint64),
+		params.NewParamSetPair(KeyMinGasPrices, &p.MinGasPrices, validateMinGasPrices),
+	}
+}
+
+func DefaultParams() Params {
+	return NewParams(
+		DefaultMaxBlockGas,
+		DefaultMaxExecutions,
+		DefaultMinGasPrices,
+	)
+}
+
+// Validate checks the params are valid
+func (p Params) Validate() error {
+	if err := validatePositiveUint64(p.MaxBlockGas); err != nil {
+		return err
+	}
+	if err := validatePositiveUint64(p.MaxExecutions); err != nil {
+		return err
+	}
+	return validateMinGasPrices(p.MinGasPrices)
+}
+
+// MinFee returns the min fee of an execution using gasLimit gas, fee = ceil(minGasPrice * gasLimit)
+func (p Params) MinFee(gasLimit uint64) sdk.Coins {
+	fees := sdk.Coins{}
+	gasLimitDec := sdk.NewDec(int64(gasLimit))
+	for _, gp := range p.MinGasPrices {
+		fees = fees.Add(sdk.NewCoins(sdk.NewCoin(gp.Denom, gp.Amount.Mul(gasLimitDec).Ceil().RoundInt())))
+	}
+	return fees
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Max Block Gas    : %d
+  Max Executions   : %d
+  Min Gas Prices   : %s`,
+		p.MaxBlockGas,
+		p.MaxExecutions,
+		p.MinGasPrices)
+}
+
+func validatePositiveUint64(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("parameter must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMinGasPrices(i interface{}) error {
+	v, ok := i.(sdk.DecCoins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() && !v.Empty() {
+		return fmt.Errorf("invalid min gas prices: %s", v)
+	}
+
+	return nil
+}
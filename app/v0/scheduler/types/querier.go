@@ -0,0 +1,34 @@
+package types
+
+import (
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	QuerySchedule          = "schedule"
+	QuerySchedules         = "schedules"
+	QueryExecutionFailures = "failures"
+	QueryParameters        = "parameters"
+)
+
+// QueryScheduleParams are the params of the query of a schedule, and of its execution failures
+type QueryScheduleParams struct {
+	ScheduleID uint64 `json:"schedule_id" yaml:"schedule_id"`
+}
+
+func NewQueryScheduleParams(id uint64) QueryScheduleParams {
+	return QueryScheduleParams{
+		ScheduleID: id,
+	}
+}
+
+// QuerySchedulesParams are the params of the query of the schedules of an owner
+type QuerySchedulesParams struct {
+	Owner sdk.AccAddress `json:"owner" yaml:"owner"`
+}
+
+func NewQuerySchedulesParams(owner sdk.AccAddress) QuerySchedulesParams {
+	return QuerySchedulesParams{
+		Owner: owner,
+	}
+}
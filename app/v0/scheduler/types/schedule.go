@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// Schedule is a msg of its owner executed by the chain from StartHeight, or from the first block
+// at or after StartTime, then every Interval blocks until it has been executed MaxExecutions times.
+// Each execution runs with at most GasLimit gas and pays Fee out of the Escrow of the schedule.
+type Schedule struct {
+	ID            uint64         `json:"id" yaml:"id"`
+	Owner         sdk.AccAddress `json:"owner" yaml:"owner"`
+	Msg           sdk.Msg        `json:"msg" yaml:"msg"`
+	StartHeight   int64          `json:"start_height" yaml:"start_height"`
+	StartTime     time.Time      `json:"start_time" yaml:"start_time"`
+	Interval      int64          `json:"interval" yaml:"interval"`
+	MaxExecutions uint64         `json:"max_executions" yaml:"max_executions"`
+	GasLimit      uint64         `json:"gas_limit" yaml:"gas_limit"`
+	Fee           sdk.Coins      `json:"fee" yaml:"fee"`
+	Escrow        sdk.Coins      `json:"escrow" yaml:"escrow"`
+	Executions    uint64         `json:"executions" yaml:"executions"`
+	Failures      uint64         `json:"failures" yaml:"failures"`
+	NextHeight    int64          `json:"next_height" yaml:"next_height"`
+}
+
+// NewSchedule creates a new schedule, escrowing the fees of all its executions
+func NewSchedule(id uint64, msg MsgCreateSchedule) Schedule {
+	return Schedule{
+		ID:            id,
+		Owner:         msg.Owner,
+		Msg:           msg.Msg,
+		StartHeight:   msg.StartHeight,
+		StartTime:     msg.StartTime,
+		Interval:      msg.Interval,
+		MaxExecutions: msg.MaxExecutions,
+		GasLimit:      msg.GasLimit,
+		Fee:           msg.Fee,
+		Escrow:        msg.Escrow(),
+		NextHeight:    msg.StartHeight,
+	}
+}
+
+// Finished returns whether the schedule has been executed MaxExecutions times
+func (s Schedule) Finished() bool {
+	return s.Executions >= s.MaxExecutions
+}
+
+// ExecutionTxBytes returns the bytes standing for the tx of the current execution of the schedule,
+// so that the msg of each execution runs with its own tx hash
+func (s Schedule) ExecutionTxBytes() []byte {
+	bz := append([]byte(ModuleName), sdk.Uint64ToBigEndian(s.ID)...)
+	return append(bz, sdk.Uint64ToBigEndian(s.Executions)...)
+}
+
+func (s Schedule) String() string {
+	return fmt.Sprintf(`Schedule %d:
+  Owner:           %s
+  Msg:             %s/%s
+  Start Height:    %d
+  Start Time:      %s
+  Interval:        %d
+  Max Executions:  %d
+  Gas Limit:       %d
+  Fee:             %s
+  Escrow:          %s
+  Executions:      %d
+  Failures:        %d
+  Next Height:     %d`,
+		s.ID, s.Owner, s.Msg.Route(), s.Msg.Type(), s.StartHeight, s.StartTime, s.Interval,
+		s.MaxExecutions, s.GasLimit, s.Fee, s.Escrow, s.Executions, s.Failures, s.NextHeight)
+}
+
+// Schedules is a collection of Schedule
+type Schedules []Schedule
+
+func (s Schedules) String() string {
+	out := make([]string, 0, len(s))
+	for _, schedule := range s {
+		out = append(out, schedule.String())
+	}
+	return strings.Join(out, "\n")
+}
+
+// ExecutionFailure records the error of a failed execution of a schedule
+type ExecutionFailure struct {
+	ScheduleID uint64 `json:"schedule_id" yaml:"schedule_id"`
+	Execution  uint64 `json:"execution" yaml:"execution"`
+	Height     int64  `json:"height" yaml:"height"`
+	Error      string `json:"error" yaml:"error"`
+}
+
+func NewExecutionFailure(scheduleID, execution uint64, height int64, err error) ExecutionFailure {
+	return ExecutionFailure{
+		ScheduleID: scheduleID,
+		Execution:  execution,
+		Height:     height,
+		Error:      err.Error(),
+	}
+}
+
+func (f ExecutionFailure) String() string {
+	return fmt.Sprintf("schedule %d execution %d at height %d: %s", f.ScheduleID, f.Execution, f.Height, f.Error)
+}
+
+// ExecutionFailures is a collection of ExecutionFailure
+type ExecutionFailures []ExecutionFailure
+
+func (f ExecutionFailures) String() string {
+	out := make([]string, 0, len(f))
+	for _, failure := range f {
+		out = append(out, failure.String())
+	}
+	return strings.Join(out, "\n")
+}
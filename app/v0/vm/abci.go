@@ -7,6 +7,17 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// BeginBlocker stores the hash of the previous block for the BLOCKHASH opcode
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, keeper keeper.Keeper) {
+	height := req.Header.Height - 1
+	hash := req.Header.LastBlockId.Hash
+	if height <= 0 || len(hash) == 0 {
+		return
+	}
+
+	keeper.SetBlockHash(ctx, uint64(height), sdk.BytesToHash(hash))
+}
+
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
@@ -23,5 +34,14 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 	// Clear accounts cache after account data has been committed
 	keeper.StateDB.ClearStateObjects()
 
+	// Write the receipts of the failed txs, discarded with the txs
+	keeper.StateDB.CommitFailedReceipts()
+
+	// Drop the tx state diffs which left the retention window
+	height, retention := uint64(ctx.BlockHeight()), keeper.GetStateDiffRetention(ctx)
+	if height >= retention {
+		keeper.PruneStateDiffs(ctx, height-retention)
+	}
+
 	return []abci.ValidatorUpdate{}
 }
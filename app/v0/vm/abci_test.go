@@ -0,0 +1,35 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestBeginBlockerBlockHashWindow(t *testing.T) {
+	ctx, _, k, _ := keep.CreateTestInput(t, false, 1000)
+
+	blockHash := func(height int64) []byte {
+		return sdk.Uint64ToBigEndian(uint64(height) + 1)
+	}
+
+	last := int64(types.BlockHashWindow + 10)
+	for height := int64(1); height <= last; height++ {
+		BeginBlocker(ctx, abci.RequestBeginBlock{Header: abci.Header{
+			Height:      height,
+			LastBlockId: abci.BlockID{Hash: blockHash(height - 1)},
+		}}, k)
+	}
+
+	// the hashes of the last BlockHashWindow blocks are kept
+	for height := last - types.BlockHashWindow; height < last; height++ {
+		require.Equal(t, sdk.BytesToHash(blockHash(height)), k.GetBlockHash(ctx, uint64(height)))
+	}
+	require.Equal(t, sdk.Hash{}, k.GetBlockHash(ctx, uint64(last-types.BlockHashWindow-1)))
+	require.Equal(t, sdk.Hash{}, k.GetBlockHash(ctx, uint64(last)))
+}
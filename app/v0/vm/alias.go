@@ -18,16 +18,29 @@ type (
 	Keeper        = keeper.Keeper
 	AccountKeeper = types.AccountKeeper
 	MsgContract   = types.MsgContract
+	MsgEthereumTx = types.MsgEthereumTx
 	CommitStateDB = types.CommitStateDB
+	NativeKeepers = types.NativeKeepers
 	Log           = types.Log
+	ForkHeights   = types.ForkHeights
+	AccessList    = types.AccessList
+	AccessTuple   = types.AccessTuple
+
+	MsgSetContractMetadata = types.MsgSetContractMetadata
+	ContractMetadata       = types.ContractMetadata
 
 	GenesisState = types.GenesisState
 )
 
 var (
 	// functions aliases
-	NewKeeper        = keeper.NewKeeper
-	NewCommitStateDB = types.NewCommitStateDB
+	NewKeeper         = keeper.NewKeeper
+	NewCommitStateDB  = types.NewCommitStateDB
+	NewMsgEthereumTx  = types.NewMsgEthereumTx
+	NewTxDecoder      = types.NewTxDecoder
+	ChainIDFromString = types.ChainIDFromString
+
+	NewMsgSetContractMetadata = types.NewMsgSetContractMetadata
 
 	CreateAddress  = common.CreateAddress
 	CreateAddress2 = common.CreateAddress2
@@ -51,6 +64,11 @@ var (
 	ErrGasUintOverflow          = types.ErrGasUintOverflow
 	ErrNoPayload                = types.ErrNoPayload
 	ErrWrongCtx                 = types.ErrWrongCtx
+	ErrNativeUnavailable        = types.ErrNativeUnavailable
+	ErrInvalidNativeCall        = types.ErrInvalidNativeCall
+	ErrInvalidCode              = types.ErrInvalidCode
+	ErrAccessListNotActive      = types.ErrAccessListNotActive
+	ErrNotContractDeployer      = types.ErrNotContractDeployer
 
 	// variable aliases
 	ModuleCdc = types.ModuleCdc
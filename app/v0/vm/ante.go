@@ -0,0 +1,125 @@
+package vm
+
+import (
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/ante"
+	authtypes "github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NewAnteHandler returns an AnteHandler which routes RLP encoded ethereum txs through the
+// ethereum tx decorators and every other tx through the default auth AnteHandler.
+func NewAnteHandler(ak auth.AccountKeeper, supplyKeeper authtypes.SupplyKeeper, feegrantKeeper authtypes.FeegrantKeeper, sigGasConsumer ante.SignatureVerificationGasConsumer) sdk.AnteHandler {
+	stdAnteHandler := ante.NewAnteHandler(ak, supplyKeeper, feegrantKeeper, sigGasConsumer)
+	ethAnteHandler := sdk.ChainAnteDecorators(
+		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
+		ante.NewFeePreprocessDecorator(ak),
+		ante.NewMempoolFeeDecorator(),
+		NewEthSigVerificationDecorator(ak),
+		NewEthNonceDecorator(ak),
+		ante.NewDeductFeeDecorator(ak, supplyKeeper, nil),
+		NewEthIncrementSequenceDecorator(ak), // innermost AnteDecorator
+	)
+
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		switch tx.(type) {
+		case types.MsgEthereumTx:
+			return ethAnteHandler(ctx, tx, simulate)
+		default:
+			return stdAnteHandler(ctx, tx, simulate)
+		}
+	}
+}
+
+// EthSigVerificationDecorator checks the tx is signed for the current chain and recovers its
+// sender, it consumes the tx size gas and the secp256k1 signature verification gas.
+type EthSigVerificationDecorator struct {
+	ak auth.AccountKeeper
+}
+
+func NewEthSigVerificationDecorator(ak auth.AccountKeeper) EthSigVerificationDecorator {
+	return EthSigVerificationDecorator{
+		ak: ak,
+	}
+}
+
+func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	ethTx, ok := tx.(types.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
+	}
+
+	params := esvd.ak.GetParams(ctx)
+	ctx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*sdk.Gas(len(ctx.TxBytes())), "txSize")
+	ctx.GasMeter().ConsumeGas(params.SigVerifyCostSecp256k1, "ante verify: secp256k1")
+
+	if err := ethTx.ValidateBasic(); err != nil {
+		return ctx, err
+	}
+
+	chainID := types.ChainIDFromString(ctx.ChainID())
+	if txChainID := ethTx.ChainID(); txChainID == nil || txChainID.Cmp(chainID) != 0 {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid chain id: %v, expected: %s", txChainID, chainID)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthNonceDecorator checks the nonce of the tx matches the sequence of the sender account.
+type EthNonceDecorator struct {
+	ak auth.AccountKeeper
+}
+
+func NewEthNonceDecorator(ak auth.AccountKeeper) EthNonceDecorator {
+	return EthNonceDecorator{
+		ak: ak,
+	}
+}
+
+func (end EthNonceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	ethTx, ok := tx.(types.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
+	}
+
+	acc, err := ante.GetSignerAcc(ctx, end.ak, ethTx.FeePayer())
+	if err != nil {
+		return ctx, err
+	}
+
+	if ethTx.Data.AccountNonce != acc.GetSequence() {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "invalid nonce; got %d, expected %d", ethTx.Data.AccountNonce, acc.GetSequence())
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthIncrementSequenceDecorator increments the sequence of the sender account. Unlike
+// IncrementSequenceDecorator the sequence is also incremented on CheckTx, so that an account
+// can have several ethereum txs with consecutive nonces in the mempool, as ethereum wallets expect.
+type EthIncrementSequenceDecorator struct {
+	ak auth.AccountKeeper
+}
+
+func NewEthIncrementSequenceDecorator(ak auth.AccountKeeper) EthIncrementSequenceDecorator {
+	return EthIncrementSequenceDecorator{
+		ak: ak,
+	}
+}
+
+func (eisd EthIncrementSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	ethTx, ok := tx.(types.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
+	}
+
+	acc := eisd.ak.GetAccount(ctx, ethTx.FeePayer())
+	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+		panic(err)
+	}
+	eisd.ak.SetAccount(ctx, acc)
+
+	return next(ctx, tx, simulate)
+}
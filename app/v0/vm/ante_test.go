@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/auth/ante"
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestEthereumTxAnteAndHandler(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, supplyKeeper := keep.CreateTestInput(t, false, 1000000)
+	ctx = ctx.WithChainID("nch-1").WithBlockHeight(1)
+	accountKeeper.SetParams(ctx, auth.DefaultParams())
+
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := sdk.AccAddress(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes())
+	initCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100000000000000))
+	acc := accountKeeper.NewAccountWithAddress(ctx, sender)
+	require.NoError(t, acc.SetCoins(initCoins))
+	accountKeeper.SetAccount(ctx, acc)
+
+	anteHandler := NewAnteHandler(accountKeeper, supplyKeeper, nil, ante.DefaultSigVerificationGasConsumer)
+	handler := NewHandler(vmKeeper)
+
+	to := ethcmn.BytesToAddress(keep.Addrs[0])
+	gasPrice := big.NewInt(int64(auth.DefaultParams().GasPriceThreshold))
+	newTx := func(nonce uint64, chainID *big.Int) (MsgEthereumTx, []byte) {
+		tx := NewMsgEthereumTx(nonce, &to, big.NewInt(100), 100000, gasPrice, nil)
+		require.NoError(t, tx.Sign(chainID, key))
+		bz, err := rlp.EncodeToBytes(&tx)
+		require.NoError(t, err)
+		return tx, bz
+	}
+
+	// wrong chain id
+	tx, bz := newTx(0, big.NewInt(2))
+	_, err = anteHandler(ctx.WithTxBytes(bz), tx, false)
+	require.Error(t, err)
+
+	// wrong nonce
+	tx, bz = newTx(1, big.NewInt(1))
+	_, err = anteHandler(ctx.WithTxBytes(bz), tx, false)
+	require.Error(t, err)
+
+	tx, bz = newTx(0, big.NewInt(1))
+	newCtx, err := anteHandler(ctx.WithTxBytes(bz), tx, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), accountKeeper.GetAccount(newCtx, sender).GetSequence())
+
+	fee := tx.GetFee()
+	require.Equal(t, initCoins.Sub(fee), accountKeeper.GetAccount(newCtx, sender).GetCoins())
+
+	balanceBefore := accountKeeper.GetAccount(newCtx, keep.Addrs[0]).GetCoins()
+	_, err = handler(newCtx, tx)
+	require.NoError(t, err)
+	require.Equal(t, balanceBefore.Add(sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100))), accountKeeper.GetAccount(newCtx, keep.Addrs[0]).GetCoins())
+
+	// replaying the same tx fails
+	_, err = anteHandler(newCtx.WithTxBytes(bz), tx, false)
+	require.Error(t, err)
+}
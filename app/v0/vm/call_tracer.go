@@ -0,0 +1,176 @@
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+var _ Tracer = (*CallTracer)(nil)
+
+// callFrame is a call frame under construction, gasLeft and gasCost are the gas left and the
+// cost of the last step executed within the frame
+type callFrame struct {
+	*types.CallFrame
+	entered bool
+	gasLeft uint64
+	gasCost uint64
+}
+
+// CallTracer is an VM tracer implementing Tracer which records the tree of nested
+// CALL/CALLCODE/DELEGATECALL/STATICCALL/CREATE/CREATE2 frames of an execution.
+type CallTracer struct {
+	root  *types.CallFrame
+	stack []*callFrame // frames entered and not yet returned, stack[i] executes at depth i+1
+}
+
+// NewCallTracer returns a new call tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements the Tracer interface to initialize the top level frame.
+func (t *CallTracer) CaptureStart(from sdk.AccAddress, to sdk.AccAddress, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := CALL.String()
+	if create {
+		typ = CREATE.String()
+	}
+
+	t.root = &types.CallFrame{
+		Type:  typ,
+		From:  from,
+		To:    to,
+		Value: new(big.Int).Set(value),
+		Gas:   gas,
+		Input: append([]byte{}, input...),
+	}
+	t.stack = []*callFrame{{CallFrame: t.root, entered: true, gasLeft: gas}}
+	return nil
+}
+
+// CaptureState implements the Tracer interface, it opens a frame on each call or create
+// opcode and closes the frames of the calls which returned to the current depth.
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth uint64, err error) error {
+	if len(t.stack) == 0 {
+		return nil
+	}
+
+	// close the frames which returned, the top of the stack holds the call result
+	for uint64(len(t.stack)) > depth && len(t.stack) > 1 {
+		t.exit(stack)
+	}
+
+	frame := t.stack[len(t.stack)-1]
+	if !frame.entered {
+		frame.entered = true
+		frame.Gas = gas
+		if frame.To.Empty() {
+			frame.To = contract.Address()
+		}
+	}
+	frame.gasLeft, frame.gasCost = gas, cost
+
+	switch op {
+	case RETURN, REVERT:
+		if stack.len() >= 2 {
+			frame.Output = memory.GetCopy(stack.Back(0).Int64(), stack.Back(1).Int64())
+		}
+		if op == REVERT {
+			frame.Error = ErrExecutionReverted.Error()
+		}
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE, CREATE2:
+		if err == nil {
+			t.enter(op, contract, memory, stack)
+		}
+	}
+
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to record the error of the current frame.
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth uint64, err error) error {
+	if err != nil && depth > 0 && depth <= uint64(len(t.stack)) {
+		t.stack[depth-1].Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the top level frame.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+
+	t.root.Output = append([]byte{}, output...)
+	t.root.GasUsed = gasUsed
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+	t.stack = nil
+	return nil
+}
+
+// CallFrame returns the captured top level frame
+func (t *CallTracer) CallFrame() *types.CallFrame {
+	return t.root
+}
+
+func (t *CallTracer) enter(op OpCode, contract *Contract, memory *Memory, stack *Stack) {
+	frame := &types.CallFrame{
+		Type: op.String(),
+		From: contract.Address(),
+	}
+
+	switch op {
+	case CALL, CALLCODE:
+		if stack.len() < 7 {
+			return
+		}
+		frame.To = sdk.BigToAddress(stack.Back(1))
+		frame.Value = new(big.Int).Set(stack.Back(2))
+		frame.Input = memory.GetCopy(stack.Back(3).Int64(), stack.Back(4).Int64())
+	case DELEGATECALL, STATICCALL:
+		if stack.len() < 6 {
+			return
+		}
+		frame.To = sdk.BigToAddress(stack.Back(1))
+		frame.Input = memory.GetCopy(stack.Back(2).Int64(), stack.Back(3).Int64())
+	case CREATE, CREATE2:
+		if stack.len() < 3 {
+			return
+		}
+		frame.Value = new(big.Int).Set(stack.Back(0))
+		frame.Input = memory.GetCopy(stack.Back(1).Int64(), stack.Back(2).Int64())
+	}
+
+	parent := t.stack[len(t.stack)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.stack = append(t.stack, &callFrame{CallFrame: frame})
+}
+
+func (t *CallTracer) exit(stack *Stack) {
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+
+	if frame.entered && frame.Gas >= frame.gasLeft {
+		frame.GasUsed = frame.Gas - frame.gasLeft + frame.gasCost
+	}
+
+	if stack.len() == 0 {
+		return
+	}
+
+	result := stack.Back(0)
+	switch frame.Type {
+	case CREATE.String(), CREATE2.String():
+		if result.Sign() != 0 {
+			frame.To = sdk.BigToAddress(result)
+		}
+	}
+
+	if result.Sign() == 0 && frame.Error == "" {
+		frame.Error = "call failed"
+	}
+}
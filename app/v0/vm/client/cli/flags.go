@@ -9,4 +9,20 @@ const (
 	flagAbiFile      = "abi_file"
 	flagShowCode     = "show_code"
 	flagAll          = "all"
+
+	flagTracer         = "tracer"
+	flagDisableMemory  = "disable_memory"
+	flagDisableStack   = "disable_stack"
+	flagDisableStorage = "disable_storage"
+	flagLimit          = "limit"
+
+	flagFromBlock = "from_block"
+	flagToBlock   = "to_block"
+	flagAddresses = "addresses"
+	flagTopics    = "topics"
+
+	flagName            = "name"
+	flagCompilerVersion = "compiler_version"
+	flagSourceFile      = "source_file"
+	flagNonce           = "nonce"
 )
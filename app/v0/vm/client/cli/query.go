@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	vmutils "github.com/netcloth/netcloth-chain/app/v0/vm/client/utils"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -39,9 +41,17 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryCode(cdc),
 		GetCmdGetStorage(cdc),
 		GetCmdGetLogs(cdc),
+		GetCmdQueryReceipt(cdc),
+		GetCmdFilterLogs(cdc),
 		GetCmdQueryCreateFee(cdc),
 		GetCmdQueryCallFee(cdc),
 		GetCmdQueryCall(cdc),
+		GetCmdQueryTrace(cdc),
+		GetCmdQueryTraceCall(cdc),
+		GetCmdQuerySimulate(cdc),
+		GetCmdQueryStateDiff(cdc),
+		GetCmdQueryContractMetadata(cdc),
+		GetCmdVerifyContract(cdc),
 	)...)
 	return vmQueryCmd
 }
@@ -77,7 +87,7 @@ func GetCmdQueryDBState(cdc *codec.Codec) *cobra.Command {
 		Short: "Query the current vm whole state",
 		Long: strings.TrimSpace(fmt.Sprintf(`Query values set as vm state.
 Example:
-$ %s query vm state [--all] [--show_code]`, version.ClientName)),
+$ %s query vm state [--all] [--show_code] [--height=<height>]`, version.ClientName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -130,7 +140,7 @@ func GetCmdQueryCode(cdc *codec.Codec) *cobra.Command {
 		Short: "Querying commands for Contract Code",
 		Long: strings.TrimSpace(fmt.Sprintf(`Query Contract Code by Account Address.
 Example:
-$ %s query vm code [address]`, version.ClientName)),
+$ %s query vm code [address] [--height=<height>]`, version.ClientName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -165,7 +175,7 @@ func GetCmdGetStorage(cdc *codec.Codec) *cobra.Command {
 		Short: "Querying storage for an account at a given key",
 		Long: strings.TrimSpace(fmt.Sprintf(`Query Contract Code by Account Address.
 Example:
-$ %s query vm code [address]`, version.ClientName)),
+$ %s query vm storage [address] [key] [--height=<height>]`, version.ClientName)),
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -213,6 +223,253 @@ $ %s query vm logs [txHash]`, version.ClientName)),
 	}
 }
 
+func GetCmdQueryReceipt(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "receipt [txhash] [msg_index]",
+		Short: "Querying the receipt of a vm tx by txHash",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the receipt of a vm tx by txHash: status, gas used, created contract address,
+logs, logs bloom and revert reason. A tx with several vm msgs has a receipt per msg, msg_index is
+the index of the msg among the vm msgs of the tx, 0 by default.
+Example:
+$ %s query vm receipt [txHash] [msg_index]`, version.ClientName)),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/vm/%s/%s", types.QueryReceipt, args[0])
+			if len(args) > 1 {
+				route = fmt.Sprintf("%s/%s", route, args[1])
+			}
+			res, _, err := cliCtx.Query(route)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+}
+
+func GetCmdQueryStateDiff(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "state-diff [txhash]",
+		Short: "Querying the state diff of a vm tx by txHash",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the balances, nonces, code and storage slots changed by a vm tx, with their values before
+and after the tx. The state diffs are only recorded when the state_diff_retention param is set, and
+are kept for that number of blocks.
+Example:
+$ %s query vm state-diff 6D0A4A1F0C3F43BA4D6D0EBC1C1D8E0AA8D0A4F51E1A3C4B5D6E7F8091A2B3C4`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.Query(
+				fmt.Sprintf("custom/vm/%s/%s", types.QueryStateDiff, args[0]))
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+}
+
+func GetCmdQueryContractMetadata(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "contract-metadata [contract]",
+		Short: "Querying the metadata registered for a contract",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the name, abi, compiler version and source hash registered by the deployer of a
+contract.
+Example:
+$ %s query vm contract-metadata nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 [--height=<height>]`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			contract, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.Query(
+				fmt.Sprintf("custom/vm/%s/%s", types.QueryContractMetadata, contract))
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+}
+
+// VerifyResult is the result of the verification of a contract against its compiler output
+type VerifyResult struct {
+	Contract sdk.AccAddress `json:"contract"`
+	// Verified is true when the code matches the deployed bytecode, ignoring the solc metadata
+	Verified   bool `json:"verified"`
+	ExactMatch bool `json:"exact_match"`
+	// ABIMatch and SourceHashMatch are only set when metadata is registered for the contract, and
+	// for SourceHashMatch when a source file is given
+	ABIMatch        *bool `json:"abi_match,omitempty"`
+	SourceHashMatch *bool `json:"source_hash_match,omitempty"`
+}
+
+func GetCmdVerifyContract(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [contract] [artifact_file]",
+		Short: "Verify that the code of a contract is the deployed bytecode of a compiler artifact",
+		Long: strings.TrimSpace(fmt.Sprintf(`Compare the code of a contract to the deployed bytecode of the json artifact produced by
+compiling its source (truffle, hardhat, solc --combined-json or solc --standard-json output). The
+code is verified when it matches once the metadata appended by solc is stripped, it is an exact match
+when the metadata matches too. When metadata is registered for the contract, its abi is compared to
+the abi of the artifact and its source hash to the keccak256 hash of the --source_file.
+Example:
+$ %s query vm verify nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 ./build/Demo.json --source_file=./Demo.sol`, version.ClientName)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			contract, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			artifact, err := ArtifactFromFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			code, _, err := cliCtx.Query(fmt.Sprintf("custom/vm/%s/%s", types.QueryCode, contract))
+			if err != nil {
+				return err
+			}
+			if len(code) == 0 {
+				return fmt.Errorf("no code found with address %s", contract)
+			}
+
+			result := VerifyResult{
+				Contract:   contract,
+				ExactMatch: bytes.Equal(code, artifact.DeployedBytecode),
+			}
+			result.Verified = result.ExactMatch || bytes.Equal(StripCodeMetadata(code), StripCodeMetadata(artifact.DeployedBytecode))
+
+			if metadata, err := vmutils.QueryContractMetadata(cliCtx, contract); err == nil {
+				abiMatch, err := EqualABIs(metadata.ABI, artifact.ABI)
+				if err != nil {
+					return err
+				}
+				result.ABIMatch = &abiMatch
+
+				if sourceFile := viper.GetString(flagSourceFile); len(sourceFile) != 0 {
+					sourceHash, err := SourceHashFromFile(sourceFile)
+					if err != nil {
+						return err
+					}
+					sourceHashMatch := sourceHash == metadata.SourceHash
+					result.SourceHashMatch = &sourceHashMatch
+				}
+			}
+
+			bz, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+
+			if !result.Verified {
+				return fmt.Errorf("code of contract %s does not match the artifact", contract)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSourceFile, "", "source file whose keccak256 hash is compared to the registered source hash")
+	return cmd
+}
+
+func GetCmdFilterLogs(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "filter-logs",
+		Short: "Querying the logs of a range of blocks by contract addresses and topics",
+		Long: strings.TrimSpace(fmt.Sprintf(`Query the logs of a range of blocks, emitted by one of the contract addresses and matching
+the topics. Topic positions are separated by ';' and the alternatives of a position by ',', an
+empty position matches any topic. A zero block number means the latest block.
+Example:
+$ %s query vm filter-logs --from_block=100 --to_block=200 --addresses=nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe \
+  --topics="0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef;;0x000000000000000000000000f4d8e8ea4e2a4c4b5e6c8f1b5f5e2d2c3b4a5968"`, version.ClientName)),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var addresses []sdk.AccAddress
+			for _, s := range viper.GetStringSlice(flagAddresses) {
+				addr, err := sdk.AccAddressFromBech32(s)
+				if err != nil {
+					return err
+				}
+				addresses = append(addresses, addr)
+			}
+
+			topics, err := parseTopics(viper.GetString(flagTopics))
+			if err != nil {
+				return err
+			}
+
+			params := types.NewQueryFilterLogsParams(viper.GetUint64(flagFromBlock), viper.GetUint64(flagToBlock), addresses, topics)
+			if err := params.ValidateBasic(); err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/vm/%s", types.QueryFilterLogs), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryLogsResult
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().Uint64(flagFromBlock, 0, "first block of the range, 0 means the latest block")
+	cmd.Flags().Uint64(flagToBlock, 0, "last block of the range, 0 means the latest block")
+	cmd.Flags().StringSlice(flagAddresses, nil, "contract addresses emitting the logs, comma separated, any address when empty")
+	cmd.Flags().String(flagTopics, "", "topics of the logs, positions separated by ';' and alternatives by ','")
+	return cmd
+}
+
+// parseTopics parses topic positions separated by ';', each holding hex topics separated by ','
+func parseTopics(s string) ([][]sdk.Hash, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	positions := strings.Split(s, ";")
+	topics := make([][]sdk.Hash, len(positions))
+	for i, position := range positions {
+		for _, topic := range strings.Split(position, ",") {
+			topic = strings.TrimSpace(topic)
+			if topic == "" {
+				continue
+			}
+
+			bz, err := hexutil.Decode(topic)
+			if err != nil {
+				return nil, fmt.Errorf("invalid topic %s: %v", topic, err)
+			}
+			if len(bz) != sdk.HashLength {
+				return nil, fmt.Errorf("invalid topic %s: length must be %d bytes", topic, sdk.HashLength)
+			}
+			topics[i] = append(topics[i], sdk.BytesToHash(bz))
+		}
+	}
+	return topics, nil
+}
+
 func GetCmdQueryCreateFee(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "feecreate [code_file]",
@@ -252,10 +509,11 @@ func GetCmdQueryCallFee(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "feecall [from] [to] [method] [args] [amount] [abi_file]",
 		Short: "Querying fee to call contract",
-		Long: strings.TrimSpace(fmt.Sprintf(`Querying fee to call contract.
+		Long: strings.TrimSpace(fmt.Sprintf(`Querying fee to call contract. The abi_file may be omitted when the abi is registered in
+the metadata of the contract.
 Example:
-$ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf 0000000000000000000000000000000000000000000000000000000000000001 0pnch ./demo.abi`, version.ClientName)),
-		Args: cobra.ExactArgs(6),
+$ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf 0000000000000000000000000000000000000000000000000000000000000001 0pnch ./demo.abi [--height=<height>]`, version.ClientName)),
+		Args: cobra.RangeArgs(5, 6),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -269,7 +527,11 @@ $ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz
 				return err
 			}
 
-			abiObj, err := AbiFromFile(args[5])
+			var abiFile string
+			if len(args) > 5 {
+				abiFile = args[5]
+			}
+			abiObj, err := ContractABI(cliCtx, abiFile, toAddr)
 			if err != nil {
 				return err
 			}
@@ -320,12 +582,13 @@ $ %s query vm feecall nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz
 
 func GetCmdQueryCall(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "call [from] [to] [method] [args] [amount] [abi_file]",
+		Use:   "call [from] [to] [method] [abi_file]",
 		Short: "Querying fee to call contract",
-		Long: strings.TrimSpace(fmt.Sprintf(`call contract for query, don't create a transaction.
+		Long: strings.TrimSpace(fmt.Sprintf(`call contract for query, don't create a transaction. The abi_file may be omitted when the
+abi is registered in the metadata of the contract.
 Example:
-$ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf ./demo.abi --amount=0pnch --args="arg1 arg2"`, version.ClientName)),
-		Args: cobra.ExactArgs(4),
+$ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 balanceOf ./demo.abi --amount=0pnch --args="arg1 arg2" [--height=<height>]`, version.ClientName)),
+		Args: cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
@@ -339,8 +602,17 @@ $ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz474
 				return err
 			}
 
+			var abiFile string
+			if len(args) > 3 {
+				abiFile = args[3]
+			}
+			abiObj, err := ContractABI(cliCtx, abiFile, toAddr)
+			if err != nil {
+				return err
+			}
+
 			argList := viper.GetStringSlice(flagArgs)
-			payload, m, err := GenPayload(args[3], args[2], argList)
+			payload, m, err := GenPayloadFromABI(abiObj, args[2], argList)
 			if err != nil {
 				return err
 			}
@@ -393,3 +665,166 @@ $ %s query vm call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz474
 
 	return cmd
 }
+
+func GetCmdQueryTrace(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace [txhash]",
+		Short: "Trace the vm execution of a committed tx",
+		Long: strings.TrimSpace(fmt.Sprintf(`Re-execute the first vm msg of a committed tx on the state it was executed on and print
+the opcode level trace (--tracer=struct) or the tree of nested calls (--tracer=call).
+Example:
+$ %s query vm trace 6D0A4A1F0C3F43BA4D6D0EBC1C1D8E0AA8D0A4F51E1A3C4B5D6E7F8091A2B3C4 --tracer=call`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			hash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := vmutils.QueryTxTrace(cliCtx, hash, traceParamsFromFlags())
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+
+	addTraceFlags(cmd)
+	return cmd
+}
+
+func GetCmdQueryTraceCall(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace-call [from] [to] [payload]",
+		Short: "Trace the vm execution of a contract call, don't create a transaction",
+		Long: strings.TrimSpace(fmt.Sprintf(`Execute a contract call on the current state and print the opcode level trace
+(--tracer=struct) or the tree of nested calls (--tracer=call).
+Example:
+$ %s query vm trace-call nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 70a08231 --amount=0pnch`, version.ClientName)),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			fromAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			toAddr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			payload, err := hex.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
+
+			amount, err := sdk.ParseCoin(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+
+			params := traceParamsFromFlags()
+			params.Msg = types.NewMsgContract(fromAddr, toAddr, payload, amount)
+
+			res, err := vmutils.QueryTrace(cliCtx, params)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+
+	addTraceFlags(cmd)
+	cmd.Flags().String(flagAmount, "0pnch", "amount of coins to send (e.g. --amount=100pnch)")
+	return cmd
+}
+
+func GetCmdQuerySimulate(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "simulate [params_file]",
+		Short: "Simulate a sequence of contract calls with state overrides, don't create a transaction",
+		Long: strings.TrimSpace(fmt.Sprintf(`Execute the msgs of the params file one after another on the current state with the
+balances, nonces, code and storage slots of the overrides applied, and print the result of each
+msg and the resulting state diff. Nothing is committed.
+Example:
+$ %s query vm simulate ./simulate.json [--height=<height>]
+
+Where simulate.json contains:
+{
+  "msgs": [
+    {
+      "from": "nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe",
+      "to": "nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5",
+      "payload": "0x70a08231000000000000000000000000da44b8335905077af44afe5120c49c0c4077f500",
+      "amount": {"denom": "pnch", "amount": "0"}
+    }
+  ],
+  "overrides": [
+    {
+      "address": "nch1mfztsv6eq5rhtaz2l6jjp3yup3q80agsqra9qe",
+      "balance": "1000000000000",
+      "storage": [
+        {
+          "key": "0000000000000000000000000000000000000000000000000000000000000001",
+          "value": "0000000000000000000000000000000000000000000000000000000000000002"
+        }
+      ]
+    }
+  ]
+}`, version.ClientName)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var params types.QuerySimulateParams
+			if err := cdc.UnmarshalJSON(bz, &params); err != nil {
+				return err
+			}
+
+			res, err := vmutils.QuerySimulate(cliCtx, params)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(res)
+		},
+	}
+}
+
+func addTraceFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagTracer, types.TracerStruct, fmt.Sprintf("tracer to use, %s or %s", types.TracerStruct, types.TracerCall))
+	cmd.Flags().Bool(flagDisableMemory, false, "don't capture memory with the struct tracer")
+	cmd.Flags().Bool(flagDisableStack, false, "don't capture stack with the struct tracer")
+	cmd.Flags().Bool(flagDisableStorage, false, "don't capture storage with the struct tracer")
+	cmd.Flags().Int(flagLimit, 0, "maximum number of steps captured by the struct tracer, 0 means unlimited")
+}
+
+func traceParamsFromFlags() types.QueryTraceParams {
+	params := types.NewQueryTraceParams(types.MsgContract{}, viper.GetString(flagTracer))
+	params.DisableMemory = viper.GetBool(flagDisableMemory)
+	params.DisableStack = viper.GetBool(flagDisableStack)
+	params.DisableStorage = viper.GetBool(flagDisableStorage)
+	params.Limit = viper.GetInt(flagLimit)
+	return params
+}
+
+func printJSON(bz []byte) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, bz, "", "  "); err != nil {
+		return err
+	}
+
+	fmt.Println(out.String())
+	return nil
+}
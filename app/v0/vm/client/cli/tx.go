@@ -3,12 +3,14 @@ package cli
 import (
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
 	"github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/common"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/context"
@@ -24,6 +26,7 @@ func VMCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(
 		ContractCreateCmd(cdc),
 		ContractCallCmd(cdc),
+		SetContractMetadataCmd(cdc),
 	)
 	return txCmd
 }
@@ -107,22 +110,26 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 				coin = coinInput
 			}
 
-			abiFile := viper.GetString(flagAbiFile)
-			method := viper.GetString(flagMethod)
-			argList := viper.GetStringSlice(flagArgs)
-			payload, _, err := GenPayload(abiFile, method, argList)
+			contractAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagContractAddr))
 			if err != nil {
 				return err
 			}
 
-			dump := make([]byte, len(payload)*2)
-			hex.Encode(dump, payload)
+			abiObj, err := ContractABI(cliCtx, viper.GetString(flagAbiFile), contractAddr)
+			if err != nil {
+				return err
+			}
 
-			contractAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagContractAddr))
+			method := viper.GetString(flagMethod)
+			argList := viper.GetStringSlice(flagArgs)
+			payload, _, err := GenPayloadFromABI(abiObj, method, argList)
 			if err != nil {
 				return err
 			}
 
+			dump := make([]byte, len(payload)*2)
+			hex.Encode(dump, payload)
+
 			msg := types.NewMsgContract(cliCtx.GetFromAddress(), contractAddr, payload, coin)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -136,13 +143,91 @@ func ContractCallCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagAmount, "0pnch", "amount of coins to send (e.g. 1000000pnch)")
 	cmd.Flags().String(flagMethod, "", "contract method")
 	cmd.Flags().String(flagArgs, "", "contract method arg list")
-	cmd.Flags().String(flagAbiFile, "", "contract abi file path")
+	cmd.Flags().String(flagAbiFile, "", "contract abi file path, the abi registered in the contract metadata is used when omitted")
 
 	cmd.MarkFlagRequired(flagContractAddr)
 	cmd.MarkFlagRequired(flagMethod)
-	cmd.MarkFlagRequired(flagAbiFile)
 
 	cmd = client.PostCommands(cmd)[0]
 
 	return cmd
 }
+
+func SetContractMetadataCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-metadata [contract] [abi_file]",
+		Short: "Create and sign a tx registering the metadata of a contract deployed by the sender",
+		Long: `Register the name, abi, compiler version and source hash of a contract deployed by the sender,
+so that the contract can be called without an abi file and verified against its source. The sender
+proves it deployed the contract with the nonce the contract was created with, it is looked up from
+the account of the sender when --nonce is omitted.`,
+		Example: "nchcli vm set-metadata nch1rk47h83x4nz4745d63dtnpl8uwsramfgz8snr5 ./demo.abi --from=<user key name> --name=Demo --compiler_version=0.6.12+commit.27d51765 --source_file=./Demo.sol",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			contract, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			abiJSON, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			var sourceHash sdk.Hash
+			if sourceFile := viper.GetString(flagSourceFile); len(sourceFile) != 0 {
+				sourceHash, err = SourceHashFromFile(sourceFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			from := cliCtx.GetFromAddress()
+			nonce := viper.GetUint64(flagNonce)
+			if !cmd.Flags().Changed(flagNonce) {
+				nonce, err = creationNonce(cliCtx, from, contract)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSetContractMetadata(from, contract, nonce, viper.GetString(flagName),
+				string(abiJSON), viper.GetString(flagCompilerVersion), sourceHash)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagName, "", "contract name")
+	cmd.Flags().String(flagCompilerVersion, "", "version of the compiler the contract was compiled with")
+	cmd.Flags().String(flagSourceFile, "", "source file whose keccak256 hash is registered")
+	cmd.Flags().Uint64(flagNonce, 0, "nonce of the sender the contract was created with")
+
+	cmd.MarkFlagRequired(flagName)
+
+	cmd = client.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// creationNonce returns the nonce of the deployer the contract was created with, looked up among
+// the nonces used by the account so far
+func creationNonce(cliCtx context.CLIContext, deployer, contract sdk.AccAddress) (uint64, error) {
+	_, sequence, err := auth.NewAccountRetriever(cliCtx).GetAccountNumberSequence(deployer)
+	if err != nil {
+		return 0, err
+	}
+
+	for nonce := uint64(0); nonce <= sequence; nonce++ {
+		if common.CreateAddress(deployer, nonce).Equals(contract) {
+			return nonce, nil
+		}
+	}
+	return 0, fmt.Errorf("contract %s is not created by %s, use --nonce to set the nonce it was created with", contract, deployer)
+}
@@ -3,18 +3,23 @@ package cli
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
+	vmutils "github.com/netcloth/netcloth-chain/app/v0/vm/client/utils"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/common/math"
+	"github.com/netcloth/netcloth-chain/client/context"
 	"github.com/netcloth/netcloth-chain/hexutil"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
@@ -69,12 +74,17 @@ func AbiFromFile(abiFile string) (abiObj abi.ABI, err error) {
 func GenPayload(abiFile, method string, args []string) (payload []byte, m abi.Method, err error) {
 	//fmt.Fprintf(os.Stderr, fmt.Sprintf("abiFile = %s, method = %s, args = %v, len=%d\n", abiFile, method, args, len(args)))
 
-	emptyMethod := abi.Method{}
 	abiObj, err := AbiFromFile(abiFile)
 	if err != nil {
-		return nil, emptyMethod, err
+		return nil, abi.Method{}, err
 	}
 
+	return GenPayloadFromABI(abiObj, method, args)
+}
+
+// GenPayloadFromABI packs the args of the method, or of the constructor when the method is empty
+func GenPayloadFromABI(abiObj abi.ABI, method string, args []string) (payload []byte, m abi.Method, err error) {
+	emptyMethod := abi.Method{}
 	if len(method) == 0 { //constructor
 		m = abiObj.Constructor
 	} else if v, ok := abiObj.Methods[method]; ok {
@@ -235,3 +245,147 @@ func GenPayload(abiFile, method string, args []string) (payload []byte, m abi.Me
 
 	return payload, m, err
 }
+
+// ContractABI returns the abi of the abi file, or the abi registered in the metadata of the contract
+// when no abi file is given
+func ContractABI(cliCtx context.CLIContext, abiFile string, contract sdk.AccAddress) (abi.ABI, error) {
+	if len(abiFile) != 0 {
+		return AbiFromFile(abiFile)
+	}
+
+	metadata, err := vmutils.QueryContractMetadata(cliCtx, contract)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("no abi_file given and no metadata found for contract %s: %v", contract, err)
+	}
+	return metadata.ParseABI()
+}
+
+// Artifact is the compiler output of a contract
+type Artifact struct {
+	ABI              string
+	DeployedBytecode []byte
+}
+
+// ArtifactFromFile reads the abi and the deployed bytecode of a contract from a json artifact file,
+// as written by truffle or hardhat ("abi", "deployedBytecode"), by solc --combined-json for a single
+// contract ("abi", "bin-runtime") or by solc --standard-json ("abi", "evm.deployedBytecode.object")
+func ArtifactFromFile(artifactFile string) (artifact Artifact, err error) {
+	bz, err := ioutil.ReadFile(artifactFile)
+	if err != nil {
+		return
+	}
+
+	var fields struct {
+		ABI              json.RawMessage `json:"abi"`
+		DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+		BinRuntime       string          `json:"bin-runtime"`
+		EVM              struct {
+			DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+		} `json:"evm"`
+	}
+	if err = json.Unmarshal(bz, &fields); err != nil {
+		return
+	}
+
+	// solc --combined-json writes the abi as a json string
+	var abiString string
+	if err := json.Unmarshal(fields.ABI, &abiString); err == nil {
+		artifact.ABI = abiString
+	} else {
+		artifact.ABI = string(fields.ABI)
+	}
+
+	var code string
+	switch {
+	case len(fields.DeployedBytecode) != 0:
+		code, err = bytecodeField(fields.DeployedBytecode)
+	case len(fields.EVM.DeployedBytecode) != 0:
+		code, err = bytecodeField(fields.EVM.DeployedBytecode)
+	default:
+		code = fields.BinRuntime
+	}
+	if err != nil {
+		return
+	}
+
+	code = strings.TrimPrefix(strings.TrimSpace(code), "0x")
+	if len(code) == 0 {
+		return artifact, errors.New("no deployed bytecode in artifact")
+	}
+	artifact.DeployedBytecode, err = hex.DecodeString(code)
+	return
+}
+
+// bytecodeField returns the hex bytecode of a field holding either the bytecode or an object with
+// the bytecode in its "object" field
+func bytecodeField(raw json.RawMessage) (string, error) {
+	var code string
+	if err := json.Unmarshal(raw, &code); err == nil {
+		return code, nil
+	}
+
+	var obj struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return "", err
+	}
+	return obj.Object, nil
+}
+
+// StripCodeMetadata removes the cbor encoded metadata appended by solc to the deployed bytecode,
+// its length is stored in the last 2 bytes of the code
+func StripCodeMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - n
+	// the metadata is a cbor map
+	if n == 0 || start < 0 || code[start]&0xe0 != 0xa0 {
+		return code
+	}
+	return code[:start]
+}
+
+// SourceHashFromFile returns the keccak256 hash of the content of the source file
+func SourceHashFromFile(sourceFile string) (sdk.Hash, error) {
+	bz, err := ioutil.ReadFile(sourceFile)
+	if err != nil {
+		return sdk.Hash{}, err
+	}
+	return sdk.BytesToHash(ethcrypto.Keccak256(bz)), nil
+}
+
+// EqualABIs reports whether two json abis hold the same entries, regardless of their order and
+// formatting
+func EqualABIs(a, b string) (bool, error) {
+	ca, err := canonicalABI(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := canonicalABI(b)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(ca, cb), nil
+}
+
+func canonicalABI(s string) ([]string, error) {
+	var entries []interface{}
+	if err := json.Unmarshal([]byte(s), &entries); err != nil {
+		return nil, err
+	}
+
+	canonical := make([]string, len(entries))
+	for i, entry := range entries {
+		bz, err := json.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		canonical[i] = string(bz)
+	}
+	sort.Strings(canonical)
+	return canonical, nil
+}
@@ -33,6 +33,31 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		getLogFn(cliCtx),
 	).Methods("GET")
 
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s/{txId}", types.QueryReceipt),
+		getReceiptFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s", types.QueryFilterLogs),
+		filterLogsFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s/{txId}", types.QueryStateDiff),
+		getStateDiffFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s/{addr}", types.QueryContractMetadata),
+		getContractMetadataFn(cliCtx),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/vm/%s", types.QuerySimulate),
+		simulateFn(cliCtx),
+	).Methods("POST")
+
 	// Get the current staking parameter values
 	r.HandleFunc(
 		"/vm/parameters",
@@ -141,6 +166,146 @@ func getLog(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 }
 
+func getReceipt(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		txID := vars["txId"]
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s/%s", types.QueryReceipt, txID)
+		// the index of the msg among the vm msgs of the tx
+		if index := r.URL.Query().Get("index"); index != "" {
+			route = fmt.Sprintf("%s/%s", route, index)
+		}
+		res, height, err := cliCtx.Query(route)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func getStateDiff(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		txID := vars["txId"]
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s/%s", types.QueryStateDiff, txID)
+		res, height, err := cliCtx.Query(route)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func getContractMetadata(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		addr := vars["addr"]
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s/%s", types.QueryContractMetadata, addr)
+		res, height, err := cliCtx.Query(route)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func filterLogs(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params types.QueryFilterLogsParams
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &params) {
+			return
+		}
+
+		if err := params.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		d, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s", types.QueryFilterLogs)
+		res, height, err := cliCtx.QueryWithData(route, d)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func simulate(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params types.QuerySimulateParams
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &params) {
+			return
+		}
+
+		if err := params.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		d, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/vm/%s", types.QuerySimulate)
+		res, height, err := cliCtx.QueryWithData(route, d)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func getParams(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
@@ -174,6 +339,26 @@ func getLogFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return getLog(cliCtx)
 }
 
+func getReceiptFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return getReceipt(cliCtx)
+}
+
+func filterLogsFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return filterLogs(cliCtx)
+}
+
+func getStateDiffFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return getStateDiff(cliCtx)
+}
+
+func getContractMetadataFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return getContractMetadata(cliCtx)
+}
+
+func simulateFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return simulate(cliCtx)
+}
+
 // HTTP request handler to query the staking params values
 func paramsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return getParams(cliCtx)
@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// SubscriptionLogs is the kind of the websocket subscription to the vm logs of new blocks
+const SubscriptionLogs = "logs"
+
+// LogsSubscription creates a subscription to the vm logs of each new block matching the addresses
+// and topics of the filter, the block range of the filter is ignored
+func LogsSubscription(cliCtx context.CLIContext, params json.RawMessage) (func(header tmtypes.Header) ([]interface{}, error), error) {
+	var filter types.QueryFilterLogsParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &filter); err != nil {
+			return nil, err
+		}
+	}
+	if err := filter.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return func(header tmtypes.Header) ([]interface{}, error) {
+		filter.FromBlock, filter.ToBlock = uint64(header.Height), uint64(header.Height)
+		bz, err := cliCtx.Codec.MarshalJSON(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		res, _, err := cliCtx.WithHeight(header.Height).QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFilterLogs), bz)
+		if err != nil {
+			return nil, err
+		}
+
+		var out types.QueryLogsResult
+		if err := cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+			return nil, err
+		}
+
+		blockHash := sdk.BytesToHash(header.Hash())
+		logs := make([]interface{}, len(out.Logs))
+		for i, log := range out.Logs {
+			log.BlockHash = blockHash
+			logs[i] = log
+		}
+		return logs, nil
+	}, nil
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	vmutils "github.com/netcloth/netcloth-chain/app/v0/vm/client/utils"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+// CallTracer is the name of the call tracer in the debug_traceTransaction tracer option
+const CallTracer = "callTracer"
+
+// TraceConfig holds the options of debug_traceTransaction
+type TraceConfig struct {
+	Tracer         *string `json:"tracer"`
+	DisableMemory  bool    `json:"disableMemory"`
+	DisableStack   bool    `json:"disableStack"`
+	DisableStorage bool    `json:"disableStorage"`
+	Limit          int     `json:"limit"`
+}
+
+// PublicDebugAPI is the debug_ prefixed set of APIs of the JSON-RPC endpoint.
+type PublicDebugAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicDebugAPI creates an instance of the public Debug API.
+func NewPublicDebugAPI(cliCtx context.CLIContext) *PublicDebugAPI {
+	return &PublicDebugAPI{cliCtx: cliCtx}
+}
+
+// TraceTransaction re-executes the transaction identified by hash on the state it was executed on
+// and returns the opcode level trace, or the tree of nested calls with the callTracer.
+func (d *PublicDebugAPI) TraceTransaction(hash common.Hash, config *TraceConfig) (json.RawMessage, error) {
+	params := types.NewQueryTraceParams(types.MsgContract{}, types.TracerStruct)
+	if config != nil {
+		if config.Tracer != nil {
+			switch *config.Tracer {
+			case CallTracer:
+				params.Tracer = types.TracerCall
+			default:
+				return nil, fmt.Errorf("unsupported tracer: %s", *config.Tracer)
+			}
+		}
+		params.DisableMemory = config.DisableMemory
+		params.DisableStack = config.DisableStack
+		params.DisableStorage = config.DisableStorage
+		params.Limit = config.Limit
+	}
+
+	res, err := vmutils.QueryTxTrace(d.cliCtx, hash.Bytes(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(res), nil
+}
@@ -0,0 +1,602 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	authutils "github.com/netcloth/netcloth-chain/app/v0/auth/client/utils"
+	authtypes "github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// PublicEthAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
+type PublicEthAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicEthAPI creates an instance of the public Eth Web3 API.
+func NewPublicEthAPI(cliCtx context.CLIContext) *PublicEthAPI {
+	return &PublicEthAPI{cliCtx: cliCtx}
+}
+
+// ChainId returns the chain's identifier in hex format
+// nolint
+func (e *PublicEthAPI) ChainId() (*hexutil.Big, error) {
+	chainID, err := chainIDFromCtx(e.cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*hexutil.Big)(chainID), nil
+}
+
+// Syncing returns whether or not the current node is syncing with other peers. Returns false if not, or a struct
+// outlining the state of the sync if it is.
+func (e *PublicEthAPI) Syncing() (interface{}, error) {
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return false, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return false, err
+	}
+
+	if !status.SyncInfo.CatchingUp {
+		return false, nil
+	}
+
+	return map[string]interface{}{
+		"startingBlock": hexutil.Uint64(0),
+		"currentBlock":  hexutil.Uint64(status.SyncInfo.LatestBlockHeight),
+		"highestBlock":  nil, // NA
+	}, nil
+}
+
+// GasPrice returns the gas price threshold of the chain.
+func (e *PublicEthAPI) GasPrice() (*hexutil.Big, error) {
+	res, _, err := e.cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", authtypes.QuerierRoute, authtypes.QueryParams), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var params auth.Params
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+		return nil, err
+	}
+
+	return (*hexutil.Big)(new(big.Int).SetUint64(params.GasPriceThreshold)), nil
+}
+
+// Accounts returns the list of accounts available to this node, keys are never held by the rpc server.
+func (e *PublicEthAPI) Accounts() []common.Address {
+	return []common.Address{}
+}
+
+// BlockNumber returns the current block number.
+func (e *PublicEthAPI) BlockNumber() (hexutil.Uint64, error) {
+	height, err := e.latestHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint64(height), nil
+}
+
+// GetBalance returns the provided account's balance up to the provided block number.
+func (e *PublicEthAPI) GetBalance(address common.Address, blockNum rpc.BlockNumber) (*hexutil.Big, error) {
+	acc, err := auth.NewAccountRetriever(e.queryCtx(blockNum)).GetAccount(ToAccAddress(address))
+	if err != nil {
+		return (*hexutil.Big)(new(big.Int)), nil
+	}
+
+	return (*hexutil.Big)(acc.GetCoins().AmountOf(sdk.NativeTokenName).BigInt()), nil
+}
+
+// GetTransactionCount returns the number of transactions at the given address up to the given block number.
+func (e *PublicEthAPI) GetTransactionCount(address common.Address, blockNum rpc.BlockNumber) (*hexutil.Uint64, error) {
+	var nonce hexutil.Uint64
+	acc, err := auth.NewAccountRetriever(e.queryCtx(blockNum)).GetAccount(ToAccAddress(address))
+	if err != nil {
+		return &nonce, nil
+	}
+
+	nonce = hexutil.Uint64(acc.GetSequence())
+	return &nonce, nil
+}
+
+// GetCode returns the contract code at the given address and block number.
+func (e *PublicEthAPI) GetCode(address common.Address, blockNum rpc.BlockNumber) (hexutil.Bytes, error) {
+	route := fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryCode, ToAccAddress(address))
+	res, _, err := e.queryCtx(blockNum).Query(route)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetStorageAt returns the contract storage at the given address, block number, and key.
+func (e *PublicEthAPI) GetStorageAt(address common.Address, key string, blockNum rpc.BlockNumber) (hexutil.Bytes, error) {
+	slot, err := decodeStorageKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("custom/%s/%s/%s/%s", types.QuerierRoute, types.QueryStorage, ToAccAddress(address), hex.EncodeToString(slot.Bytes()))
+	res, _, err := e.queryCtx(blockNum).Query(route)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryStorageResult
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Value.Bytes(), nil
+}
+
+// Call performs a raw contract call.
+func (e *PublicEthAPI) Call(args CallArgs, blockNum rpc.BlockNumber) (hexutil.Bytes, error) {
+	out, err := e.simulate(types.QueryCall, args, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(out.Res)
+}
+
+// EstimateGas returns an estimate of gas usage for the given smart contract call, against the
+// latest state unless a block number is given.
+func (e *PublicEthAPI) EstimateGas(args CallArgs, blockNum *rpc.BlockNumber) (hexutil.Uint64, error) {
+	num := rpc.LatestBlockNumber
+	if blockNum != nil {
+		num = *blockNum
+	}
+
+	out, err := e.simulate(types.EstimateGas, args, num)
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint64(out.Gas), nil
+}
+
+// GetBlockByNumber returns the block identified by number.
+func (e *PublicEthAPI) GetBlockByNumber(blockNum rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	height, err := e.resolveHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := node.Block(&height)
+	if err != nil {
+		return nil, nil
+	}
+
+	return e.formatBlock(resBlock, fullTx)
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions in the block identified by number.
+func (e *PublicEthAPI) GetBlockTransactionCountByNumber(blockNum rpc.BlockNumber) (*hexutil.Uint, error) {
+	height, err := e.resolveHeight(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := node.Block(&height)
+	if err != nil {
+		return nil, nil
+	}
+
+	n := hexutil.Uint(len(resBlock.Block.Txs))
+	return &n, nil
+}
+
+// SendRawTransaction broadcasts an RLP encoded, EIP-155 signed transaction and returns its hash.
+func (e *PublicEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	var tx types.MsgEthereumTx
+	if err := rlp.DecodeBytes(data, &tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := tx.ValidateBasic(); err != nil {
+		return common.Hash{}, err
+	}
+
+	res, err := e.cliCtx.BroadcastTxSync(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if res.Code != abci.CodeTypeOK {
+		return common.Hash{}, errors.New(res.RawLog)
+	}
+
+	return common.HexToHash(res.TxHash), nil
+}
+
+// GetTransactionByHash returns the transaction identified by hash.
+func (e *PublicEthAPI) GetTransactionByHash(hash common.Hash) (*RPCTransaction, error) {
+	resTx, err := e.queryTx(hash)
+	if err != nil {
+		return nil, nil
+	}
+
+	tx, err := e.decodeTx(resTx.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash, err := e.blockHash(resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRPCTransaction(tx, hash, blockHash, uint64(resTx.Height), uint64(resTx.Index)), nil
+}
+
+// GetTransactionReceipt returns the transaction receipt identified by hash.
+func (e *PublicEthAPI) GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
+	resTx, err := e.queryTx(hash)
+	if err != nil {
+		return nil, nil
+	}
+
+	tx, err := e.decodeTx(resTx.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := node.Block(&resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+	blockHash := common.BytesToHash(resBlock.BlockMeta.BlockID.Hash)
+
+	cumulativeGasUsed := uint64(resTx.TxResult.GasUsed)
+	if resBlockResults, err := node.BlockResults(&resTx.Height); err == nil {
+		cumulativeGasUsed = 0
+		for i := 0; i <= int(resTx.Index) && i < len(resBlockResults.Results.DeliverTx); i++ {
+			cumulativeGasUsed += uint64(resBlockResults.Results.DeliverTx[i].GasUsed)
+		}
+	}
+
+	logs, err := e.txLogs(hash, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := hexutil.Uint(ethtypes.ReceiptStatusSuccessful)
+	if resTx.TxResult.Code != abci.CodeTypeOK {
+		status = hexutil.Uint(ethtypes.ReceiptStatusFailed)
+	}
+
+	rpcTx := NewRPCTransaction(tx, hash, blockHash, uint64(resTx.Height), uint64(resTx.Index))
+	fields := map[string]interface{}{
+		"blockHash":         blockHash,
+		"blockNumber":       hexutil.Uint64(resTx.Height),
+		"transactionHash":   hash,
+		"transactionIndex":  hexutil.Uint64(resTx.Index),
+		"from":              rpcTx.From,
+		"to":                rpcTx.To,
+		"gasUsed":           hexutil.Uint64(resTx.TxResult.GasUsed),
+		"cumulativeGasUsed": hexutil.Uint64(cumulativeGasUsed),
+		"contractAddress":   nil,
+		"logs":              logs,
+		"logsBloom":         ethtypes.BytesToBloom(ethtypes.LogsBloom(logs).Bytes()),
+		"status":            status,
+	}
+
+	if contractAddr := contractAddressFromEvents(resTx.TxResult.Events); contractAddr != nil {
+		fields["contractAddress"] = ToEthAddress(contractAddr)
+	}
+
+	return fields, nil
+}
+
+// GetLogs returns logs matching the given argument that are stored within the state, the
+// blocks are filtered by the node with their logs bloom.
+func (e *PublicEthAPI) GetLogs(criteria FilterCriteria) ([]*ethtypes.Log, error) {
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	if criteria.BlockHash != nil {
+		return nil, errors.New("filtering logs by block hash is not supported, use fromBlock and toBlock")
+	}
+
+	from, err := e.resolveHeight(criteria.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := e.resolveHeight(criteria.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: fromBlock %d is greater than toBlock %d", from, to)
+	}
+
+	addresses := make([]sdk.AccAddress, len(criteria.Addresses))
+	for i, addr := range criteria.Addresses {
+		addresses[i] = ToAccAddress(addr)
+	}
+	topics := make([][]sdk.Hash, len(criteria.Topics))
+	for i, sub := range criteria.Topics {
+		for _, topic := range sub {
+			topics[i] = append(topics[i], sdk.Hash(topic))
+		}
+	}
+
+	data, err := e.cliCtx.Codec.MarshalJSON(types.NewQueryFilterLogsParams(uint64(from), uint64(to), addresses, topics))
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := e.cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryFilterLogs), data)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryLogsResult
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	logs := []*ethtypes.Log{}
+	blockHashes := make(map[uint64]common.Hash)
+	for _, log := range out.Logs {
+		blockHash, ok := blockHashes[log.BlockNumber]
+		if !ok {
+			height := int64(log.BlockNumber)
+			resBlock, err := node.Block(&height)
+			if err != nil {
+				return nil, err
+			}
+			blockHash = common.BytesToHash(resBlock.BlockMeta.BlockID.Hash)
+			blockHashes[log.BlockNumber] = blockHash
+		}
+		logs = append(logs, ToEthLogs([]*types.Log{log}, blockHash)...)
+	}
+
+	return logs, nil
+}
+
+func (e *PublicEthAPI) simulate(path string, args CallArgs, blockNum rpc.BlockNumber) (out types.SimulationResult, err error) {
+	data, err := e.cliCtx.Codec.MarshalJSON(args.ToMsgContractQuery())
+	if err != nil {
+		return
+	}
+
+	res, _, err := e.queryCtx(blockNum).QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, path), data)
+	if err != nil {
+		return
+	}
+
+	err = e.cliCtx.Codec.UnmarshalJSON(res, &out)
+	return
+}
+
+func (e *PublicEthAPI) txLogs(hash, blockHash common.Hash) ([]*ethtypes.Log, error) {
+	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryTxLogs, hex.EncodeToString(hash.Bytes())))
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryLogsResult
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return ToEthLogs(out.Logs, blockHash), nil
+}
+
+// blockBloom returns the logs bloom of the block at the given height, an empty bloom when it can
+// not be queried
+func (e *PublicEthAPI) blockBloom(height int64) ethtypes.Bloom {
+	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%d", types.QuerierRoute, types.QueryBlockBloom, height))
+	if err != nil {
+		return ethtypes.Bloom{}
+	}
+	return ethtypes.BytesToBloom(res)
+}
+
+func (e *PublicEthAPI) formatBlock(resBlock *ctypes.ResultBlock, fullTx bool) (map[string]interface{}, error) {
+	block := resBlock.Block
+	blockHash := common.BytesToHash(resBlock.BlockMeta.BlockID.Hash)
+
+	var gasUsed uint64
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+	if resBlockResults, err := node.BlockResults(&block.Height); err == nil {
+		for _, deliverTx := range resBlockResults.Results.DeliverTx {
+			gasUsed += uint64(deliverTx.GasUsed)
+		}
+	}
+
+	transactions := make([]interface{}, 0, len(block.Txs))
+	for i, txBytes := range block.Txs {
+		txHash := common.BytesToHash(txBytes.Hash())
+		if !fullTx {
+			transactions = append(transactions, txHash)
+			continue
+		}
+
+		tx, err := e.decodeTx(txBytes)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, NewRPCTransaction(tx, txHash, blockHash, uint64(block.Height), uint64(i)))
+	}
+
+	return map[string]interface{}{
+		"number":           hexutil.Uint64(block.Height),
+		"hash":             blockHash,
+		"parentHash":       common.BytesToHash(block.LastBlockID.Hash),
+		"nonce":            ethtypes.BlockNonce{},
+		"sha3Uncles":       ethtypes.EmptyUncleHash,
+		"logsBloom":        e.blockBloom(block.Height),
+		"transactionsRoot": common.BytesToHash(block.DataHash),
+		"stateRoot":        common.BytesToHash(block.AppHash),
+		"miner":            common.BytesToAddress(block.ProposerAddress),
+		"difficulty":       (*hexutil.Big)(new(big.Int)),
+		"totalDifficulty":  (*hexutil.Big)(new(big.Int)),
+		"extraData":        hexutil.Bytes{},
+		"size":             hexutil.Uint64(block.Size()),
+		"gasLimit":         hexutil.Uint64(0), // NA, block gas is bounded by the consensus params
+		"gasUsed":          hexutil.Uint64(gasUsed),
+		"timestamp":        hexutil.Uint64(block.Time.Unix()),
+		"transactions":     transactions,
+		"uncles":           []common.Hash{},
+	}, nil
+}
+
+func (e *PublicEthAPI) queryTx(hash common.Hash) (*ctypes.ResultTx, error) {
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resTx, err := node.Tx(hash.Bytes(), !e.cliCtx.TrustNode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authutils.ValidateTxResult(e.cliCtx, resTx); err != nil {
+		return nil, err
+	}
+
+	return resTx, nil
+}
+
+func (e *PublicEthAPI) decodeTx(txBytes []byte) (sdk.Tx, error) {
+	return types.NewTxDecoder(e.cliCtx.Codec)(txBytes)
+}
+
+func (e *PublicEthAPI) blockHash(height int64) (common.Hash, error) {
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	resBlock, err := node.Block(&height)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(resBlock.BlockMeta.BlockID.Hash), nil
+}
+
+func (e *PublicEthAPI) latestHeight() (int64, error) {
+	node, err := e.cliCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// resolveHeight converts a block number into a concrete block height
+func (e *PublicEthAPI) resolveHeight(blockNum rpc.BlockNumber) (int64, error) {
+	switch blockNum {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return e.latestHeight()
+	case rpc.EarliestBlockNumber:
+		return 1, nil
+	default:
+		return blockNum.Int64(), nil
+	}
+}
+
+// queryCtx returns a context querying the state at the given block number, 0 means the latest state
+func (e *PublicEthAPI) queryCtx(blockNum rpc.BlockNumber) context.CLIContext {
+	switch blockNum {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return e.cliCtx.WithHeight(0)
+	case rpc.EarliestBlockNumber:
+		return e.cliCtx.WithHeight(1)
+	default:
+		return e.cliCtx.WithHeight(blockNum.Int64())
+	}
+}
+
+func contractAddressFromEvents(events []abci.Event) sdk.AccAddress {
+	for _, event := range events {
+		if event.Type != types.EventTypeNewContract {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != types.AttributeKeyAddress {
+				continue
+			}
+
+			addr, err := sdk.AccAddressFromBech32(string(attr.Value))
+			if err == nil {
+				return addr
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeStorageKey(key string) (sdk.Hash, error) {
+	key = strings.TrimPrefix(key, "0x")
+	if len(key)%2 == 1 {
+		key = "0" + key
+	}
+
+	b, err := hex.DecodeString(key)
+	if err != nil {
+		return sdk.Hash{}, err
+	}
+
+	if len(b) > sdk.HashLength {
+		return sdk.Hash{}, fmt.Errorf("storage key too long: %d bytes", len(b))
+	}
+
+	return sdk.BytesToHash(b), nil
+}
@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// FilterCriteria represents a request to create a new filter, it has the same shape as
+// the eth_getLogs/eth_newFilter parameter object of the Ethereum JSON-RPC spec.
+type FilterCriteria struct {
+	BlockHash *common.Hash
+	FromBlock rpc.BlockNumber
+	ToBlock   rpc.BlockNumber
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// UnmarshalJSON sets *args fields with given data, address may be a single address or an
+// array of addresses and each topic position may be null, a single topic or an array of topics.
+func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
+	type input struct {
+		BlockHash *common.Hash     `json:"blockHash"`
+		FromBlock *rpc.BlockNumber `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber `json:"toBlock"`
+		Addresses interface{}      `json:"address"`
+		Topics    []interface{}    `json:"topics"`
+	}
+
+	var raw input
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	args.FromBlock, args.ToBlock = rpc.LatestBlockNumber, rpc.LatestBlockNumber
+	if raw.BlockHash != nil {
+		if raw.FromBlock != nil || raw.ToBlock != nil {
+			return errors.New("cannot specify both BlockHash and FromBlock/ToBlock, choose one or the other")
+		}
+		args.BlockHash = raw.BlockHash
+	} else {
+		if raw.FromBlock != nil {
+			args.FromBlock = *raw.FromBlock
+		}
+		if raw.ToBlock != nil {
+			args.ToBlock = *raw.ToBlock
+		}
+	}
+
+	switch addr := raw.Addresses.(type) {
+	case nil:
+	case []interface{}:
+		for i, a := range addr {
+			s, ok := a.(string)
+			if !ok {
+				return fmt.Errorf("non-string address at index %d", i)
+			}
+			address, err := decodeAddress(s)
+			if err != nil {
+				return fmt.Errorf("invalid address at index %d: %v", i, err)
+			}
+			args.Addresses = append(args.Addresses, address)
+		}
+	case string:
+		address, err := decodeAddress(addr)
+		if err != nil {
+			return fmt.Errorf("invalid address: %v", err)
+		}
+		args.Addresses = []common.Address{address}
+	default:
+		return errors.New("invalid addresses in query")
+	}
+
+	args.Topics = make([][]common.Hash, len(raw.Topics))
+	for i, t := range raw.Topics {
+		switch topic := t.(type) {
+		case nil:
+			// ignore topic when matching logs
+		case string:
+			top, err := decodeTopic(topic)
+			if err != nil {
+				return err
+			}
+			args.Topics[i] = []common.Hash{top}
+		case []interface{}:
+			// or case e.g. [null, "topic0", "topic1"]
+			for _, rawTopic := range topic {
+				if rawTopic == nil {
+					// null component, match all
+					args.Topics[i] = nil
+					break
+				}
+				s, ok := rawTopic.(string)
+				if !ok {
+					return errors.New("invalid topic(s)")
+				}
+				top, err := decodeTopic(s)
+				if err != nil {
+					return err
+				}
+				args.Topics[i] = append(args.Topics[i], top)
+			}
+		default:
+			return errors.New("invalid topic(s)")
+		}
+	}
+
+	return nil
+}
+
+func decodeAddress(s string) (common.Address, error) {
+	b, err := hexutil.Decode(s)
+	if err == nil && len(b) != common.AddressLength {
+		err = fmt.Errorf("hex has invalid length %d after decoding; expected %d for address", len(b), common.AddressLength)
+	}
+	return common.BytesToAddress(b), err
+}
+
+func decodeTopic(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err == nil && len(b) != common.HashLength {
+		err = fmt.Errorf("hex has invalid length %d after decoding; expected %d for topic", len(b), common.HashLength)
+	}
+	return common.BytesToHash(b), err
+}
+
+// FilterLogs returns the logs matching the given addresses and topics, an empty address list
+// matches any address, topics are AND-ed by position and OR-ed within a position.
+func FilterLogs(logs []*ethtypes.Log, addresses []common.Address, topics [][]common.Hash) []*ethtypes.Log {
+	var ret []*ethtypes.Log
+Logs:
+	for _, log := range logs {
+		if len(addresses) > 0 && !includes(addresses, log.Address) {
+			continue
+		}
+		// If the to filtered topics is greater than the amount of topics in logs, skip.
+		if len(topics) > len(log.Topics) {
+			continue Logs
+		}
+		for i, sub := range topics {
+			match := len(sub) == 0 // empty rule set == wildcard
+			for _, topic := range sub {
+				if log.Topics[i] == topic {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue Logs
+			}
+		}
+		ret = append(ret, log)
+	}
+	return ret
+}
+
+func includes(addresses []common.Address, a common.Address) bool {
+	for _, addr := range addresses {
+		if addr == a {
+			return true
+		}
+	}
+
+	return false
+}
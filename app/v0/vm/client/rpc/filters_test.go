@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterCriteriaUnmarshalJSON(t *testing.T) {
+	var criteria FilterCriteria
+	input := `{"fromBlock":"0x1","toBlock":"0x10","address":"0x0000000000000000000000000000000000000001","topics":[null,["0x0000000000000000000000000000000000000000000000000000000000000002","0x0000000000000000000000000000000000000000000000000000000000000003"]]}`
+	require.NoError(t, json.Unmarshal([]byte(input), &criteria))
+
+	require.Equal(t, rpc.BlockNumber(1), criteria.FromBlock)
+	require.Equal(t, rpc.BlockNumber(16), criteria.ToBlock)
+	require.Equal(t, []common.Address{common.BytesToAddress([]byte{1})}, criteria.Addresses)
+	require.Len(t, criteria.Topics, 2)
+	require.Nil(t, criteria.Topics[0])
+	require.Equal(t, []common.Hash{common.BytesToHash([]byte{2}), common.BytesToHash([]byte{3})}, criteria.Topics[1])
+
+	criteria = FilterCriteria{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &criteria))
+	require.Equal(t, rpc.LatestBlockNumber, criteria.FromBlock)
+	require.Equal(t, rpc.LatestBlockNumber, criteria.ToBlock)
+
+	require.Error(t, json.Unmarshal([]byte(`{"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001","fromBlock":"0x1"}`), &criteria))
+	require.Error(t, json.Unmarshal([]byte(`{"address":"0x01"}`), &criteria))
+}
+
+func TestFilterLogs(t *testing.T) {
+	addr1 := common.BytesToAddress([]byte{1})
+	addr2 := common.BytesToAddress([]byte{2})
+	topicA := common.BytesToHash([]byte("a"))
+	topicB := common.BytesToHash([]byte("b"))
+	topicC := common.BytesToHash([]byte("c"))
+
+	logs := []*ethtypes.Log{
+		{Address: addr1, Topics: []common.Hash{topicA}},
+		{Address: addr1, Topics: []common.Hash{topicA, topicB}},
+		{Address: addr2, Topics: []common.Hash{topicB, topicC}},
+	}
+
+	cases := []struct {
+		addresses []common.Address
+		topics    [][]common.Hash
+		expected  []*ethtypes.Log
+	}{
+		{nil, nil, logs},
+		{[]common.Address{addr1}, nil, logs[:2]},
+		{nil, [][]common.Hash{{topicA}}, logs[:2]},
+		{nil, [][]common.Hash{{topicA, topicB}}, logs},
+		{nil, [][]common.Hash{nil, {topicB}}, logs[1:2]},
+		{nil, [][]common.Hash{nil, {topicB, topicC}}, logs[1:]},
+		{[]common.Address{addr2}, [][]common.Hash{{topicA}}, nil},
+	}
+
+	for i, tc := range cases {
+		require.Equal(t, tc.expected, FilterLogs(logs, tc.addresses, tc.topics), "case %d", i)
+	}
+}
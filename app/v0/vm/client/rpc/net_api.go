@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+// PublicNetAPI is the net_ prefixed set of APIs in the Web3 JSON-RPC spec.
+type PublicNetAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicNetAPI creates an instance of the public Net Web3 API.
+func NewPublicNetAPI(cliCtx context.CLIContext) *PublicNetAPI {
+	return &PublicNetAPI{cliCtx: cliCtx}
+}
+
+// Version returns the current network id, derived from the chain id.
+func (a *PublicNetAPI) Version() (string, error) {
+	chainID, err := chainIDFromCtx(a.cliCtx)
+	if err != nil {
+		return "", err
+	}
+
+	return chainID.String(), nil
+}
+
+// Listening returns whether the connected node is listening for p2p connections.
+func (a *PublicNetAPI) Listening() (bool, error) {
+	node, err := a.cliCtx.GetNode()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := node.NetInfo()
+	if err != nil {
+		return false, err
+	}
+
+	return info.Listening, nil
+}
+
+// PeerCount returns the number of peers currently connected to the node.
+func (a *PublicNetAPI) PeerCount() (hexutil.Uint, error) {
+	node, err := a.cliCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := node.NetInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	return hexutil.Uint(info.NPeers), nil
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/mux"
+
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+// RPC namespaces and API version
+const (
+	Web3Namespace  = "web3"
+	EthNamespace   = "eth"
+	NetNamespace   = "net"
+	DebugNamespace = "debug"
+
+	apiVersion = "1.0"
+)
+
+// GetAPIs returns the list of all Ethereum JSON-RPC APIs served by the node
+func GetAPIs(cliCtx context.CLIContext) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: Web3Namespace,
+			Version:   apiVersion,
+			Service:   NewPublicWeb3API(),
+			Public:    true,
+		},
+		{
+			Namespace: EthNamespace,
+			Version:   apiVersion,
+			Service:   NewPublicEthAPI(cliCtx),
+			Public:    true,
+		},
+		{
+			Namespace: NetNamespace,
+			Version:   apiVersion,
+			Service:   NewPublicNetAPI(cliCtx),
+			Public:    true,
+		},
+		{
+			Namespace: DebugNamespace,
+			Version:   apiVersion,
+			Service:   NewPublicDebugAPI(cliCtx),
+			Public:    true,
+		},
+	}
+}
+
+// RegisterRoutes mounts the Ethereum JSON-RPC endpoint on the root path of the rest server
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	server := rpc.NewServer()
+	for _, api := range GetAPIs(cliCtx) {
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			panic(err)
+		}
+	}
+
+	r.Handle("/", server).Methods("POST")
+}
@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// CallArgs represents the arguments for a call
+type CallArgs struct {
+	From     *common.Address `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+}
+
+// ToMsgContractQuery converts the call arguments into the query msg understood by the vm querier
+func (args CallArgs) ToMsgContractQuery() types.MsgContractQuery {
+	from := make(sdk.AccAddress, common.AddressLength)
+	if args.From != nil {
+		from = ToAccAddress(*args.From)
+	}
+
+	var to sdk.AccAddress
+	if args.To != nil {
+		to = ToAccAddress(*args.To)
+	}
+
+	amount := sdk.ZeroInt()
+	if args.Value != nil {
+		amount = sdk.NewIntFromBigInt(args.Value.ToInt())
+	}
+
+	var payload []byte
+	if args.Data != nil {
+		payload = *args.Data
+	}
+
+	return types.NewMsgContractQuery(from, to, payload, sdk.NewCoin(sdk.NativeTokenName, amount))
+}
+
+// RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
+type RPCTransaction struct {
+	BlockHash        *common.Hash    `json:"blockHash"`
+	BlockNumber      *hexutil.Big    `json:"blockNumber"`
+	From             common.Address  `json:"from"`
+	Gas              hexutil.Uint64  `json:"gas"`
+	GasPrice         *hexutil.Big    `json:"gasPrice"`
+	Hash             common.Hash     `json:"hash"`
+	Input            hexutil.Bytes   `json:"input"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	To               *common.Address `json:"to"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	Value            *hexutil.Big    `json:"value"`
+}
+
+// NewRPCTransaction returns a transaction that will serialize to the RPC representation,
+// for a StdTx the contract call fields are taken from the first MsgContract of the tx if any.
+func NewRPCTransaction(tx sdk.Tx, txHash, blockHash common.Hash, blockNumber, index uint64) *RPCTransaction {
+	if ethTx, ok := tx.(types.MsgEthereumTx); ok {
+		return newRPCTransactionFromEthTx(ethTx, txHash, blockHash, blockNumber, index)
+	}
+
+	stdTx, ok := tx.(auth.StdTx)
+	if !ok {
+		return nil
+	}
+
+	return newRPCTransactionFromStdTx(stdTx, txHash, blockHash, blockNumber, index)
+}
+
+func newRPCTransactionFromEthTx(tx types.MsgEthereumTx, txHash, blockHash common.Hash, blockNumber, index uint64) *RPCTransaction {
+	result := &RPCTransaction{
+		BlockHash:        &blockHash,
+		BlockNumber:      (*hexutil.Big)(new(big.Int).SetUint64(blockNumber)),
+		Gas:              hexutil.Uint64(tx.Data.GasLimit),
+		GasPrice:         (*hexutil.Big)(tx.Data.Price),
+		Hash:             txHash,
+		Input:            hexutil.Bytes(tx.Data.Payload),
+		Nonce:            hexutil.Uint64(tx.Data.AccountNonce),
+		To:               tx.Data.Recipient,
+		TransactionIndex: (*hexutil.Uint64)(&index),
+		Value:            (*hexutil.Big)(tx.Data.Amount),
+	}
+
+	if from, err := tx.From(); err == nil {
+		result.From = ToEthAddress(from)
+	}
+
+	return result
+}
+
+func newRPCTransactionFromStdTx(tx auth.StdTx, txHash, blockHash common.Hash, blockNumber, index uint64) *RPCTransaction {
+	result := &RPCTransaction{
+		BlockHash:        &blockHash,
+		BlockNumber:      (*hexutil.Big)(new(big.Int).SetUint64(blockNumber)),
+		Gas:              hexutil.Uint64(tx.Fee.Gas),
+		GasPrice:         (*hexutil.Big)(gasPriceOf(tx)),
+		Hash:             txHash,
+		Input:            hexutil.Bytes{},
+		TransactionIndex: (*hexutil.Uint64)(&index),
+		Value:            (*hexutil.Big)(new(big.Int)),
+	}
+
+	if signers := tx.GetSigners(); len(signers) > 0 {
+		result.From = ToEthAddress(signers[0])
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if msg, ok := msg.(types.MsgContract); ok {
+			result.Input = hexutil.Bytes(msg.Payload)
+			result.Value = (*hexutil.Big)(msg.Amount.Amount.BigInt())
+			if !msg.To.Empty() {
+				to := ToEthAddress(msg.To)
+				result.To = &to
+			}
+			break
+		}
+	}
+
+	return result
+}
+
+func gasPriceOf(tx auth.StdTx) *big.Int {
+	if tx.Fee.Gas == 0 {
+		return new(big.Int)
+	}
+
+	return tx.Fee.Amount.AmountOf(sdk.NativeTokenName).QuoRaw(int64(tx.Fee.Gas)).BigInt()
+}
+
+// ToEthLogs converts vm logs into their ethereum representation
+func ToEthLogs(logs []*types.Log, blockHash common.Hash) []*ethtypes.Log {
+	ethLogs := make([]*ethtypes.Log, 0, len(logs))
+	for _, l := range logs {
+		topics := make([]common.Hash, len(l.Topics))
+		for i, topic := range l.Topics {
+			topics[i] = common.Hash(topic)
+		}
+
+		ethLogs = append(ethLogs, &ethtypes.Log{
+			Address:     ToEthAddress(l.Address),
+			Topics:      topics,
+			Data:        l.Data,
+			BlockNumber: l.BlockNumber,
+			TxHash:      common.Hash(l.TxHash),
+			TxIndex:     l.TxIndex,
+			BlockHash:   blockHash,
+			Index:       uint(l.Index),
+			Removed:     l.Removed,
+		})
+	}
+
+	return ethLogs
+}
+
+// ToEthAddress converts a 20 bytes account address into an ethereum address
+func ToEthAddress(addr sdk.AccAddress) common.Address {
+	return common.BytesToAddress(addr.Bytes())
+}
+
+// ToAccAddress converts an ethereum address into an account address
+func ToAccAddress(addr common.Address) sdk.AccAddress {
+	return sdk.AccAddress(addr.Bytes())
+}
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"math/big"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+// chainIDFromCtx returns the chain id of the connected node as a big integer,
+// derived from the chain id string the same way the ante handler checks ethereum txs.
+func chainIDFromCtx(cliCtx context.CLIContext) (*big.Int, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ChainIDFromString(status.NodeInfo.Network), nil
+}
@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/client/context"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// QueryTxTrace re-executes the first vm msg of a committed tx on the state of the previous block
+// with the given tracer attached. The txs preceding it in the same block are replayed first: the
+// fee deduction and sequence increments of their ante handler and their vm msgs. Their other msgs
+// can't be replayed, the trace is then flagged as approximate.
+func QueryTxTrace(cliCtx context.CLIContext, hash []byte, params types.QueryTraceParams) ([]byte, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resTx, err := node.Tx(hash, !cliCtx.TrustNode)
+	if err != nil {
+		return nil, err
+	}
+
+	if resTx.Height <= 1 {
+		return nil, errors.New("txs of the first block can't be traced")
+	}
+
+	resBlock, err := node.Block(&resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	resBlockResults, err := node.BlockResults(&resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := types.NewTxDecoder(cliCtx.Codec)
+	params.Preceding = nil
+	for i, txBytes := range resBlock.Block.Txs {
+		tx, err := decoder(txBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint32(i) < resTx.Index {
+			traceTx := TraceTx(tx, len(tx.GetMsgs()))
+			traceTx.Failed = i >= len(resBlockResults.Results.DeliverTx) || !resBlockResults.Results.DeliverTx[i].IsOK()
+			params.Preceding = append(params.Preceding, traceTx)
+			continue
+		}
+
+		// the first vm msg is traced
+		for j, msg := range tx.GetMsgs() {
+			if vmMsg, ok := VMMsg(msg); ok {
+				traceTx := TraceTx(tx, j)
+				params.Tx = &traceTx
+				params.Msg = vmMsg
+				break
+			}
+		}
+		if params.Tx == nil {
+			return nil, fmt.Errorf("tx %X has no vm msg", hash)
+		}
+		break
+	}
+
+	params.Height = resTx.Height
+	params.Time = resBlock.Block.Time
+
+	return QueryTrace(cliCtx.WithHeight(resTx.Height-1), params)
+}
+
+// QueryTrace queries the vm trace of the msg of the given params
+func QueryTrace(cliCtx context.CLIContext, params types.QueryTraceParams) ([]byte, error) {
+	if err := params.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	data, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryTrace), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(res) {
+		return nil, errors.New("invalid trace result")
+	}
+
+	return res, nil
+}
+
+// QuerySimulate queries the simulation of the msgs of the given params
+func QuerySimulate(cliCtx context.CLIContext, params types.QuerySimulateParams) ([]byte, error) {
+	if err := params.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	data, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QuerySimulate), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(res) {
+		return nil, errors.New("invalid simulation result")
+	}
+
+	return res, nil
+}
+
+// QueryContractMetadata queries the metadata registered for the given contract
+func QueryContractMetadata(cliCtx context.CLIContext, contract sdk.AccAddress) (metadata types.ContractMetadata, err error) {
+	res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, types.QueryContractMetadata, contract))
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(res, &metadata)
+	return
+}
+
+// VMMsgs returns the msgs executed by the vm for a tx
+func VMMsgs(tx sdk.Tx) []types.MsgContract {
+	var msgs []types.MsgContract
+	for _, msg := range tx.GetMsgs() {
+		if vmMsg, ok := VMMsg(msg); ok {
+			msgs = append(msgs, vmMsg)
+		}
+	}
+
+	return msgs
+}
+
+// VMMsg returns the msg executed by the vm for a msg, if any
+func VMMsg(msg sdk.Msg) (types.MsgContract, bool) {
+	switch msg := msg.(type) {
+	case types.MsgContract:
+		return msg, true
+	case types.MsgEthereumTx:
+		if contractMsg, err := msg.ToMsgContract(); err == nil {
+			return contractMsg, true
+		}
+	}
+
+	return types.MsgContract{}, false
+}
+
+// TraceTx returns the replay of the ante handler and of the vm msgs of the first n msgs of a tx, the
+// msgs changing neither the vm accounts nor the vm storage are not counted as skipped
+func TraceTx(tx sdk.Tx, n int) types.TraceTx {
+	var traceTx types.TraceTx
+
+	if signersTx, ok := tx.(interface{ GetSigners() []sdk.AccAddress }); ok {
+		traceTx.Signers = signersTx.GetSigners()
+	} else {
+		seen := map[string]bool{}
+		for _, msg := range tx.GetMsgs() {
+			for _, signer := range msg.GetSigners() {
+				if !seen[signer.String()] {
+					seen[signer.String()] = true
+					traceTx.Signers = append(traceTx.Signers, signer)
+				}
+			}
+		}
+	}
+
+	if feeTx, ok := tx.(interface {
+		GetFee() sdk.Coins
+		FeePayer() sdk.AccAddress
+	}); ok {
+		traceTx.Fee = feeTx.GetFee()
+		traceTx.FeePayer = feeTx.FeePayer()
+	}
+	if granterTx, ok := tx.(interface{ GetFeeGranter() sdk.AccAddress }); ok && !granterTx.GetFeeGranter().Empty() {
+		traceTx.FeePayer = granterTx.GetFeeGranter()
+	}
+
+	for _, msg := range tx.GetMsgs()[:n] {
+		if vmMsg, ok := VMMsg(msg); ok {
+			traceTx.Msgs = append(traceTx.Msgs, vmMsg)
+			continue
+		}
+		if _, ok := msg.(types.MsgSetContractMetadata); !ok {
+			traceTx.Skipped++
+		}
+	}
+
+	return traceTx
+}
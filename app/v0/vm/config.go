@@ -1,10 +1,79 @@
 package vm
 
+import (
+	"math/big"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+)
+
 // ChainConfig is the core config which determines the blockchain settings.
 //
 // ChainConfig is stored in the database on a per block basis. This means
 // that any network, identified by its genesis block, can have its own
 // set of configuration options.
 type ChainConfig struct {
-	ChainID string `json:"chainId"` // chainId identifies the current chain and is used for replay protection
+	ChainID *big.Int `json:"chainId"` // chainId identifies the current chain and is used for replay protection
+
+	BerlinBlock   *big.Int `json:"berlinBlock,omitempty"`   // Berlin switch block (nil = no fork)
+	LondonBlock   *big.Int `json:"londonBlock,omitempty"`   // London switch block (nil = no fork)
+	ShanghaiBlock *big.Int `json:"shanghaiBlock,omitempty"` // Shanghai switch block (nil = no fork)
+}
+
+// NewChainConfig returns the ChainConfig of the chain with the given id and of the forks scheduled
+// by the vm params
+func NewChainConfig(chainID *big.Int, forkHeights types.ForkHeights) ChainConfig {
+	return ChainConfig{
+		ChainID:       chainID,
+		BerlinBlock:   forkBlock(forkHeights.Berlin),
+		LondonBlock:   forkBlock(forkHeights.London),
+		ShanghaiBlock: forkBlock(forkHeights.Shanghai),
+	}
+}
+
+func forkBlock(height int64) *big.Int {
+	if height == 0 {
+		return nil
+	}
+	return big.NewInt(height)
+}
+
+// IsBerlin returns whether num is either equal to the Berlin fork block or greater.
+func (c ChainConfig) IsBerlin(num *big.Int) bool {
+	return isForked(c.BerlinBlock, num)
+}
+
+// IsLondon returns whether num is either equal to the London fork block or greater.
+func (c ChainConfig) IsLondon(num *big.Int) bool {
+	return isForked(c.LondonBlock, num)
+}
+
+// IsShanghai returns whether num is either equal to the Shanghai fork block or greater.
+func (c ChainConfig) IsShanghai(num *big.Int) bool {
+	return isForked(c.ShanghaiBlock, num)
+}
+
+// isForked returns whether a fork scheduled at block s is active at the given head block.
+func isForked(s, head *big.Int) bool {
+	if s == nil || head == nil {
+		return false
+	}
+	return s.Cmp(head) <= 0
+}
+
+// Rules wraps ChainConfig and is merely syntactic sugar or can be used for functions
+// that do not have or require information about the block.
+//
+// Rules is a one time interface meaning that it shouldn't be used in between transition
+// phases.
+type Rules struct {
+	IsBerlin, IsLondon, IsShanghai bool
+}
+
+// Rules returns the forks active at block num.
+func (c ChainConfig) Rules(num *big.Int) Rules {
+	return Rules{
+		IsBerlin:   c.IsBerlin(num),
+		IsLondon:   c.IsLondon(num),
+		IsShanghai: c.IsShanghai(num),
+	}
 }
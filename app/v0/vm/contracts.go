@@ -27,7 +27,8 @@ type PrecompiledContract interface {
 	Run(input []byte) ([]byte, error) // Run runs the precompiled contract
 }
 
-// PrecompiledContracts contains the default set of pre-compiled contracts used in the Istanbul release.
+// PrecompiledContracts contains the default set of pre-compiled contracts used in the Istanbul release
+// and the native contracts giving access to the native modules.
 var PrecompiledContracts = map[string]PrecompiledContract{
 	(sdk.BytesToAddress([]byte{1})).String(): &ecrecover{},
 	(sdk.BytesToAddress([]byte{2})).String(): &sha256hash{},
@@ -38,6 +39,30 @@ var PrecompiledContracts = map[string]PrecompiledContract{
 	(sdk.BytesToAddress([]byte{7})).String(): &bn256ScalarMul{},
 	(sdk.BytesToAddress([]byte{8})).String(): &bn256Pairing{},
 	(sdk.BytesToAddress([]byte{9})).String(): &blake2F{},
+
+	NativeBankAddress.String():    nativeBank,
+	NativeStakingAddress.String(): nativeStaking,
+	NativeIPALAddress.String():    nativeIPAL,
+	NativeCIPALAddress.String():   nativeCIPAL,
+}
+
+// precompiledAddresses are the addresses of the precompiled contracts, warm from the start of a tx
+// from the berlin fork (EIP-2929)
+var precompiledAddresses = []sdk.AccAddress{
+	sdk.BytesToAddress([]byte{1}),
+	sdk.BytesToAddress([]byte{2}),
+	sdk.BytesToAddress([]byte{3}),
+	sdk.BytesToAddress([]byte{4}),
+	sdk.BytesToAddress([]byte{5}),
+	sdk.BytesToAddress([]byte{6}),
+	sdk.BytesToAddress([]byte{7}),
+	sdk.BytesToAddress([]byte{8}),
+	sdk.BytesToAddress([]byte{9}),
+
+	NativeBankAddress,
+	NativeStakingAddress,
+	NativeIPALAddress,
+	NativeCIPALAddress,
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
@@ -0,0 +1,383 @@
+package vm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// Reserved addresses of the native precompiled contracts
+var (
+	NativeBankAddress    = sdk.BytesToAddress([]byte{1, 0})
+	NativeStakingAddress = sdk.BytesToAddress([]byte{1, 1})
+	NativeIPALAddress    = sdk.BytesToAddress([]byte{1, 2})
+	NativeCIPALAddress   = sdk.BytesToAddress([]byte{1, 3})
+)
+
+// ABIs of the native precompiled contracts
+const (
+	NativeBankABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"denom","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+	NativeStakingABI = `[
+	{"type":"function","name":"bondDenom","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"validator","stateMutability":"view","inputs":[{"name":"validator","type":"string"}],"outputs":[{"name":"tokens","type":"uint256"},{"name":"jailed","type":"bool"},{"name":"status","type":"uint8"}]},
+	{"type":"function","name":"delegation","stateMutability":"view","inputs":[{"name":"delegator","type":"address"},{"name":"validator","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"delegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"undelegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"completionTime","type":"uint256"}]}
+]`
+
+	NativeIPALABI = `[
+	{"type":"function","name":"nodeByOperator","stateMutability":"view","inputs":[{"name":"operator","type":"address"}],"outputs":[{"name":"moniker","type":"string"},{"name":"website","type":"string"},{"name":"details","type":"string"},{"name":"extension","type":"string"},{"name":"endpointTypes","type":"uint64[]"},{"name":"endpoints","type":"string[]"},{"name":"bond","type":"uint256"}]},
+	{"type":"function","name":"operatorByMoniker","stateMutability":"view","inputs":[{"name":"moniker","type":"string"}],"outputs":[{"name":"","type":"address"}]}
+]`
+
+	NativeCIPALABI = `[
+	{"type":"function","name":"serviceInfos","stateMutability":"view","inputs":[{"name":"user","type":"address"}],"outputs":[{"name":"serviceTypes","type":"uint64[]"},{"name":"serviceAddresses","type":"string[]"}]},
+	{"type":"function","name":"resolve","stateMutability":"view","inputs":[{"name":"user","type":"address"},{"name":"serviceType","type":"uint64"}],"outputs":[{"name":"","type":"string"}]}
+]`
+)
+
+// StatefulPrecompiledContract is a precompiled contract reading and changing the state of the
+// native modules, it is run with the EVM and the contract calling it.
+type StatefulPrecompiledContract interface {
+	PrecompiledContract
+	RunStateful(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error)
+}
+
+// RunStatefulPrecompiledContract runs and evaluates the output of a stateful precompiled contract.
+func RunStatefulPrecompiledContract(evm *EVM, p StatefulPrecompiledContract, input []byte, contract *Contract, readOnly bool) (ret []byte, err error) {
+	gas := p.RequiredGas(input)
+	if contract.UseGas(gas) {
+		return p.RunStateful(evm, contract, input, readOnly)
+	}
+	return nil, ErrOutOfGas
+}
+
+// nativeMethod is a method of a native precompiled contract, the arguments and results are
+// the ABI unpacked inputs and the outputs to pack
+type nativeMethod struct {
+	gas      uint64
+	readOnly bool
+	run      func(ctx sdk.Context, nk types.NativeKeepers, caller sdk.AccAddress, args []interface{}) ([]interface{}, error)
+}
+
+// nativeContract is a stateful precompiled contract giving contracts access to a native module.
+// It can only be called with CALL or STATICCALL and without value, methods changing the state
+// are rejected within a STATICCALL.
+type nativeContract struct {
+	abi       abi.ABI
+	methods   map[string]nativeMethod
+	available func(nk types.NativeKeepers) bool
+}
+
+func newNativeContract(abiJSON string, methods map[string]nativeMethod, available func(nk types.NativeKeepers) bool) *nativeContract {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+
+	for name := range contractABI.Methods {
+		if _, ok := methods[name]; !ok {
+			panic("native contract method not implemented: " + name)
+		}
+	}
+
+	return &nativeContract{
+		abi:       contractABI,
+		methods:   methods,
+		available: available,
+	}
+}
+
+func (c *nativeContract) method(input []byte) (*abi.Method, nativeMethod, error) {
+	if len(input) < 4 {
+		return nil, nativeMethod{}, sdkerrors.Wrap(ErrInvalidNativeCall, "missing method selector")
+	}
+
+	method, err := c.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, nativeMethod{}, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+
+	return method, c.methods[method.Name], nil
+}
+
+func (c *nativeContract) RequiredGas(input []byte) uint64 {
+	_, m, err := c.method(input)
+	if err != nil {
+		return NativeQueryGas
+	}
+	return m.gas
+}
+
+func (c *nativeContract) Run(input []byte) ([]byte, error) {
+	return nil, sdkerrors.Wrap(ErrInvalidNativeCall, "native contract requires the evm state")
+}
+
+func (c *nativeContract) RunStateful(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
+	if !c.available(evm.NativeKeepers) {
+		return nil, ErrNativeUnavailable
+	}
+	if !contract.Address().Equals(*contract.CodeAddr) {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, "native contract cannot be called with DELEGATECALL or CALLCODE")
+	}
+	if contract.Value().Sign() != 0 {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, "native contract does not accept value")
+	}
+
+	method, m, err := c.method(input)
+	if err != nil {
+		return nil, err
+	}
+	if readOnly && !m.readOnly {
+		return nil, ErrWriteProtection
+	}
+
+	args, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+
+	var res []interface{}
+	err = evm.StateDB.RunNative(m.readOnly, func(ctx sdk.Context) (err error) {
+		res, err = m.run(ctx, evm.NativeKeepers, contract.Caller(), args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(res...)
+}
+
+// native bank contract, transfers of non-native tokens
+
+var nativeBank = newNativeContract(NativeBankABI, map[string]nativeMethod{
+	"balanceOf": {gas: NativeQueryGas, readOnly: true, run: bankBalanceOf},
+	"transfer":  {gas: NativeTransferGas, run: bankTransfer},
+}, func(nk types.NativeKeepers) bool { return nk.BankKeeper != nil })
+
+func bankBalanceOf(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	account := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+	denom := args[1].(string)
+
+	return []interface{}{nk.BankKeeper.GetCoins(ctx, account).AmountOf(denom).BigInt()}, nil
+}
+
+func bankTransfer(ctx sdk.Context, nk types.NativeKeepers, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	to := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+	denom := args[1].(string)
+
+	amount, err := nativeAmount(args[2].(*big.Int))
+	if err != nil {
+		return nil, err
+	}
+
+	if denom == sdk.NativeTokenName {
+		return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "%s must be transferred as call value", sdk.NativeTokenName)
+	}
+	if !nk.BankKeeper.GetSendEnabled(ctx) {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, "transfers are currently disabled")
+	}
+	if nk.BankKeeper.BlacklistedAddr(to) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive transactions", to)
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(denom, amount))
+	if err := nk.BankKeeper.SendCoins(ctx, caller, to, coins); err != nil {
+		return nil, err
+	}
+
+	return []interface{}{true}, nil
+}
+
+// native staking contract, delegations of the caller
+
+var nativeStaking = newNativeContract(NativeStakingABI, map[string]nativeMethod{
+	"bondDenom":  {gas: NativeQueryGas, readOnly: true, run: stakingBondDenom},
+	"validator":  {gas: NativeQueryGas, readOnly: true, run: stakingValidator},
+	"delegation": {gas: NativeQueryGas, readOnly: true, run: stakingDelegation},
+	"delegate":   {gas: NativeStakingGas, run: stakingDelegate},
+	"undelegate": {gas: NativeStakingGas, run: stakingUndelegate},
+}, func(nk types.NativeKeepers) bool { return nk.StakingKeeper != nil })
+
+func stakingBondDenom(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, _ []interface{}) ([]interface{}, error) {
+	return []interface{}{nk.StakingKeeper.BondDenom(ctx)}, nil
+}
+
+func stakingValidator(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	valAddr, err := sdk.ValAddressFromBech32(args[0].(string))
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+
+	validator, found := nk.StakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "validator %s not found", valAddr)
+	}
+
+	return []interface{}{validator.Tokens.BigInt(), validator.Jailed, uint8(validator.Status)}, nil
+}
+
+func stakingDelegation(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	delAddr := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+	valAddr, err := sdk.ValAddressFromBech32(args[1].(string))
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+
+	tokens := new(big.Int)
+	validator, found := nk.StakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return []interface{}{tokens}, nil
+	}
+	if delegation, found := nk.StakingKeeper.GetDelegation(ctx, delAddr, valAddr); found {
+		tokens = validator.TokensFromSharesTruncated(delegation.Shares).TruncateInt().BigInt()
+	}
+
+	return []interface{}{tokens}, nil
+}
+
+func stakingDelegate(ctx sdk.Context, nk types.NativeKeepers, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	valAddr, err := sdk.ValAddressFromBech32(args[0].(string))
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+	amount, err := nativeAmount(args[1].(*big.Int))
+	if err != nil {
+		return nil, err
+	}
+
+	validator, found := nk.StakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "validator %s not found", valAddr)
+	}
+
+	// NOTE: source funds are always unbonded
+	if _, err := nk.StakingKeeper.Delegate(ctx, caller, amount, sdk.Unbonded, validator, true); err != nil {
+		return nil, err
+	}
+
+	return []interface{}{true}, nil
+}
+
+func stakingUndelegate(ctx sdk.Context, nk types.NativeKeepers, caller sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	valAddr, err := sdk.ValAddressFromBech32(args[0].(string))
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalidNativeCall, err.Error())
+	}
+	amount, err := nativeAmount(args[1].(*big.Int))
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := nk.StakingKeeper.ValidateUnbondAmount(ctx, caller, valAddr, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTime, err := nk.StakingKeeper.Undelegate(ctx, caller, valAddr, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{big.NewInt(completionTime.Unix())}, nil
+}
+
+// native ipal contract, lookup of the IPAL nodes
+
+var nativeIPAL = newNativeContract(NativeIPALABI, map[string]nativeMethod{
+	"nodeByOperator":    {gas: NativeQueryGas, readOnly: true, run: ipalNodeByOperator},
+	"operatorByMoniker": {gas: NativeQueryGas, readOnly: true, run: ipalOperatorByMoniker},
+}, func(nk types.NativeKeepers) bool { return nk.IPALKeeper != nil })
+
+func ipalNodeByOperator(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	operator := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+
+	node, found := nk.IPALKeeper.GetIPALNode(ctx, operator)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "ipal node %s not found", operator)
+	}
+
+	endpointTypes := make([]uint64, 0, len(node.Endpoints))
+	endpoints := make([]string, 0, len(node.Endpoints))
+	for _, endpoint := range node.Endpoints {
+		endpointTypes = append(endpointTypes, endpoint.Type)
+		endpoints = append(endpoints, endpoint.Endpoint)
+	}
+
+	return []interface{}{
+		node.Moniker,
+		node.Website,
+		node.Details,
+		node.Extension,
+		endpointTypes,
+		endpoints,
+		node.Bond.Amount.BigInt(),
+	}, nil
+}
+
+func ipalOperatorByMoniker(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	moniker := args[0].(string)
+
+	operator, found := nk.IPALKeeper.GetIPALNodeAddByMoniker(ctx, moniker)
+	if !found {
+		return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "ipal node %s not found", moniker)
+	}
+
+	return []interface{}{ethcmn.BytesToAddress(operator.Bytes())}, nil
+}
+
+// native cipal contract, resolution of the user addresses
+
+var nativeCIPAL = newNativeContract(NativeCIPALABI, map[string]nativeMethod{
+	"serviceInfos": {gas: NativeQueryGas, readOnly: true, run: cipalServiceInfos},
+	"resolve":      {gas: NativeQueryGas, readOnly: true, run: cipalResolve},
+}, func(nk types.NativeKeepers) bool { return nk.CIPALKeeper != nil })
+
+func cipalServiceInfos(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	user := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+
+	serviceTypes := make([]uint64, 0)
+	serviceAddresses := make([]string, 0)
+	if obj, found := nk.CIPALKeeper.GetCIPALObject(ctx, user.String()); found {
+		for _, info := range obj.ServiceInfos {
+			serviceTypes = append(serviceTypes, info.Type)
+			serviceAddresses = append(serviceAddresses, info.Address)
+		}
+	}
+
+	return []interface{}{serviceTypes, serviceAddresses}, nil
+}
+
+func cipalResolve(ctx sdk.Context, nk types.NativeKeepers, _ sdk.AccAddress, args []interface{}) ([]interface{}, error) {
+	user := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+	serviceType := args[1].(uint64)
+
+	if obj, found := nk.CIPALKeeper.GetCIPALObject(ctx, user.String()); found {
+		for _, info := range obj.ServiceInfos {
+			if info.Type == serviceType {
+				return []interface{}{info.Address}, nil
+			}
+		}
+	}
+
+	return nil, sdkerrors.Wrapf(ErrInvalidNativeCall, "no service of type %d for %s", serviceType, user)
+}
+
+// nativeAmount converts an ABI uint256 to a positive token amount
+func nativeAmount(amount *big.Int) (sdk.Int, error) {
+	if amount.Sign() <= 0 || amount.BitLen() > 255 {
+		return sdk.Int{}, sdkerrors.Wrapf(ErrInvalidNativeCall, "invalid amount %s", amount)
+	}
+	return sdk.NewIntFromBigInt(amount), nil
+}
@@ -0,0 +1,139 @@
+package vm
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+type fakeCIPALKeeper map[string]cipaltypes.CIPALObject
+
+func (k fakeCIPALKeeper) GetCIPALObject(_ sdk.Context, userAddress string) (cipaltypes.CIPALObject, bool) {
+	obj, found := k[userAddress]
+	return obj, found
+}
+
+func newNativeTestEVM(ctx sdk.Context, k Keeper) *EVM {
+	st := StateTransition{StateDB: k.StateDB.WithContext(ctx)}
+	vmParams := k.GetParams(ctx)
+
+	return NewEVM(Context{
+		CanTransfer:   st.CanTransfer,
+		Transfer:      st.Transfer,
+		NativeKeepers: k.NativeKeepers,
+	}, st.StateDB, ChainConfig{}, Config{
+		OpConstGasConfig:          &vmParams.VMOpGasParams,
+		ContractCreationGasConfig: &vmParams.VMContractCreationGasParams,
+		MaxCodeSize:               vmParams.MaxCodeSize,
+		MaxCallCreateDepth:        vmParams.MaxCallCreateDepth,
+	})
+}
+
+func packNative(t *testing.T, abiJSON, method string, args ...interface{}) []byte {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+	input, err := contractABI.Pack(method, args...)
+	require.NoError(t, err)
+	return input
+}
+
+func TestNativeBankTransfer(t *testing.T) {
+	ctx, ak, k, _ := keep.CreateTestInput(t, false, 1000)
+	from, to := keep.Addrs[0], keep.Addrs[1]
+
+	acc := ak.GetAccount(ctx, from)
+	require.NoError(t, acc.SetCoins(acc.GetCoins().Add(sdk.NewCoins(sdk.NewInt64Coin("uabc", 100)))))
+	ak.SetAccount(ctx, acc)
+
+	transfer := packNative(t, NativeBankABI, "transfer", ethcmn.BytesToAddress(to), "uabc", big.NewInt(30))
+	balanceOf := packNative(t, NativeBankABI, "balanceOf", ethcmn.BytesToAddress(to), "uabc")
+
+	// a reverted transfer is rolled back with the evm state changes
+	evm := newNativeTestEVM(ctx, k)
+	snapshot := evm.StateDB.Snapshot()
+	evm.StateDB.SubBalance(from, big.NewInt(5))
+	_, _, err := evm.Call(AccountRef(from), NativeBankAddress, transfer, 100000, new(big.Int))
+	require.NoError(t, err)
+	evm.StateDB.RevertToSnapshot(snapshot)
+	evm.StateDB.Finalise(true)
+	k.StateDB.ClearStateObjects()
+
+	require.Equal(t, int64(100), ak.GetAccount(ctx, from).GetCoins().AmountOf("uabc").Int64())
+	require.True(t, ak.GetAccount(ctx, to).GetCoins().AmountOf("uabc").IsZero())
+
+	// a successful transfer keeps the evm state changes done before and after it
+	evm = newNativeTestEVM(ctx, k)
+	balance := evm.StateDB.GetBalance(from)
+	evm.StateDB.SubBalance(from, big.NewInt(5))
+	_, _, err = evm.Call(AccountRef(from), NativeBankAddress, transfer, 100000, new(big.Int))
+	require.NoError(t, err)
+	ret, _, err := evm.Call(AccountRef(from), NativeBankAddress, balanceOf, 100000, new(big.Int))
+	require.NoError(t, err)
+	require.Equal(t, int64(30), new(big.Int).SetBytes(ret).Int64())
+	evm.StateDB.SubBalance(from, big.NewInt(5))
+	evm.StateDB.Finalise(true)
+	k.StateDB.ClearStateObjects()
+
+	fromCoins := ak.GetAccount(ctx, from).GetCoins()
+	require.Equal(t, int64(70), fromCoins.AmountOf("uabc").Int64())
+	require.Equal(t, new(big.Int).Sub(balance, big.NewInt(10)), fromCoins.AmountOf(sdk.NativeTokenName).BigInt())
+	require.Equal(t, int64(30), ak.GetAccount(ctx, to).GetCoins().AmountOf("uabc").Int64())
+
+	// state changes are rejected within a static call, native tokens and unknown methods fail
+	evm = newNativeTestEVM(ctx, k)
+	_, _, err = evm.StaticCall(AccountRef(from), NativeBankAddress, transfer, 100000)
+	require.Equal(t, ErrWriteProtection, err)
+	native := packNative(t, NativeBankABI, "transfer", ethcmn.BytesToAddress(to), sdk.NativeTokenName, big.NewInt(30))
+	_, _, err = evm.Call(AccountRef(from), NativeBankAddress, native, 100000, new(big.Int))
+	require.Error(t, err)
+	_, _, err = evm.Call(AccountRef(from), NativeBankAddress, []byte{1, 2, 3, 4}, 100000, new(big.Int))
+	require.Error(t, err)
+
+	// gas is charged per method
+	_, leftOverGas, err := evm.Call(AccountRef(from), NativeBankAddress, balanceOf, 100000, new(big.Int))
+	require.NoError(t, err)
+	require.Equal(t, 100000-NativeQueryGas, leftOverGas)
+	_, _, err = evm.Call(AccountRef(from), NativeBankAddress, transfer, NativeTransferGas-1, new(big.Int))
+	require.Equal(t, ErrOutOfGas, err)
+}
+
+func TestNativeCIPALResolve(t *testing.T) {
+	ctx, _, k, _ := keep.CreateTestInput(t, false, 1000)
+	user := keep.Addrs[0]
+
+	resolve := packNative(t, NativeCIPALABI, "resolve", ethcmn.BytesToAddress(user), uint64(1))
+
+	evm := newNativeTestEVM(ctx, k)
+	_, _, err := evm.Call(AccountRef(user), NativeCIPALAddress, resolve, 100000, new(big.Int))
+	require.Equal(t, ErrNativeUnavailable, err)
+
+	k.SetNativeKeepers(types.NativeKeepers{CIPALKeeper: fakeCIPALKeeper{
+		user.String(): cipaltypes.CIPALObject{
+			UserAddress:  user.String(),
+			ServiceInfos: []cipaltypes.ServiceInfo{{Type: 1, Address: "service-1"}},
+		},
+	}})
+
+	evm = newNativeTestEVM(ctx, k)
+	ret, _, err := evm.StaticCall(AccountRef(user), NativeCIPALAddress, resolve, 100000)
+	require.NoError(t, err)
+
+	contractABI, err := abi.JSON(strings.NewReader(NativeCIPALABI))
+	require.NoError(t, err)
+	res, err := contractABI.Methods["resolve"].Outputs.UnpackValues(ret)
+	require.NoError(t, err)
+	require.Equal(t, "service-1", res[0])
+
+	unknown := packNative(t, NativeCIPALABI, "resolve", ethcmn.BytesToAddress(user), uint64(2))
+	_, _, err = evm.StaticCall(AccountRef(user), NativeCIPALAddress, unknown, 100000)
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestForkHeightsValidate(t *testing.T) {
+	require.NoError(t, types.ForkHeights{}.Validate())
+	require.NoError(t, types.ForkHeights{Berlin: 10}.Validate())
+	require.NoError(t, types.ForkHeights{Berlin: 10, London: 10, Shanghai: 20}.Validate())
+	require.Error(t, types.ForkHeights{Berlin: -1}.Validate())
+	require.Error(t, types.ForkHeights{London: 10}.Validate())
+	require.Error(t, types.ForkHeights{Berlin: 20, London: 10}.Validate())
+	require.Error(t, types.ForkHeights{Berlin: 10, Shanghai: 20}.Validate())
+}
+
+func TestChainConfigRules(t *testing.T) {
+	config := NewChainConfig(big.NewInt(1), types.ForkHeights{Berlin: 10, London: 20})
+	require.Equal(t, Rules{}, config.Rules(big.NewInt(9)))
+	require.Equal(t, Rules{IsBerlin: true}, config.Rules(big.NewInt(10)))
+	require.Equal(t, Rules{IsBerlin: true, IsLondon: true}, config.Rules(big.NewInt(100)))
+	require.Equal(t, Rules{}, config.Rules(nil))
+}
+
+func TestGasSLoadEIP2929(t *testing.T) {
+	evm := newEVM()
+	contract := NewContract(AccountRef(sdk.BytesToAddress([]byte("caller"))), AccountRef(sdk.BytesToAddress([]byte("contract"))), big.NewInt(0), 100000)
+	stack := newstack()
+	stack.push(big.NewInt(1))
+
+	evm.StateDB.PrepareAccessList(contract.Caller(), contract.Address(), nil, nil, nil)
+	snapshot := evm.StateDB.Snapshot()
+
+	gas, err := gasSLoadEIP2929(evm, contract, stack, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, ColdSloadCostEIP2929, gas)
+	gas, err = gasSLoadEIP2929(evm, contract, stack, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, WarmStorageReadCostEIP2929, gas)
+
+	// the slot gets cold again when the access is reverted
+	evm.StateDB.RevertToSnapshot(snapshot)
+	gas, err = gasSLoadEIP2929(evm, contract, stack, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, ColdSloadCostEIP2929, gas)
+}
+
+func TestRefundGas(t *testing.T) {
+	require.Equal(t, uint64(100), refundGas(1000, 100, RefundQuotientEIP3529))
+	require.Equal(t, uint64(200), refundGas(1000, 4800, RefundQuotientEIP3529))
+}
+
+func TestMsgContractForks(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	handler := NewHandler(vmKeeper)
+	zero := sdk.NewInt64Coin(sdk.NativeTokenName, 0)
+
+	push0 := sdk.FromHex("5f00")                  // PUSH0 STOP
+	efCode := sdk.FromHex("60ef60005360016000f3") // returns the code 0xef
+	sender := 0
+	create := func(height int64, code []byte, accessList types.AccessList) error {
+		sender++
+		msg := types.NewMsgContract(keep.Addrs[sender], nil, code, zero)
+		msg.AccessList = accessList
+		_, err := handler(ctx.WithBlockHeight(height), msg)
+		return err
+	}
+	accessList := types.AccessList{{Address: keep.Addrs[0], StorageKeys: []sdk.Hash{{}}}}
+
+	// no fork scheduled
+	require.Error(t, create(100, push0, nil))
+	require.NoError(t, create(100, efCode, nil))
+	require.True(t, ErrAccessListNotActive.Is(create(100, efCode, accessList)))
+
+	vmKeeper.SetForkHeights(ctx, types.ForkHeights{Berlin: 10, London: 20, Shanghai: 30})
+
+	require.NoError(t, create(10, efCode, accessList))
+	require.True(t, ErrInvalidCode.Is(create(20, efCode, nil)))
+	require.Error(t, create(20, push0, nil))
+	require.NoError(t, create(30, push0, nil))
+}
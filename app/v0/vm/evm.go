@@ -1,7 +1,6 @@
 package vm
 
 import (
-	"fmt"
 	"math/big"
 	"time"
 
@@ -17,15 +16,16 @@ type (
 	TransferFunc func(sdk.AccAddress, sdk.AccAddress, *big.Int)
 	// GetHashFunc returns the nth block hash in the blockchain
 	// and is used by the BLOCKHASH EVM op code.
-	//GetHashFunc func(sdk.Context) types.Hash
-	GetHashFunc func() sdk.Hash
+	GetHashFunc func(uint64) sdk.Hash
 )
 
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
 		precompiles := PrecompiledContracts
 		if p := precompiles[contract.CodeAddr.String()]; p != nil {
-			fmt.Println("RunPrecompiledContract ...")
+			if sp, ok := p.(StatefulPrecompiledContract); ok {
+				return RunStatefulPrecompiledContract(evm, sp, input, contract, readOnly)
+			}
 			return RunPrecompiledContract(p, input, contract)
 		}
 	}
@@ -78,6 +78,10 @@ type Context struct {
 	GasLimit    uint64
 	BlockNumber *big.Int
 	Time        *big.Int
+	BaseFee     *big.Int // returned by BASEFEE, the chain has no EIP-1559 base fee so it is 0
+
+	// NativeKeepers gives the native precompiled contracts access to the native modules
+	NativeKeepers NativeKeepers
 }
 
 type EVM struct {
@@ -90,6 +94,8 @@ type EVM struct {
 	depth uint64
 
 	chainConfig ChainConfig
+	// chainRules are the forks active at the block of the context
+	chainRules Rules
 
 	// virtual machine configuration options used to initialise the vm
 	vmConfig Config
@@ -107,10 +113,12 @@ type EVM struct {
 	callGasTemp uint64
 }
 
-func NewEVM(ctx Context, statedb *CommitStateDB, vmConfig Config) *EVM {
+func NewEVM(ctx Context, statedb *CommitStateDB, chainConfig ChainConfig, vmConfig Config) *EVM {
 	evm := &EVM{
 		Context:      ctx,
 		StateDB:      statedb,
+		chainConfig:  chainConfig,
+		chainRules:   chainConfig.Rules(ctx.BlockNumber),
 		vmConfig:     vmConfig,
 		interpreters: make([]Interpreter, 0, 1),
 	}
@@ -120,6 +128,9 @@ func NewEVM(ctx Context, statedb *CommitStateDB, vmConfig Config) *EVM {
 	return evm
 }
 
+// ChainConfig returns the environment's chain configuration
+func (evm *EVM) ChainConfig() ChainConfig { return evm.chainConfig }
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() Interpreter {
 	return evm.interpreter
@@ -148,6 +159,12 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 		evm.StateDB.SetNonce(caller.Address(), nonce+1)
 	}
 
+	// We add this to the access list _before_ taking a snapshot. Even if the creation fails,
+	// the access-list change should not be rolled back
+	if evm.chainRules.IsBerlin {
+		evm.StateDB.AddAddressToAccessList(address)
+	}
+
 	// Ensure there's no existing contract already at the designated address
 	contractHash := evm.StateDB.GetCodeHash(address)
 	if evm.StateDB.GetNonce(address) != 0 || (contractHash != (sdk.Hash{})) {
@@ -170,8 +187,16 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 
 	start := time.Now()
+	if evm.vmConfig.Debug && evm.depth == 0 {
+		evm.vmConfig.Tracer.CaptureStart(caller.Address(), address, true, codeAndHash.code, gas, value)
+	}
+
 	ret, err := run(evm, contract, nil, false)
 	maxCodeSizeExceeded := len(ret) > int(evm.vmConfig.MaxCodeSize)
+	// Reject code starting with 0xEF if EIP-3541 is enabled.
+	if err == nil && len(ret) >= 1 && ret[0] == 0xEF && evm.chainRules.IsLondon {
+		err = ErrInvalidCode
+	}
 	if err == nil && !maxCodeSizeExceeded {
 		createGas := evm.vmConfig.ContractCreationGasConfig.Gas + uint64(len(ret))*evm.vmConfig.ContractCreationGasConfig.GasPerByte
 		if contract.UseGas(createGas) {
@@ -14,6 +14,10 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgContract:
 			return handleMsgContract(ctx, msg, k)
+		case MsgEthereumTx:
+			return handleMsgEthereumTx(ctx, msg, k)
+		case MsgSetContractMetadata:
+			return handleMsgSetContractMetadata(ctx, msg, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
@@ -26,7 +30,8 @@ func handleMsgContract(ctx sdk.Context, msg MsgContract, k Keeper) (*sdk.Result,
 		return nil, err
 	}
 
-	_, res, err := DoStateTransition(ctx, msg, k, ctx.Simulate)
+	receipt, res, err := deliverStateTransition(ctx, msg, k)
+	setReceipt(ctx, k, receipt)
 	if err != nil {
 		return &sdk.Result{Data: res.Data, GasUsed: res.GasUsed}, err
 	}
@@ -40,3 +45,93 @@ func handleMsgContract(ctx sdk.Context, msg MsgContract, k Keeper) (*sdk.Result,
 
 	return &sdk.Result{Data: res.Data, GasUsed: res.GasUsed, Events: ctx.EventManager().Events()}, nil
 }
+
+func handleMsgEthereumTx(ctx sdk.Context, msg MsgEthereumTx, k Keeper) (*sdk.Result, error) {
+	contractMsg, err := msg.ToMsgContract()
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+	}
+
+	receipt, res, err := deliverStateTransition(ctx, contractMsg, k)
+	setReceipt(ctx, k, receipt)
+	if err != nil {
+		return &sdk.Result{Data: res.Data, GasUsed: res.GasUsed}, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, contractMsg.From.String()),
+		),
+	)
+
+	return &sdk.Result{Data: res.Data, GasUsed: res.GasUsed, Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgSetContractMetadata stores the metadata of a contract when the sender is its deployer,
+// proven by the contract address being the one created by the sender with the nonce of the msg
+func handleMsgSetContractMetadata(ctx sdk.Context, msg MsgSetContractMetadata, k Keeper) (*sdk.Result, error) {
+	err := msg.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
+	if !CreateAddress(msg.From, msg.Nonce).Equals(msg.Contract) {
+		return nil, sdkerrors.Wrapf(ErrNotContractDeployer, "contract %s is not created by %s with nonce %d", msg.Contract, msg.From, msg.Nonce)
+	}
+	// the code of a contract deployed in the same block is only held by the StateDB until the end
+	// of the block
+	if len(k.StateDB.WithContext(ctx).GetCode(msg.Contract)) == 0 {
+		return nil, sdkerrors.Wrapf(ErrNoCodeExist, "contract %s", msg.Contract)
+	}
+
+	k.SetContractMetadata(ctx, msg.Metadata())
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetContractMetadata,
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Contract.String()),
+			sdk.NewAttribute(types.AttributeKeyDeployer, msg.From.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.From.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// deliverStateTransition executes the msg and, when the state diff retention is set, records the
+// state diff of a delivered tx. The param is read without charging gas so that the recording does
+// not change the gas used by the tx.
+func deliverStateTransition(ctx sdk.Context, msg MsgContract, k Keeper) (*types.Receipt, *sdk.Result, error) {
+	if ctx.Simulate || k.GetStateDiffRetention(ctx.WithGasMeter(sdk.NewInfiniteGasMeter())) == 0 {
+		return DoStateTransition(ctx, msg, k, ctx.Simulate)
+	}
+
+	k.StateDB.RecordStateDiff()
+	receipt, res, err := DoStateTransition(ctx, msg, k, false)
+	diff := k.StateDB.StateDiff()
+	if err == nil && receipt != nil {
+		k.SetTxStateDiff(ctx, types.TxStateDiff{TxHash: receipt.TxHash, Height: receipt.BlockNumber, StateDiff: diff})
+	}
+	return receipt, res, err
+}
+
+// setReceipt stores the receipt of a delivered tx. The writes of a failed tx are discarded, so
+// its receipt is kept by the StateDB until the EndBlocker writes it.
+func setReceipt(ctx sdk.Context, k Keeper, receipt *types.Receipt) {
+	if ctx.Simulate || receipt == nil {
+		return
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		k.StateDB.AddFailedReceipt(receipt)
+		return
+	}
+
+	k.SetReceipt(ctx, receipt)
+}
@@ -3,9 +3,12 @@ package vm
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"strings"
 	"testing"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -91,3 +94,284 @@ func TestMsgContractCreateAndCall(t *testing.T) {
 	}
 
 }
+
+func TestMsgContractReceipt(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	handler := NewHandler(vmKeeper)
+	querier := NewQuerier(vmKeeper)
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+
+	queryReceipt := func(txBytes []byte) (receipt types.Receipt) {
+		hash := sdk.BytesToHash(tmhash.Sum(txBytes))
+		bz, err := querier(ctx, []string{types.QueryReceipt, hash.String()}, abci.RequestQuery{})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &receipt))
+		require.Equal(t, hash, receipt.TxHash)
+		return
+	}
+
+	// contract create, the code of TestMsgContractCreateAndCall
+	code := sdk.FromHex("608060405234801561001057600080fd5b506509184e72a0006000803373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550610344806100696000396000f300608060405260043610610057576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806327e235e31461005c57806370a08231146100b3578063a9059cbb1461010a575b600080fd5b34801561006857600080fd5b5061009d600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610162565b6040518082815260200191505060405180910390f35b3480156100bf57600080fd5b506100f4600480360381019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919050505061017a565b6040518082815260200191505060405180910390f35b610148600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803590602001909291905050506101c2565b604051808215151515815260200191505060405180910390f35b60006020528060005260406000206000915090505481565b60008060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b6000816000803373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020541015151561021157600080fd5b816000803373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282540392505081905550816000808573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825401925050819055508273ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef846040518082815260200191505060405180910390a360019050929150505600a165627a7a7230582015481e18f5439ee76271037928d88d33cc7d7d4bf1e5e801b78db9e902f255560029")
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence())
+	createTx := []byte("create")
+	_, err := handler(ctx.WithTxBytes(createTx), types.NewMsgContract(acc.GetAddress(), nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.NoError(t, err)
+	EndBlocker(ctx, vmKeeper)
+
+	receipt := queryReceipt(createTx)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Equal(t, contractAddr, receipt.ContractAddress)
+	require.NotZero(t, receipt.GasUsed)
+	require.Empty(t, receipt.Logs)
+
+	// contract call emitting a Transfer log
+	callTx := []byte("call")
+	payload := common.FromHex("a9059cbb0000000000000000000000005376329591cde25497d29de88ec553229ad10a610000000000000000000000000000000000000000000000000000000000000064")
+	_, err = handler(ctx.WithTxBytes(callTx), types.NewMsgContract(acc.GetAddress(), contractAddr, payload, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.NoError(t, err)
+	EndBlocker(ctx, vmKeeper)
+
+	receipt = queryReceipt(callTx)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	require.Len(t, receipt.Logs, 1)
+	require.True(t, ethtypes.BloomLookup(receipt.LogsBloom, ethcmn.BytesToAddress(contractAddr)))
+	require.True(t, ethtypes.BloomLookup(receipt.LogsBloom, ethcmn.Hash(receipt.Logs[0].Topics[0])))
+
+	// contract create reverting with Error("nope"), the receipt is written by the end blocker
+	revertTx := []byte("revert")
+	revertCode := sdk.FromHex("6064600c60003960646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6e6f706500000000000000000000000000000000000000000000000000000000")
+	_, err = handler(ctx.WithTxBytes(revertTx), types.NewMsgContract(keep.Addrs[1], nil, revertCode, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.Error(t, err)
+	require.Nil(t, vmKeeper.GetReceipt(ctx, sdk.BytesToHash(tmhash.Sum(revertTx))))
+	EndBlocker(ctx, vmKeeper)
+
+	receipt = queryReceipt(revertTx)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	require.Equal(t, ErrExecutionReverted.Error(), receipt.Error)
+	require.Equal(t, "nope", receipt.RevertReason)
+
+	// tx with two contract calls, each msg has its own receipt
+	multiTx := []byte("multi")
+	for i := 0; i < 2; i++ {
+		_, err = handler(ctx.WithTxBytes(multiTx), types.NewMsgContract(acc.GetAddress(), contractAddr, payload, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+		require.NoError(t, err)
+	}
+	EndBlocker(ctx, vmKeeper)
+
+	multiHash := sdk.BytesToHash(tmhash.Sum(multiTx))
+	var logIndexes []uint64
+	for i := 0; i < 2; i++ {
+		bz, err := querier(ctx, []string{types.QueryReceipt, multiHash.String(), fmt.Sprint(i)}, abci.RequestQuery{})
+		require.NoError(t, err)
+		receipt = types.Receipt{}
+		require.NoError(t, json.Unmarshal(bz, &receipt))
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		require.Len(t, receipt.Logs, 1)
+		logIndexes = append(logIndexes, receipt.Logs[0].Index)
+	}
+	require.NotEqual(t, logIndexes[0], logIndexes[1])
+	_, err = querier(ctx, []string{types.QueryReceipt, multiHash.String(), "2"}, abci.RequestQuery{})
+	require.Error(t, err)
+
+	// the logs of the tx are listed once in the logs of the block
+	require.Len(t, vmKeeper.StateDB.WithContext(ctx).GetBlockLogs(uint64(ctx.BlockHeight())), 3)
+
+	// unknown tx
+	_, err = querier(ctx, []string{types.QueryReceipt, sdk.Hash{}.String()}, abci.RequestQuery{})
+	require.Error(t, err)
+}
+
+func TestMsgContractStateDiff(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	handler := NewHandler(vmKeeper)
+	querier := NewQuerier(vmKeeper)
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	vmKeeper.SetStateDiffRetention(ctx, 2)
+
+	queryStateDiff := func(txBytes []byte) (diff types.TxStateDiff, err error) {
+		hash := sdk.BytesToHash(tmhash.Sum(txBytes))
+		bz, err := querier(ctx, []string{types.QueryStateDiff, hash.String()}, abci.RequestQuery{})
+		if err != nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(bz, &diff))
+		require.Equal(t, hash, diff.TxHash)
+		return
+	}
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	code := sdk.FromHex(strings.TrimSpace(string(bc)))
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence())
+	zero := sdk.NewInt64Coin(sdk.NativeTokenName, 0)
+
+	// contract create at height 1
+	ctx = ctx.WithBlockHeight(1)
+	createTx := []byte("create")
+	_, err = handler(ctx.WithTxBytes(createTx), types.NewMsgContract(acc.GetAddress(), nil, code, zero))
+	require.NoError(t, err)
+	EndBlocker(ctx, vmKeeper)
+
+	diff, err := queryStateDiff(createTx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), diff.Height)
+	require.Len(t, diff.StateDiff, 1)
+	require.Equal(t, contractAddr, diff.StateDiff[0].Address)
+	require.Empty(t, diff.StateDiff[0].PreCode)
+	require.NotEmpty(t, diff.StateDiff[0].PostCode)
+
+	// contract call at height 2, storing the address of the second contract it creates in slot 0
+	ctx = ctx.WithBlockHeight(2)
+	callTx := []byte("call")
+	_, err = handler(ctx.WithTxBytes(callTx), types.NewMsgContract(acc.GetAddress(), contractAddr, sdk.FromHex("bf335e62"), zero))
+	require.NoError(t, err)
+	EndBlocker(ctx, vmKeeper)
+
+	diff, err = queryStateDiff(callTx)
+	require.NoError(t, err)
+	require.Len(t, diff.StateDiff, 3)
+	for _, d := range diff.StateDiff {
+		if d.Address.Equals(contractAddr) {
+			require.Empty(t, d.PostCode)
+			require.Len(t, d.Storage, 1)
+			require.Equal(t, sdk.Hash{}, d.Storage[0].Key)
+			require.Equal(t, sdk.Hash{}, d.Storage[0].Pre)
+			require.NotEqual(t, sdk.Hash{}, d.Storage[0].Post)
+		} else {
+			require.NotEmpty(t, d.PostCode)
+		}
+	}
+
+	// the diffs are kept for 2 blocks
+	EndBlocker(ctx.WithBlockHeight(3), vmKeeper)
+	_, err = queryStateDiff(createTx)
+	require.Error(t, err)
+	_, err = queryStateDiff(callTx)
+	require.NoError(t, err)
+
+	EndBlocker(ctx.WithBlockHeight(4), vmKeeper)
+	_, err = queryStateDiff(callTx)
+	require.Error(t, err)
+
+	// no diff is recorded when the retention is not set
+	vmKeeper.SetStateDiffRetention(ctx, 0)
+	otherTx := []byte("other")
+	_, err = handler(ctx.WithTxBytes(otherTx), types.NewMsgContract(acc.GetAddress(), contractAddr, sdk.FromHex("bf335e62"), zero))
+	require.NoError(t, err)
+	require.Nil(t, vmKeeper.GetTxStateDiff(ctx, sdk.BytesToHash(tmhash.Sum(otherTx))))
+}
+
+func TestMsgSetContractMetadata(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	handler := NewHandler(vmKeeper)
+	querier := NewQuerier(vmKeeper)
+	deployer := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	other := accountKeeper.GetAccount(ctx, keep.Addrs[1])
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	abiJSON, err := ioutil.ReadFile("./testdata/opCreate/a.abi")
+	require.NoError(t, err)
+	code := sdk.FromHex(strings.TrimSpace(string(bc)))
+	nonce := deployer.GetSequence()
+	contractAddr := CreateAddress(deployer.GetAddress(), nonce)
+	sourceHash := sdk.BytesToHash(tmhash.Sum([]byte("contract A {}")))
+
+	msg := types.NewMsgSetContractMetadata(deployer.GetAddress(), contractAddr, nonce, "A", string(abiJSON), "0.6.0", sourceHash)
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, types.TypeMsgSetContractMetadata, msg.Type())
+
+	// the contract is not deployed yet
+	_, err = handler(ctx, msg)
+	require.True(t, ErrNoCodeExist.Is(err))
+
+	_, err = handler(ctx, types.NewMsgContract(deployer.GetAddress(), nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.NoError(t, err)
+	EndBlocker(ctx, vmKeeper)
+
+	// only the deployer can set the metadata
+	_, err = handler(ctx, types.NewMsgSetContractMetadata(other.GetAddress(), contractAddr, nonce, "A", string(abiJSON), "0.6.0", sourceHash))
+	require.True(t, ErrNotContractDeployer.Is(err))
+	_, err = handler(ctx, types.NewMsgSetContractMetadata(deployer.GetAddress(), contractAddr, nonce+1, "A", string(abiJSON), "0.6.0", sourceHash))
+	require.True(t, ErrNotContractDeployer.Is(err))
+
+	// the abi must be valid
+	_, err = handler(ctx, types.NewMsgSetContractMetadata(deployer.GetAddress(), contractAddr, nonce, "A", "{", "0.6.0", sourceHash))
+	require.Error(t, err)
+
+	res, err := handler(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, types.EventTypeSetContractMetadata, res.Events[0].Type)
+
+	bz, err := querier(ctx, []string{types.QueryContractMetadata, contractAddr.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var metadata types.ContractMetadata
+	require.NoError(t, json.Unmarshal(bz, &metadata))
+	require.Equal(t, msg.Metadata(), metadata)
+	parsed, err := metadata.ParseABI()
+	require.NoError(t, err)
+	require.Contains(t, parsed.Methods, "a")
+
+	// the metadata is exported and imported with the genesis state
+	module := NewAppModule(vmKeeper)
+	genesis := module.ExportGenesis(ctx)
+	var genesisState types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(genesis, &genesisState)
+	require.Equal(t, []types.ContractMetadata{metadata}, genesisState.ContractMetadata)
+	require.NoError(t, ValidateGenesis(genesisState))
+
+	_, err = querier(ctx, []string{types.QueryContractMetadata, other.GetAddress().String()}, abci.RequestQuery{})
+	require.Error(t, err)
+}
+
+func TestMsgSetContractMetadataSameBlock(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	handler := NewHandler(vmKeeper)
+	deployer := accountKeeper.GetAccount(ctx, keep.Addrs[2])
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	abiJSON, err := ioutil.ReadFile("./testdata/opCreate/a.abi")
+	require.NoError(t, err)
+	nonce := deployer.GetSequence()
+	contractAddr := CreateAddress(deployer.GetAddress(), nonce)
+
+	// the contract is registered in the block it is deployed, before the end blocker commits its code
+	_, err = handler(ctx, types.NewMsgContract(deployer.GetAddress(), nil, sdk.FromHex(strings.TrimSpace(string(bc))), sdk.NewInt64Coin(sdk.NativeTokenName, 0)))
+	require.NoError(t, err)
+	msg := types.NewMsgSetContractMetadata(deployer.GetAddress(), contractAddr, nonce, "A", string(abiJSON), "0.6.0", sdk.Hash{})
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+
+	metadata := vmKeeper.GetContractMetadata(ctx, contractAddr)
+	require.NotNil(t, metadata)
+	require.Equal(t, msg.Metadata(), *metadata)
+}
+
+func TestMsgContractChainID(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	ctx = ctx.WithChainID("nch-7").WithBlockHeight(10)
+	handler := NewHandler(vmKeeper)
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+
+	// init code returning the 32 bytes pushed by CHAINID as the contract code
+	code := sdk.FromHex("4660005260206000f3")
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence())
+	msg := types.NewMsgContract(acc.GetAddress(), nil, code, sdk.NewInt64Coin(sdk.NativeTokenName, 0))
+
+	// CHAINID is enabled by the berlin fork
+	_, err := handler(ctx, msg)
+	require.Error(t, err)
+
+	params := vmKeeper.GetParams(ctx)
+	params.ForkHeights = types.ForkHeights{Berlin: 10}
+	vmKeeper.SetParams(ctx, params)
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+
+	require.Equal(t, sdk.BigToHash(ChainIDFromString(ctx.ChainID())).Bytes(), vmKeeper.StateDB.WithContext(ctx).GetCode(contractAddr))
+}
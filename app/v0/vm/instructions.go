@@ -512,9 +512,15 @@ func opGasprice(pc *uint64, interpreter *EVMInterpreter, contract *Contract, mem
 }
 
 func opBlockhash(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
-	stack.pop()
-	stack.push(interpreter.evm.GetHash().Big())
+	num := stack.pop()
 
+	n := interpreter.intPool.get().Sub(interpreter.evm.BlockNumber, common.Big257)
+	if num.Cmp(n) > 0 && num.Cmp(interpreter.evm.BlockNumber) < 0 {
+		stack.push(interpreter.evm.GetHash(num.Uint64()).Big())
+	} else {
+		stack.push(interpreter.intPool.getZero())
+	}
+	interpreter.intPool.put(num, n)
 	return nil, nil
 }
 
@@ -910,7 +916,10 @@ func opSelfBalance(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 }
 
 func opChainID(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
-	chainID := interpreter.intPool.get().SetBytes([]byte(interpreter.evm.chainConfig.ChainID))
+	chainID := interpreter.intPool.getZero()
+	if interpreter.evm.chainConfig.ChainID != nil {
+		chainID.Set(interpreter.evm.chainConfig.ChainID)
+	}
 	stack.push(chainID)
 	return nil, nil
 }
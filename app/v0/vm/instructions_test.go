@@ -779,6 +779,44 @@ func TestOpGasLimit(t *testing.T) {
 	require.True(t, actualGasLimit.Uint64() == gasLimit)
 }
 
+func TestOpBlockhash(t *testing.T) {
+	var (
+		addr  = sdk.AccAddress{0xab}
+		value = big.NewInt(1000)
+
+		env         = newEVM()
+		stack       = newstack()
+		mem         = NewMemory()
+		interpreter = NewEVMInterpreter(env, env.vmConfig)
+		contract    = NewContract(&dummyContractRef{address: addr}, &dummyContractRef{address: addr}, value, 0)
+	)
+
+	pc := uint64(0)
+	interpreter.intPool = poolOfIntPools.get()
+
+	env.Context.BlockNumber = big.NewInt(1000)
+	env.Context.GetHash = func(height uint64) sdk.Hash {
+		return sdk.BigToHash(new(big.Int).SetUint64(height))
+	}
+
+	cases := []struct {
+		num      int64
+		expected int64
+	}{
+		{1000, 0},
+		{1001, 0},
+		{999, 999},
+		{744, 744},
+		{743, 0},
+		{0, 0},
+	}
+	for _, tc := range cases {
+		stack.push(big.NewInt(tc.num))
+		opBlockhash(&pc, interpreter, contract, mem, stack)
+		require.Equal(t, tc.expected, stack.pop().Int64(), "BLOCKHASH(%d)", tc.num)
+	}
+}
+
 func TestOpPush1(t *testing.T) {
 	var (
 		addr        = sdk.AccAddress{0xab}
@@ -1455,15 +1493,17 @@ func TestOpChainID(t *testing.T) {
 	)
 
 	interpreter.intPool = poolOfIntPools.get()
-	interpreter.evm.chainConfig.ChainID = "test"
+	interpreter.evm.chainConfig.ChainID = ChainIDFromString("nch-1")
 	pc := uint64(0)
 
 	opChainID(&pc, interpreter, contract, nil, stack)
 
 	v := stack.pop()
-	t.Log(string(v.Bytes()))
+	require.Equal(t, int64(1), v.Int64())
 
-	require.True(t, string(v.Bytes()) == interpreter.evm.chainConfig.ChainID)
+	// the pushed value must not alias the chain config
+	v.SetInt64(2)
+	require.Equal(t, int64(1), interpreter.evm.chainConfig.ChainID.Int64())
 }
 
 func TestOpPop(t *testing.T) {
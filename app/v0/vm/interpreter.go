@@ -152,7 +152,9 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		op = contract.GetOp(pc)
 		//fmt.Fprintf(os.Stderr, fmt.Sprintf("op code = %s\n", op))
 		operation := in.cfg.JumpTable[op]
-		operation.constantGas = in.cfg.OpConstGasConfig[op]
+		if !operation.forkConstantGas {
+			operation.constantGas = in.cfg.OpConstGasConfig[op]
+		}
 		if !operation.valid {
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrInternal, "invalid opcode 0x%x", int(op))
 		}
@@ -250,7 +252,17 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 // run by the current interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if !cfg.JumpTable[STOP].valid {
-		jt := istanbulInstructionSet
+		var jt JumpTable
+		switch {
+		case evm.chainRules.IsShanghai:
+			jt = shanghaiInstructionSet
+		case evm.chainRules.IsLondon:
+			jt = londonInstructionSet
+		case evm.chainRules.IsBerlin:
+			jt = berlinInstructionSet
+		default:
+			jt = istanbulInstructionSet
+		}
 		cfg.JumpTable = jt
 	}
 
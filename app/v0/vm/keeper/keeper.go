@@ -1,8 +1,10 @@
 package keeper
 
 import (
+	"encoding/json"
 	"fmt"
 
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
@@ -14,19 +16,46 @@ import (
 
 type Keeper struct {
 	Cdc        *codec.Codec
+	storeKey   sdk.StoreKey
 	paramstore params.Subspace
 	StateDB    *types.CommitStateDB
+
+	// NativeKeepers are the native modules reachable from contracts
+	NativeKeepers types.NativeKeepers
+
+	// maxLogsBlockRange is the max number of blocks a logs filter query may scan, set from the node config
+	maxLogsBlockRange uint64
 }
 
 // NewKeeper returns vm keeper
 func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramstore params.Subspace, ak auth.AccountKeeper) Keeper {
 	return Keeper{
 		Cdc:        cdc,
+		storeKey:   storeKey,
 		paramstore: paramstore.WithKeyTable(ParamKeyTable()),
 		StateDB:    types.NewCommitStateDB(ak, storeKey),
+
+		maxLogsBlockRange: types.DefaultMaxLogsBlockRange,
 	}
 }
 
+// SetNativeKeepers sets the keepers of the native modules reachable from contracts through the
+// native precompiled contracts
+func (k *Keeper) SetNativeKeepers(nk types.NativeKeepers) {
+	k.NativeKeepers = nk
+}
+
+// SetMaxLogsBlockRange sets the max number of blocks a logs filter query may scan, it is a node
+// config and not a param so that each node bounds the load of the queries it serves
+func (k *Keeper) SetMaxLogsBlockRange(maxLogsBlockRange uint64) {
+	k.maxLogsBlockRange = maxLogsBlockRange
+}
+
+// GetMaxLogsBlockRange returns the max number of blocks a logs filter query may scan
+func (k Keeper) GetMaxLogsBlockRange() uint64 {
+	return k.maxLogsBlockRange
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("modules/%s", types.ModuleName))
@@ -44,6 +73,158 @@ func (k *Keeper) GetLogs(ctx sdk.Context, hash sdk.Hash) []*types.Log {
 	return k.StateDB.WithContext(ctx).GetLogs(hash)
 }
 
+// GetReceipt returns the receipt of the first vm msg of the tx with the given hash, or nil when it
+// is not stored
+func (k *Keeper) GetReceipt(ctx sdk.Context, hash sdk.Hash) *types.Receipt {
+	return k.StateDB.WithContext(ctx).GetReceipt(hash)
+}
+
+// GetReceipts returns the receipts of the vm msgs of the tx with the given hash
+func (k *Keeper) GetReceipts(ctx sdk.Context, hash sdk.Hash) []*types.Receipt {
+	return k.StateDB.WithContext(ctx).GetReceipts(hash)
+}
+
+// SetReceipt appends the receipt of a vm msg to the receipts of its tx, the write is not charged as
+// the receipt holds the gas used by the tx
+func (k *Keeper) SetReceipt(ctx sdk.Context, receipt *types.Receipt) {
+	k.StateDB.WithContext(ctx.WithGasMeter(sdk.NewInfiniteGasMeter())).SetReceipt(receipt)
+}
+
+// GetBlockBloom returns the bloom of the logs of the block at the given height
+func (k *Keeper) GetBlockBloom(ctx sdk.Context, height uint64) ethtypes.Bloom {
+	return k.StateDB.WithContext(ctx).GetBlockBloom(height)
+}
+
+// FilterLogs returns the logs of the blocks in the range of the filter matching its addresses
+// and topics, the blocks whose logs bloom does not match the filter are skipped
+func (k *Keeper) FilterLogs(ctx sdk.Context, filter types.QueryFilterLogsParams) []*types.Log {
+	csdb := k.StateDB.WithContext(ctx)
+
+	logs := []*types.Log{}
+	for height := filter.FromBlock; height <= filter.ToBlock; height++ {
+		if !filter.BloomMatches(csdb.GetBlockBloom(height)) {
+			continue
+		}
+		logs = append(logs, filter.Filter(csdb.GetBlockLogs(height))...)
+	}
+	return logs
+}
+
 func (k *Keeper) GetAllHostContractAddresses(ctx sdk.Context) []sdk.AccAddress {
 	return k.StateDB.WithContext(ctx).GetAllHotContractAddrs()
 }
+
+// SetBlockHash stores the hash of the block at the given height and prunes the hash which left
+// the window of the BLOCKHASH opcode
+func (k Keeper) SetBlockHash(ctx sdk.Context, height uint64, hash sdk.Hash) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.BlockHashKey(height), hash.Bytes())
+
+	if height >= types.BlockHashWindow {
+		store.Delete(types.BlockHashKey(height - types.BlockHashWindow))
+	}
+}
+
+// GetBlockHash returns the hash of the block at the given height, or an empty hash when it is
+// not stored
+func (k Keeper) GetBlockHash(ctx sdk.Context, height uint64) sdk.Hash {
+	return sdk.BytesToHash(ctx.KVStore(k.storeKey).Get(types.BlockHashKey(height)))
+}
+
+// SetTxStateDiff stores the state diff of a tx, the write is not charged as the recording is
+// optional
+func (k Keeper) SetTxStateDiff(ctx sdk.Context, diff types.TxStateDiff) {
+	bz, err := json.Marshal(diff)
+	if err != nil {
+		k.Logger(ctx).Error(err.Error())
+		return
+	}
+
+	store := ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).KVStore(k.storeKey)
+	store.Set(types.StateDiffKey(diff.TxHash), bz)
+	store.Set(types.StateDiffHeightKey(diff.Height, diff.TxHash), []byte{})
+}
+
+// GetTxStateDiff returns the state diff of the tx with the given hash, or nil when it was not
+// recorded or has been pruned
+func (k Keeper) GetTxStateDiff(ctx sdk.Context, txHash sdk.Hash) *types.TxStateDiff {
+	bz := ctx.KVStore(k.storeKey).Get(types.StateDiffKey(txHash))
+	if bz == nil {
+		return nil
+	}
+
+	var diff types.TxStateDiff
+	if err := json.Unmarshal(bz, &diff); err != nil {
+		k.Logger(ctx).Error(err.Error())
+		return nil
+	}
+	return &diff
+}
+
+// PruneStateDiffs deletes the state diffs of the txs of the blocks at or below the given height
+func (k Keeper) PruneStateDiffs(ctx sdk.Context, height uint64) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.KeyPrefixStateDiffHeight, types.StateDiffHeightPrefix(height+1))
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	for _, key := range keys {
+		txHash := sdk.BytesToHash(key[len(types.StateDiffHeightPrefix(0)):])
+		store.Delete(types.StateDiffKey(txHash))
+		store.Delete(key)
+	}
+}
+
+// SetContractMetadata stores the metadata of a contract, replacing the previous one
+func (k Keeper) SetContractMetadata(ctx sdk.Context, metadata types.ContractMetadata) {
+	bz, err := json.Marshal(metadata)
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.ContractMetadataKey(metadata.Contract), bz)
+}
+
+// GetContractMetadata returns the metadata of the given contract, or nil when none was set
+func (k Keeper) GetContractMetadata(ctx sdk.Context, contract sdk.AccAddress) *types.ContractMetadata {
+	bz := ctx.KVStore(k.storeKey).Get(types.ContractMetadataKey(contract))
+	if bz == nil {
+		return nil
+	}
+
+	var metadata types.ContractMetadata
+	if err := json.Unmarshal(bz, &metadata); err != nil {
+		k.Logger(ctx).Error(err.Error())
+		return nil
+	}
+	return &metadata
+}
+
+// IterateContractMetadata calls the handler with the metadata of each contract, ordered by contract
+// address, until the handler returns true
+func (k Keeper) IterateContractMetadata(ctx sdk.Context, handler func(metadata types.ContractMetadata) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixContractMetadata)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var metadata types.ContractMetadata
+		if err := json.Unmarshal(iter.Value(), &metadata); err != nil {
+			panic(err)
+		}
+		if handler(metadata) {
+			break
+		}
+	}
+}
+
+// GetAllContractMetadata returns the metadata of all the contracts
+func (k Keeper) GetAllContractMetadata(ctx sdk.Context) (metadata []types.ContractMetadata) {
+	k.IterateContractMetadata(ctx, func(m types.ContractMetadata) bool {
+		metadata = append(metadata, m)
+		return false
+	})
+	return
+}
@@ -54,12 +54,36 @@ func (k Keeper) SetVMContractCreationGasParams(ctx sdk.Context, params types.VMC
 	k.paramstore.Set(ctx, types.KeyVMContractCreationGasParams, params)
 }
 
+// GetForkHeights return ForkHeights from store, no fork is scheduled when it was never set
+func (k Keeper) GetForkHeights(ctx sdk.Context) (forkHeights types.ForkHeights) {
+	k.paramstore.GetIfExists(ctx, types.KeyForkHeights, &forkHeights)
+	return
+}
+
+// SetForkHeights save ForkHeights to store
+func (k Keeper) SetForkHeights(ctx sdk.Context, forkHeights types.ForkHeights) {
+	k.paramstore.Set(ctx, types.KeyForkHeights, forkHeights)
+}
+
+// GetStateDiffRetention return StateDiffRetention from store, the recording is disabled when it was never set
+func (k Keeper) GetStateDiffRetention(ctx sdk.Context) (res uint64) {
+	k.paramstore.GetIfExists(ctx, types.KeyStateDiffRetention, &res)
+	return
+}
+
+// SetStateDiffRetention save StateDiffRetention to store
+func (k Keeper) SetStateDiffRetention(ctx sdk.Context, stateDiffRetention uint64) {
+	k.paramstore.Set(ctx, types.KeyStateDiffRetention, stateDiffRetention)
+}
+
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	return types.NewParams(
 		k.GetMaxCodeSize(ctx),
 		k.GetMaxCallCreateDepth(ctx),
 		k.GetVMOpGasParams(ctx),
 		k.GetVMContractCreationGasParams(ctx),
+		k.GetForkHeights(ctx),
+		k.GetStateDiffRetention(ctx),
 	)
 }
 
@@ -130,6 +130,7 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initPower int64) (sdk.Context
 	totalSupply := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, initTokens.MulRaw(int64(len(Addrs)))))
 
 	supplyKeeper.SetSupply(ctx, supply.NewSupply(totalSupply))
+	bankKeeper.SetSendEnabled(ctx, true)
 
 	keeper := NewKeeper(
 		cdc,
@@ -138,6 +139,7 @@ func CreateTestInput(t *testing.T, isCheckTx bool, initPower int64) (sdk.Context
 		accountKeeper,
 	)
 	keeper.SetParams(ctx, types.DefaultParams())
+	keeper.SetNativeKeepers(types.NativeKeepers{BankKeeper: bankKeeper})
 
 	supplyKeeper.SetModuleAccount(ctx, feeCollectorAcc)
 
@@ -84,6 +84,9 @@ func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.Va
 	am.keeper.SetParams(ctx, genesisState.Params)
 
 	am.keeper.StateDB.WithContext(ctx).ImportState(genesisState)
+	for _, metadata := range genesisState.ContractMetadata {
+		am.keeper.SetContractMetadata(ctx, metadata)
+	}
 
 	return nil
 }
@@ -92,6 +95,7 @@ func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.Va
 func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
 	vmState := am.keeper.StateDB.WithContext(ctx).ExportState()
 	vmState.Params = am.keeper.GetParams(ctx)
+	vmState.ContractMetadata = am.keeper.GetAllContractMetadata(ctx)
 	return ModuleCdc.MustMarshalJSON(vmState)
 }
 
@@ -116,6 +120,7 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 
 // BeginBlock function for module at start of each block
 func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
+	BeginBlocker(ctx, req, am.keeper)
 }
 
 // EndBlock function for module at end of block
@@ -0,0 +1,35 @@
+package vm
+
+import (
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+// NewParamChangeProposalHandler wraps the handler of the param change proposals to reject the
+// changes of the vm fork heights which move an active fork or activate a fork in the past
+func NewParamChangeProposalHandler(k Keeper, next govtypes.Handler) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content, pid uint64, proposer sdk.AccAddress) error {
+		if c, ok := content.(params.ParameterChangeProposal); ok {
+			for _, change := range c.Changes {
+				if change.Subspace != DefaultParamspace || change.Key != string(types.KeyForkHeights) {
+					continue
+				}
+
+				// the change is applied on top of the current value, as the subspace does
+				forkHeights := k.GetForkHeights(ctx)
+				changed := forkHeights
+				if err := types.ModuleCdc.UnmarshalJSON([]byte(change.Value), &changed); err != nil {
+					return sdkerrors.Wrapf(params.ErrSettingParameter, "key: %s, value: %s, err: %s", change.Key, change.Value, err.Error())
+				}
+				if err := forkHeights.ValidateChange(changed, ctx.BlockHeight()); err != nil {
+					return sdkerrors.Wrapf(params.ErrSettingParameter, "key: %s, value: %s, err: %s", change.Key, change.Value, err.Error())
+				}
+			}
+		}
+
+		return next(ctx, content, pid, proposer)
+	}
+}
@@ -0,0 +1,47 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	govtypes "github.com/netcloth/netcloth-chain/app/v0/gov/types"
+	"github.com/netcloth/netcloth-chain/app/v0/params"
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestParamChangeProposalHandlerForkHeights(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	ctx = ctx.WithBlockHeight(100)
+	vmParams := vmKeeper.GetParams(ctx)
+	vmParams.ForkHeights = types.ForkHeights{Berlin: 50, London: 200}
+	vmKeeper.SetParams(ctx, vmParams)
+
+	var handled int
+	handler := NewParamChangeProposalHandler(vmKeeper, func(sdk.Context, govtypes.Content, uint64, sdk.AccAddress) error {
+		handled++
+		return nil
+	})
+	propose := func(subspace, key string, value interface{}) error {
+		change := params.NewParamChange(subspace, key, string(types.ModuleCdc.MustMarshalJSON(value)))
+		return handler(ctx, params.NewParameterChangeProposal("title", "description", []params.ParamChange{change}), 1, nil)
+	}
+	forkHeights := string(types.KeyForkHeights)
+
+	// the forks to come can be rescheduled or scheduled after the current height
+	require.NoError(t, propose(DefaultParamspace, forkHeights, types.ForkHeights{Berlin: 50, London: 300}))
+	require.NoError(t, propose(DefaultParamspace, forkHeights, types.ForkHeights{Berlin: 50, London: 200, Shanghai: 250}))
+
+	// the active forks can not be moved
+	require.True(t, params.ErrSettingParameter.Is(propose(DefaultParamspace, forkHeights, types.ForkHeights{Berlin: 60, London: 200})))
+	require.True(t, params.ErrSettingParameter.Is(propose(DefaultParamspace, forkHeights, types.ForkHeights{London: 200})))
+
+	// a fork can not be activated at a past or the current height
+	require.True(t, params.ErrSettingParameter.Is(propose(DefaultParamspace, forkHeights, types.ForkHeights{Berlin: 50, London: 100})))
+
+	// the other params are not checked
+	require.NoError(t, propose(DefaultParamspace, string(types.KeyMaxCodeSize), uint64(1024)))
+	require.Equal(t, 3, handled)
+}
@@ -50,6 +50,21 @@ const (
 	ExtcodeHashGas  uint64 = 700  // Cost of EXTCODEHASH after EIP 1884 (part in Istanbul)
 	SelfdestructGas uint64 = 5000 // Cost of SELFDESTRUCT post EIP 150 (Tangerine)
 
+	// Berlin access list gas (EIP-2929, EIP-2930)
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // COLD_ACCOUNT_ACCESS_COST
+	ColdSloadCostEIP2929         uint64 = 2100 // COLD_SLOAD_COST
+	WarmStorageReadCostEIP2929   uint64 = 100  // WARM_STORAGE_READ_COST
+	SstoreSetGasEIP2200          uint64 = 20000
+	SstoreResetGasEIP2200        uint64 = 5000
+	SelfdestructGasEIP150        uint64 = 5000
+	TxAccessListAddressGas       uint64 = 2400 // Per address specified in EIP 2930 access list
+	TxAccessListStorageKeyGas    uint64 = 1900 // Per storage key specified in EIP 2930 access list
+
+	// London refunds (EIP-3529)
+	SstoreClearsScheduleRefundEIP3529 uint64 = SstoreResetGasEIP2200 - ColdSloadCostEIP2929 + TxAccessListStorageKeyGas
+	RefundQuotient                    uint64 = 2 // Max refund of a tx is gas used / RefundQuotient before EIP-3529
+	RefundQuotientEIP3529             uint64 = 5 // Max refund of a tx is gas used / RefundQuotientEIP3529 after EIP-3529
+
 	// EXP has a dynamic portion depending on the size of the exponent
 	ExpByte uint64 = 50 // was raised to 50 during Eip158 (Spurious Dragon)
 
@@ -79,4 +94,10 @@ const (
 	Bn256ScalarMulGas       uint64 = 6000  // Gas needed for an elliptic curve scalar multiplication
 	Bn256PairingBaseGas     uint64 = 45000 // Base price for an elliptic curve pairing check
 	Bn256PairingPerPointGas uint64 = 34000 // Per-point price for an elliptic curve pairing check
+
+	// Native precompiled contract gas prices
+
+	NativeQueryGas    uint64 = 2000  // Gas needed for a query of a native module
+	NativeTransferGas uint64 = 25000 // Gas needed for a transfer of non-native tokens
+	NativeStakingGas  uint64 = 80000 // Gas needed for a delegation or an undelegation
 )
@@ -3,6 +3,7 @@ package vm
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strconv"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
@@ -32,6 +33,20 @@ func NewQuerier(k keeper.Keeper) sdk.Querier {
 			return queryTxLogs(ctx, path, k)
 		case types.EstimateGas, types.QueryCall:
 			return simulateStateTransition(ctx, req, k)
+		case types.QueryTrace:
+			return queryTrace(ctx, req, k)
+		case types.QuerySimulate:
+			return querySimulate(ctx, req, k)
+		case types.QueryStateDiff:
+			return queryStateDiff(ctx, path, k)
+		case types.QueryContractMetadata:
+			return queryContractMetadata(ctx, path, k)
+		case types.QueryReceipt:
+			return queryReceipt(ctx, path, k)
+		case types.QueryFilterLogs:
+			return queryFilterLogs(ctx, req, k)
+		case types.QueryBlockBloom:
+			return queryBlockBloom(ctx, path, k)
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown query path: %s", path[0])
 		}
@@ -55,7 +70,10 @@ func queryState(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) (res []
 		return nil, err
 	}
 
-	stateObjects := k.StateDB.WithContext(ctx).ExportStateObjects(params)
+	// export from a fresh state db, the objects cached by the keeper are those of the latest state
+	stateDB := types.NewStateDB(k.StateDB).WithContext(ctx)
+	stateDB.LoadStateObjects()
+	stateObjects := stateDB.ExportStateObjects(params)
 	res, err = json.Marshal(stateObjects)
 	return
 }
@@ -65,7 +83,8 @@ func queryCode(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
 	}
-	code := k.GetCode(ctx, addr)
+	// a fresh StateDB reads the store of the queried height rather than the cached state objects
+	code := types.NewStateDB(k.StateDB).WithContext(ctx).GetCode(addr)
 
 	return code, nil
 }
@@ -73,7 +92,7 @@ func queryCode(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error)
 func queryStorage(ctx sdk.Context, path []string, keeper keeper.Keeper) ([]byte, error) {
 	addr, _ := sdk.AccAddressFromBech32(path[1])
 	key := sdk.HexToHash(path[2])
-	val := keeper.GetState(ctx, addr, key)
+	val := types.NewStateDB(keeper.StateDB).WithContext(ctx).GetState(addr, key)
 	bRes := types.QueryStorageResult{Value: val}
 	res, err := codec.MarshalJSONIndent(keeper.Cdc, bRes)
 	if err != nil {
@@ -95,6 +114,118 @@ func queryTxLogs(ctx sdk.Context, path []string, keeper keeper.Keeper) ([]byte,
 	return res, nil
 }
 
+func queryReceipt(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "missing tx hash")
+	}
+
+	// the receipt of the vm msg at the optional index, among the vm msgs of the tx
+	var index uint64
+	if len(path) > 2 {
+		var err error
+		if index, err = strconv.ParseUint(path[2], 10, 64); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+		}
+	}
+
+	receipts := k.GetReceipts(ctx, sdk.HexToHash(path[1]))
+	if index >= uint64(len(receipts)) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "receipt %d of tx %s not found", index, path[1])
+	}
+
+	res, err := json.Marshal(receipts[index])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryStateDiff(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "missing tx hash")
+	}
+
+	diff := k.GetTxStateDiff(ctx, sdk.HexToHash(path[1]))
+	if diff == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "state diff of tx %s not found, it was not recorded or has been pruned", path[1])
+	}
+
+	res, err := json.Marshal(diff)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryContractMetadata(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "missing contract address")
+	}
+
+	contract, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	metadata := k.GetContractMetadata(ctx, contract)
+	if metadata == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "metadata of contract %s not found", path[1])
+	}
+
+	res, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryFilterLogs(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) ([]byte, error) {
+	var params types.QueryFilterLogsParams
+	if err := codec.Cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if err := params.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+	}
+
+	latest := uint64(ctx.BlockHeight())
+	if params.ToBlock == 0 || params.ToBlock > latest {
+		params.ToBlock = latest
+	}
+	if params.FromBlock == 0 {
+		params.FromBlock = params.ToBlock
+	}
+	if params.FromBlock > params.ToBlock {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "from block %d is greater than the latest block %d", params.FromBlock, latest)
+	}
+
+	maxRange := k.GetMaxLogsBlockRange()
+	if params.ToBlock-params.FromBlock >= maxRange {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "block range too large, max %d blocks", maxRange)
+	}
+
+	bRes := types.QueryLogsResult{Logs: k.FilterLogs(ctx, params)}
+	res, err := codec.MarshalJSONIndent(k.Cdc, bRes)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
+func queryBlockBloom(ctx sdk.Context, path []string, k keeper.Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "missing block height")
+	}
+
+	height, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+	}
+
+	bloom := k.GetBlockBloom(ctx, height)
+	return bloom.Bytes(), nil
+}
+
 func simulateStateTransition(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) ([]byte, error) {
 	var msg types.MsgContract
 	codec.Cdc.UnmarshalJSON(req.Data, &msg)
@@ -110,5 +241,79 @@ func simulateStateTransition(ctx sdk.Context, req abci.RequestQuery, k keeper.Ke
 		return res, nil
 	}
 
-	return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "StateTransition faileds")
+	return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "state transition failed at height %d: %s", ctx.BlockHeight(), err)
+}
+
+func queryTrace(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) ([]byte, error) {
+	var params types.QueryTraceParams
+	if err := codec.Cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if err := params.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+	}
+
+	// replay on the context of the block the msg was included in
+	if params.Height > 0 {
+		ctx = ctx.WithBlockHeight(params.Height).WithBlockTime(params.Time)
+	}
+	ctx, _ = ctx.CacheContext()
+
+	// an ad-hoc call is traced as the only msg of a tx of the sender
+	tx := types.TraceTx{Signers: []sdk.AccAddress{params.Msg.From}}
+	if params.Tx != nil {
+		tx = *params.Tx
+	}
+
+	if params.Tracer == types.TracerCall {
+		tracer := NewCallTracer()
+		if _, err := TraceStateTransition(ctx, params.Preceding, tx, params.Msg, k, tracer); err != nil && tracer.CallFrame() == nil {
+			return nil, err
+		}
+
+		frame := tracer.CallFrame()
+		frame.Approximate = params.Approximate()
+		return json.Marshal(frame)
+	}
+
+	tracer := NewStructLogger(&LogConfig{
+		DisableMemory:  params.DisableMemory,
+		DisableStack:   params.DisableStack,
+		DisableStorage: params.DisableStorage,
+		Limit:          params.Limit,
+	})
+	res, err := TraceStateTransition(ctx, params.Preceding, tx, params.Msg, k, tracer)
+	if err != nil && res == nil {
+		return nil, err
+	}
+
+	return json.Marshal(types.TraceResult{
+		Gas:         res.GasUsed,
+		Failed:      tracer.Error() != nil,
+		ReturnValue: hex.EncodeToString(tracer.Output()),
+		StructLogs:  FormatStructLogs(tracer.StructLogs()),
+		Approximate: params.Approximate(),
+	})
+}
+
+func querySimulate(ctx sdk.Context, req abci.RequestQuery, k keeper.Keeper) ([]byte, error) {
+	var params types.QuerySimulateParams
+	if err := codec.Cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if err := params.ValidateBasic(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+	}
+
+	ctx, _ = ctx.CacheContext()
+	results, diff, err := SimulateStateTransitions(ctx, params.Msgs, params.Overrides, k)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "simulation failed at height %d: %s", ctx.BlockHeight(), err)
+	}
+
+	res, err := json.Marshal(types.SimulateResult{Results: results, StateDiff: diff})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
 }
@@ -0,0 +1,365 @@
+package vm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	keep "github.com/netcloth/netcloth-chain/app/v0/vm/keeper"
+	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	"github.com/netcloth/netcloth-chain/store"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestQueryTrace(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	querier := NewQuerier(vmKeeper)
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	code := sdk.FromHex(strings.TrimSpace(string(bc)))
+
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	zero := sdk.NewInt64Coin(sdk.NativeTokenName, 0)
+	msgCreate := types.NewMsgContract(acc.GetAddress(), nil, code, zero)
+
+	// the create is replayed with the sender nonce incremented as the ante handler would
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence()+1)
+	msgCall := types.NewMsgContract(acc.GetAddress(), contractAddr, sdk.FromHex("bf335e62"), zero)
+
+	query := func(params types.QueryTraceParams) []byte {
+		bz, err := codec.Cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		res, err := querier(ctx, []string{types.QueryTrace}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+		return res
+	}
+
+	// struct tracer
+	params := types.NewQueryTraceParams(msgCall, types.TracerStruct)
+	params.Preceding = []types.TraceTx{{Signers: []sdk.AccAddress{acc.GetAddress()}, Msgs: []types.MsgContract{msgCreate}}}
+	var traceResult types.TraceResult
+	require.NoError(t, json.Unmarshal(query(params), &traceResult))
+	require.False(t, traceResult.Failed)
+	require.NotEmpty(t, traceResult.StructLogs)
+	require.Equal(t, "PUSH1", traceResult.StructLogs[0].Op)
+	require.NotNil(t, traceResult.StructLogs[0].Stack)
+
+	params.DisableStack, params.Limit = true, 10
+	traceResult = types.TraceResult{}
+	require.NoError(t, json.Unmarshal(query(params), &traceResult))
+	require.Len(t, traceResult.StructLogs, 10)
+	require.Nil(t, traceResult.StructLogs[0].Stack)
+
+	// call tracer
+	params = types.NewQueryTraceParams(msgCall, types.TracerCall)
+	params.Preceding = []types.TraceTx{{Signers: []sdk.AccAddress{acc.GetAddress()}, Msgs: []types.MsgContract{msgCreate}}}
+	var frame types.CallFrame
+	require.NoError(t, json.Unmarshal(query(params), &frame))
+	require.Equal(t, CALL.String(), frame.Type)
+	require.Equal(t, contractAddr, frame.To)
+	require.Empty(t, frame.Error)
+	require.Len(t, frame.Calls, 2)
+	for _, call := range frame.Calls {
+		require.Equal(t, CREATE.String(), call.Type)
+		require.Equal(t, contractAddr, call.From)
+		require.False(t, call.To.Empty())
+		require.NotZero(t, call.GasUsed)
+	}
+
+	// the replayed state changes are discarded
+	require.Nil(t, vmKeeper.StateDB.WithContext(ctx).GetCode(contractAddr))
+
+	// unknown tracer
+	bz, err := codec.Cdc.MarshalJSON(types.NewQueryTraceParams(msgCall, "unknown"))
+	require.NoError(t, err)
+	_, err = querier(ctx, []string{types.QueryTrace}, abci.RequestQuery{Data: bz})
+	require.Error(t, err)
+}
+
+func TestQueryTraceReplay(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	querier := NewQuerier(vmKeeper)
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	code := sdk.FromHex(strings.TrimSpace(string(bc)))
+
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	zero := sdk.NewInt64Coin(sdk.NativeTokenName, 0)
+	msgCreate := types.NewMsgContract(acc.GetAddress(), nil, code, zero)
+
+	trace := func(params types.QueryTraceParams) (frame types.CallFrame) {
+		bz, err := codec.Cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		res, err := querier(ctx, []string{types.QueryTrace}, abci.RequestQuery{Data: bz})
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(res, &frame))
+		return
+	}
+
+	// the sequence of the signer is incremented once per tx, not once per msg
+	precedingTx := types.TraceTx{
+		Signers:  []sdk.AccAddress{acc.GetAddress()},
+		FeePayer: acc.GetAddress(),
+		Fee:      sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 100)),
+		Msgs:     []types.MsgContract{msgCreate, msgCreate},
+	}
+	params := types.NewQueryTraceParams(msgCreate, types.TracerCall)
+	params.Preceding = []types.TraceTx{precedingTx}
+	frame := trace(params)
+	require.Equal(t, CreateAddress(acc.GetAddress(), acc.GetSequence()+2), frame.To)
+	require.False(t, frame.Approximate)
+
+	// the ante handler of a tx whose fee can't be paid has no effect
+	precedingTx.Fee = sdk.NewCoins(sdk.NewCoin(sdk.NativeTokenName, acc.GetCoins().AmountOf(sdk.NativeTokenName).AddRaw(1)))
+	params.Preceding = []types.TraceTx{precedingTx}
+	frame = trace(params)
+	require.Equal(t, CreateAddress(acc.GetAddress(), acc.GetSequence()+1), frame.To)
+
+	// the msgs of a failed tx are not replayed
+	precedingTx.Fee, precedingTx.Failed = nil, true
+	params.Preceding = []types.TraceTx{precedingTx, precedingTx}
+	frame = trace(params)
+	require.Equal(t, CreateAddress(acc.GetAddress(), acc.GetSequence()+3), frame.To)
+	require.False(t, frame.Approximate)
+
+	// the trace is approximate when non vm msgs precede the traced msg
+	precedingTx.Failed, precedingTx.Skipped = false, 1
+	params.Preceding = []types.TraceTx{precedingTx}
+	require.True(t, trace(params).Approximate)
+}
+
+func TestQuerySimulate(t *testing.T) {
+	ctx, accountKeeper, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	querier := NewQuerier(vmKeeper)
+
+	bc, err := ioutil.ReadFile("./testdata/opCreate/a.bc")
+	require.NoError(t, err)
+	code := sdk.FromHex(strings.TrimSpace(string(bc)))
+
+	acc := accountKeeper.GetAccount(ctx, keep.Addrs[0])
+	zero := sdk.NewInt64Coin(sdk.NativeTokenName, 0)
+	contractAddr := CreateAddress(acc.GetAddress(), acc.GetSequence()+1)
+	payer, payee := keep.Addrs[2], keep.Addrs[3]
+	payeeBalance := accountKeeper.GetAccount(ctx, payee).GetCoins().AmountOf(sdk.NativeTokenName)
+
+	balance, nonce, empty := sdk.NewInt(5), uint64(7), sdk.Code{}
+	params := types.NewQuerySimulateParams(
+		[]types.MsgContract{
+			types.NewMsgContract(acc.GetAddress(), nil, code, zero),
+			types.NewMsgContract(acc.GetAddress(), contractAddr, sdk.FromHex("bf335e62"), zero),
+			types.NewMsgContract(payer, payee, nil, sdk.NewInt64Coin(sdk.NativeTokenName, 3)),
+		},
+		[]types.AccountOverride{
+			{Address: payer, Balance: &balance, Nonce: &nonce, Code: &empty},
+			{Address: keep.Addrs[4], Storage: []types.StorageOverride{{Key: sdk.BytesToHash([]byte{1}), Value: sdk.BytesToHash([]byte{2})}}},
+		},
+	)
+	bz, err := codec.Cdc.MarshalJSON(params)
+	require.NoError(t, err)
+	bz, err = querier(ctx, []string{types.QuerySimulate}, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+
+	var res types.SimulateResult
+	require.NoError(t, json.Unmarshal(bz, &res))
+	require.Len(t, res.Results, 3)
+	for _, r := range res.Results {
+		require.False(t, r.Failed)
+		require.NotZero(t, r.Gas)
+	}
+	require.Equal(t, contractAddr, res.Results[0].ContractAddress)
+
+	diffs := make(map[string]types.AccountDiff)
+	for _, d := range res.StateDiff {
+		diffs[d.Address.String()] = d
+	}
+
+	// the sender nonce is incremented before each msg, the contract creates two contracts
+	d := diffs[acc.GetAddress().String()]
+	require.Equal(t, acc.GetSequence(), d.PreNonce)
+	require.Equal(t, acc.GetSequence()+2, d.PostNonce)
+	d = diffs[contractAddr.String()]
+	require.Empty(t, d.PreCode)
+	require.NotEmpty(t, d.PostCode)
+	require.Len(t, res.StateDiff, 6)
+
+	// the transfer runs on the overridden balance and nonce of the payer
+	d = diffs[payer.String()]
+	require.Equal(t, sdk.NewInt(5), d.PreBalance)
+	require.Equal(t, sdk.NewInt(2), d.PostBalance)
+	require.Equal(t, nonce, d.PreNonce)
+	d = diffs[payee.String()]
+	require.Equal(t, payeeBalance.AddRaw(3), d.PostBalance)
+
+	// nothing is committed
+	require.Nil(t, vmKeeper.GetCode(ctx, contractAddr))
+	require.Equal(t, acc.GetSequence(), accountKeeper.GetAccount(ctx, acc.GetAddress()).GetSequence())
+
+	// invalid overrides are rejected
+	params.Overrides = append(params.Overrides, types.AccountOverride{Address: payer})
+	bz, err = codec.Cdc.MarshalJSON(params)
+	require.NoError(t, err)
+	_, err = querier(ctx, []string{types.QuerySimulate}, abci.RequestQuery{Data: bz})
+	require.Error(t, err)
+}
+
+func TestQueryAtHeight(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	querier := NewQuerier(vmKeeper)
+	cms := ctx.MultiStore().(sdk.CommitMultiStore)
+	cms.SetPruning(store.PruneNothing)
+
+	addr := keep.Addrs[1]
+	key := sdk.BytesToHash([]byte("key"))
+	commit := func(code []byte, value sdk.Hash) {
+		stateDB := vmKeeper.StateDB.WithContext(ctx)
+		stateDB.SetCode(addr, code)
+		stateDB.SetState(addr, key, value)
+		stateDB.Finalise(false)
+		_, err := stateDB.Commit(false)
+		require.NoError(t, err)
+		cms.Commit()
+	}
+	commit([]byte{0x01}, sdk.BytesToHash([]byte{0x01}))
+	commit([]byte{0x02}, sdk.BytesToHash([]byte{0x02}))
+
+	cacheMS, err := cms.CacheMultiStoreWithVersion(1)
+	require.NoError(t, err)
+	pastCtx := ctx.WithMultiStore(cacheMS).WithBlockHeight(1)
+
+	// code and storage are read from the store of the given height, not from the cached latest state
+	code, err := querier(pastCtx, []string{types.QueryCode, addr.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, code)
+	code, err = querier(ctx, []string{types.QueryCode, addr.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x02}, code)
+
+	var storage types.QueryStorageResult
+	bz, err := querier(pastCtx, []string{types.QueryStorage, addr.String(), key.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	require.NoError(t, codec.Cdc.UnmarshalJSON(bz, &storage))
+	require.Equal(t, sdk.BytesToHash([]byte{0x01}), storage.Value)
+
+	// state exports the contracts of the given height
+	bz, err = json.Marshal(types.QueryStateParams{ContractOnly: true, ShowCode: true})
+	require.NoError(t, err)
+	bz, err = querier(pastCtx, []string{types.QueryState}, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+	var sos []struct {
+		Address sdk.AccAddress `json:"address"`
+		Code    sdk.Code       `json:"code"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &sos))
+	require.Len(t, sos, 1)
+	require.Equal(t, addr, sos[0].Address)
+	require.Equal(t, sdk.Code{0x01}, sos[0].Code)
+
+	// heights that were never committed or have been pruned cannot be loaded
+	_, err = cms.CacheMultiStoreWithVersion(10)
+	require.Error(t, err)
+}
+
+func TestQueryFilterLogs(t *testing.T) {
+	ctx, _, vmKeeper, _ := keep.CreateTestInput(t, false, 1000000)
+	querier := NewQuerier(vmKeeper)
+
+	contractA, contractB := keep.Addrs[0], keep.Addrs[1]
+	transfer, approval := sdk.BytesToHash([]byte("Transfer")), sdk.BytesToHash([]byte("Approval"))
+	alice, bob := sdk.BytesToHash([]byte("alice")), sdk.BytesToHash([]byte("bob"))
+
+	// emit stores the logs of a successful tx of the block at the given height
+	emit := func(height int64, txHash sdk.Hash, logs ...*types.Log) {
+		ctx := ctx.WithBlockHeight(height)
+		csdb := vmKeeper.StateDB.WithContext(ctx)
+		csdb.Prepare(txHash, sdk.Hash{}, 0)
+		for _, log := range logs {
+			csdb.AddLog(log)
+		}
+		receipt := &types.Receipt{
+			TxHash:      txHash,
+			BlockNumber: uint64(height),
+			Status:      types.ReceiptStatusSuccessful,
+			Logs:        csdb.GetLogs(txHash),
+			LogsBloom:   types.LogsBloom(logs),
+		}
+		csdb.Finalise(true)
+		vmKeeper.SetReceipt(ctx, receipt)
+	}
+
+	emit(2, sdk.BytesToHash([]byte("tx1")), &types.Log{Address: contractA, Topics: []sdk.Hash{transfer, alice}})
+	emit(3, sdk.BytesToHash([]byte("tx2")),
+		&types.Log{Address: contractB, Topics: []sdk.Hash{transfer, bob}},
+		&types.Log{Address: contractA, Topics: []sdk.Hash{approval}})
+	emit(5, sdk.BytesToHash([]byte("tx3")), &types.Log{Address: contractA, Topics: []sdk.Hash{transfer, bob}})
+
+	ctx = ctx.WithBlockHeight(10)
+	query := func(params types.QueryFilterLogsParams) ([]*types.Log, error) {
+		bz, err := codec.Cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		res, err := querier(ctx, []string{types.QueryFilterLogs}, abci.RequestQuery{Data: bz})
+		if err != nil {
+			return nil, err
+		}
+		var out types.QueryLogsResult
+		require.NoError(t, vmKeeper.Cdc.UnmarshalJSON(res, &out))
+		return out.Logs, nil
+	}
+
+	testCases := []struct {
+		addresses []sdk.AccAddress
+		topics    [][]sdk.Hash
+		expected  []uint64
+	}{
+		{nil, nil, []uint64{2, 3, 3, 5}},
+		{[]sdk.AccAddress{contractA}, nil, []uint64{2, 3, 5}},
+		{nil, [][]sdk.Hash{{transfer}, {bob}}, []uint64{3, 5}},
+		{nil, [][]sdk.Hash{nil, {alice, bob}}, []uint64{2, 3, 5}},
+		{[]sdk.AccAddress{contractA, contractB}, [][]sdk.Hash{{approval}}, []uint64{3}},
+		{[]sdk.AccAddress{contractB}, [][]sdk.Hash{{transfer}, {alice}}, nil},
+	}
+
+	for i, tc := range testCases {
+		logs, err := query(types.NewQueryFilterLogsParams(1, 0, tc.addresses, tc.topics))
+		require.NoError(t, err, "case %d", i)
+
+		var heights []uint64
+		for _, log := range logs {
+			heights = append(heights, log.BlockNumber)
+		}
+		require.Equal(t, tc.expected, heights, "case %d", i)
+	}
+
+	// logs are ordered by their index within a block
+	logs, err := query(types.NewQueryFilterLogsParams(3, 3, nil, nil))
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, contractB, logs[0].Address)
+	require.True(t, logs[0].Index < logs[1].Index)
+
+	// the block bloom holds the addresses and topics of the logs of the block
+	res, err := querier(ctx, []string{types.QueryBlockBloom, "3"}, abci.RequestQuery{})
+	require.NoError(t, err)
+	bloom := ethtypes.BytesToBloom(res)
+	require.True(t, types.NewQueryFilterLogsParams(0, 0, []sdk.AccAddress{contractB}, [][]sdk.Hash{{approval}}).BloomMatches(bloom))
+	require.Equal(t, ethtypes.Bloom{}, vmKeeper.GetBlockBloom(ctx, 4))
+
+	// the block range is bounded by the node config
+	vmKeeper.SetMaxLogsBlockRange(5)
+	querier = NewQuerier(vmKeeper)
+	_, err = query(types.NewQueryFilterLogsParams(1, 10, nil, nil))
+	require.Error(t, err)
+	logs, err = query(types.NewQueryFilterLogsParams(3, 7, nil, nil))
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	_, err = query(types.NewQueryFilterLogsParams(5, 3, nil, nil))
+	require.Error(t, err)
+}
@@ -1,10 +1,10 @@
 package vm
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 
-	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 
 	"github.com/netcloth/netcloth-chain/app/v0/vm/types"
@@ -18,6 +18,10 @@ type StateTransition struct {
 	Amount    sdk.Int
 	Payload   []byte
 	StateDB   *types.CommitStateDB
+	Tracer    Tracer // optional, enables vm debug mode when set
+
+	// AccessList is the optional EIP-2930 access list of the tx
+	AccessList types.AccessList
 }
 
 func (st StateTransition) CanTransfer(acc sdk.AccAddress, amount *big.Int) bool {
@@ -29,26 +33,29 @@ func (st StateTransition) Transfer(from, to sdk.AccAddress, amount *big.Int) {
 	st.StateDB.AddBalance(to, amount)
 }
 
-func (st StateTransition) GetHashFn(header abci.Header) func() sdk.Hash {
-	return func() sdk.Hash {
-		var res = sdk.Hash{}
-		blockID := header.GetLastBlockId()
-		res.SetBytes(blockID.GetHash())
-		return res
+// GetHashFn returns a GetHashFunc reading the block hashes stored by the BeginBlocker, the reads
+// are not charged as the BLOCKHASH opcode has its own gas cost
+func (st StateTransition) GetHashFn(ctx sdk.Context, k Keeper) GetHashFunc {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	return func(height uint64) sdk.Hash {
+		return k.GetBlockHash(ctx, height)
 	}
 }
 
-func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *sdk.Result, error) {
+// TransitionCSDB executes the state transition and returns its receipt along with its result
+func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*types.Receipt, *sdk.Result, error) {
 	logger := k.Logger(ctx)
 
 	evmCtx := Context{
 		CanTransfer: st.CanTransfer,
 		Transfer:    st.Transfer,
-		GetHash:     st.GetHashFn(ctx.BlockHeader()),
+		GetHash:     st.GetHashFn(ctx, k),
 		Origin:      st.Sender,
 		CoinBase:    ctx.BlockHeader().ProposerAddress,
 		Time:        sdk.NewInt(ctx.BlockHeader().Time.Unix()).BigInt(),
 		BlockNumber: sdk.NewInt(ctx.BlockHeader().Height).BigInt(),
+
+		NativeKeepers: k.NativeKeepers,
 	}
 
 	gasLimitForVM := uint64(DefaultVMGasLimit)
@@ -63,22 +70,47 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 	vmParams := k.GetParams(ctx) // will consume gas
 	st.StateDB.UpdateAccounts()  // will consume gas
 
+	chainConfig := NewChainConfig(types.ChainIDFromString(ctx.ChainID()), vmParams.ForkHeights)
+	rules := chainConfig.Rules(evmCtx.BlockNumber)
+	if len(st.AccessList) > 0 {
+		if !rules.IsBerlin {
+			return nil, &sdk.Result{Data: nil}, ErrAccessListNotActive
+		}
+		accessListGas := uint64(len(st.AccessList))*TxAccessListAddressGas + uint64(st.AccessList.StorageKeys())*TxAccessListStorageKeyGas
+		ctx.GasMeter().ConsumeGas(accessListGas, "EIP-2930 access list")
+	}
+
+	// the refund counter of a failed tx is not cleared until the end of the block
+	st.StateDB.ResetRefund()
+	if rules.IsBerlin {
+		var coinbase sdk.AccAddress
+		if rules.IsShanghai {
+			coinbase = evmCtx.CoinBase
+		}
+		st.StateDB.PrepareAccessList(st.Sender, st.Recipient, precompiledAddresses, coinbase, st.AccessList)
+	}
+
 	cfg := Config{
 		OpConstGasConfig:          &vmParams.VMOpGasParams,
 		ContractCreationGasConfig: &vmParams.VMContractCreationGasParams,
 		MaxCodeSize:               vmParams.MaxCodeSize,
 		MaxCallCreateDepth:        vmParams.MaxCallCreateDepth,
 	}
-	evm := NewEVM(evmCtx, st.StateDB.WithContext(ctx.WithGasMeter(gasMeterForEvm)), cfg)
+	if st.Tracer != nil {
+		cfg.Debug = true
+		cfg.Tracer = st.Tracer
+	}
+	evm := NewEVM(evmCtx, st.StateDB.WithContext(ctx.WithGasMeter(gasMeterForEvm)), chainConfig, cfg)
 
 	var (
-		ret         []byte
-		leftOverGas uint64
-		vmerr       error
+		ret          []byte
+		contractAddr sdk.AccAddress
+		leftOverGas  uint64
+		vmerr        error
 	)
 
 	if st.Recipient.Empty() {
-		ret, _, leftOverGas, vmerr = evm.Create(st.Sender, st.Payload, gasLimitForVM, st.Amount.BigInt())
+		ret, contractAddr, leftOverGas, vmerr = evm.Create(st.Sender, st.Payload, gasLimitForVM, st.Amount.BigInt())
 		logger.Info(fmt.Sprintf("create contract, consumed gas = %v, leftOverGas = %v, vm err = %v ", gasLimitForVM-leftOverGas, leftOverGas, vmerr))
 	} else {
 		ret, leftOverGas, vmerr = evm.Call(st.Sender, st.Recipient, st.Payload, gasLimitForVM, st.Amount.BigInt())
@@ -93,28 +125,72 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context, k Keeper) (*big.Int, *
 		logger.Info(fmt.Sprintf("call contract, ret = %x, consumed gas = %v, leftOverGas = %v, vm err = %v", ret, gasLimitForVM-leftOverGas, leftOverGas, vmerr))
 	}
 
+	if rules.IsLondon {
+		// the refunds are only applied from the london fork, with the EIP-3529 cap
+		leftOverGas += refundGas(gasLimitForVM-leftOverGas, st.StateDB.GetRefund(), RefundQuotientEIP3529)
+	}
 	vmGasUsed := gasLimitForVM - leftOverGas
 
+	receipt := &types.Receipt{
+		TxHash:          st.StateDB.TxHash(),
+		BlockNumber:     uint64(ctx.BlockHeight()),
+		From:            st.Sender,
+		To:              st.Recipient,
+		ContractAddress: contractAddr,
+		Ret:             ret,
+	}
+
 	if vmerr != nil {
 		ctx.EventManager().Clear()
-		return nil, &sdk.Result{Data: ret, GasUsed: curGasMeter.GasConsumed() + vmGasUsed}, vmerr
+
+		receipt.Status = types.ReceiptStatusFailed
+		receipt.Error = vmerr.Error()
+		if vmerr == ErrExecutionReverted {
+			receipt.RevertReason = types.UnpackRevertReason(ret)
+		}
+		receipt.GasUsed = curGasMeter.GasConsumed() + vmGasUsed
+		receipt.CumulativeGasUsed = cumulativeGasUsed(ctx, receipt.GasUsed)
+		return receipt, &sdk.Result{Data: ret, GasUsed: curGasMeter.GasConsumed() + vmGasUsed}, vmerr
 	}
 
+	receipt.Status = types.ReceiptStatusSuccessful
+	receipt.Logs = st.StateDB.Logs()
+	receipt.LogsBloom = types.LogsBloom(receipt.Logs)
+
 	st.StateDB.Finalise(true)
 
 	// comsume vm gas
 	ctx.WithGasMeter(curGasMeter).GasMeter().ConsumeGas(vmGasUsed, "VM execution consumption")
 
-	return nil, &sdk.Result{Data: ret, GasUsed: ctx.GasMeter().GasConsumed()}, nil
+	receipt.GasUsed = ctx.GasMeter().GasConsumed()
+	receipt.CumulativeGasUsed = cumulativeGasUsed(ctx, receipt.GasUsed)
+	return receipt, &sdk.Result{Data: ret, GasUsed: ctx.GasMeter().GasConsumed()}, nil
+}
+
+// refundGas returns the gas refunded to a tx which used gasUsed, capped to gasUsed / refundQuotient
+func refundGas(gasUsed, refund, refundQuotient uint64) uint64 {
+	if max := gasUsed / refundQuotient; refund > max {
+		return max
+	}
+	return refund
+}
+
+// cumulativeGasUsed returns the gas used in the block including the given gas of the current tx
+func cumulativeGasUsed(ctx sdk.Context, gasUsed uint64) uint64 {
+	if ctx.BlockGasMeter() == nil {
+		return gasUsed
+	}
+	return ctx.BlockGasMeter().GasConsumed() + gasUsed
 }
 
-func DoStateTransition(ctx sdk.Context, msg types.MsgContract, k Keeper, readonly bool) (*big.Int, *sdk.Result, error) {
+func DoStateTransition(ctx sdk.Context, msg types.MsgContract, k Keeper, readonly bool) (*types.Receipt, *sdk.Result, error) {
 	st := StateTransition{
-		Sender:    msg.From,
-		Recipient: msg.To,
-		Payload:   msg.Payload,
-		Amount:    msg.Amount.Amount,
-		StateDB:   k.StateDB.WithContext(ctx).WithTxHash(tmhash.Sum(ctx.TxBytes())),
+		Sender:     msg.From,
+		Recipient:  msg.To,
+		Payload:    msg.Payload,
+		Amount:     msg.Amount.Amount,
+		StateDB:    k.StateDB.WithContext(ctx).WithTxHash(tmhash.Sum(ctx.TxBytes())),
+		AccessList: msg.AccessList,
 	}
 
 	if readonly {
@@ -131,3 +207,135 @@ func DoStateTransition(ctx sdk.Context, msg types.MsgContract, k Keeper, readonl
 
 	return st.TransitionCSDB(ctx, k)
 }
+
+// TraceStateTransition replays the preceding txs and the vm msgs of tx preceding msg, then traces the
+// execution of msg with the given tracer. As the ante handler does, the fee of each tx is deducted
+// from its fee payer and the sequence of each of its signers is incremented once before its msgs, so
+// that balances and created contract addresses match the original ones. The msgs of the failed txs
+// are not replayed. The state changes are written to the context store, callers must discard them.
+func TraceStateTransition(ctx sdk.Context, preceding []types.TraceTx, tx types.TraceTx, msg types.MsgContract, k Keeper, tracer Tracer) (*sdk.Result, error) {
+	ctx.Simulate = true
+	stateDB := types.NewStateDB(k.StateDB)
+
+	for _, precedingTx := range preceding {
+		if err := replayTx(ctx, stateDB, precedingTx, k); err != nil {
+			return nil, err
+		}
+	}
+	if err := replayTx(ctx, stateDB, tx, k); err != nil {
+		return nil, err
+	}
+
+	st := newTraceStateTransition(msg, stateDB)
+	st.Tracer = tracer
+	_, res, err := st.TransitionCSDB(ctx, k)
+	return res, err
+}
+
+// replayTx applies the effects of the ante handler of a tx on the vm state then executes its vm msgs.
+// The ante handler fails without any effect when the fee payer can't pay the fee. The fee is not
+// credited to the fee collector module account, which is not a vm account.
+func replayTx(ctx sdk.Context, stateDB *types.CommitStateDB, tx types.TraceTx, k Keeper) error {
+	stateDB.WithContext(ctx)
+
+	fee := tx.Fee.AmountOf(sdk.NativeTokenName).BigInt()
+	if fee.Sign() > 0 {
+		if stateDB.GetBalance(tx.FeePayer).Cmp(fee) < 0 {
+			return nil
+		}
+		stateDB.SubBalance(tx.FeePayer, fee)
+	}
+	for _, signer := range tx.Signers {
+		stateDB.SetNonce(signer, stateDB.GetNonce(signer)+1)
+	}
+	if _, err := stateDB.Commit(true); err != nil {
+		return err
+	}
+	stateDB.ClearStateObjects()
+
+	if tx.Failed {
+		return nil
+	}
+
+	for _, msg := range tx.Msgs {
+		// the msgs succeeded when the tx was delivered, the error of a replay is not fatal
+		_, _, _ = newTraceStateTransition(msg, stateDB).TransitionCSDB(ctx, k)
+		if _, err := stateDB.WithContext(ctx).Commit(true); err != nil {
+			return err
+		}
+		stateDB.ClearStateObjects()
+	}
+	return nil
+}
+
+func newTraceStateTransition(msg types.MsgContract, stateDB *types.CommitStateDB) StateTransition {
+	return StateTransition{
+		Sender:     msg.From,
+		Recipient:  msg.To,
+		Payload:    msg.Payload,
+		Amount:     msg.Amount.Amount,
+		StateDB:    stateDB,
+		AccessList: msg.AccessList,
+	}
+}
+
+// SimulateStateTransitions executes msgs one after another on the given (cached) context with the
+// overrides applied and returns the result of each msg along with the state diff of all of them.
+// As TraceStateTransition does, the sender nonce is incremented before each msg. The state changes
+// are written to the context store, callers must discard them.
+func SimulateStateTransitions(ctx sdk.Context, msgs []types.MsgContract, overrides []types.AccountOverride, k Keeper) ([]types.SimulateCallResult, types.StateDiff, error) {
+	if len(msgs) == 0 {
+		return nil, nil, ErrNoPayload
+	}
+
+	ctx.Simulate = true
+	stateDB := types.NewStateDB(k.StateDB).WithContext(ctx)
+
+	// the overrides are the base state of the simulation, they are not part of the diff
+	stateDB.ApplyOverrides(overrides)
+	if _, err := stateDB.Commit(false); err != nil {
+		return nil, nil, err
+	}
+	stateDB.ClearStateObjects()
+	stateDB.RecordStateDiff()
+
+	results := make([]types.SimulateCallResult, 0, len(msgs))
+	for _, msg := range msgs {
+		stateDB.WithContext(ctx).SetNonce(msg.From, stateDB.GetNonce(msg.From)+1)
+		if _, err := stateDB.Commit(true); err != nil {
+			return nil, nil, err
+		}
+		stateDB.ClearStateObjects()
+
+		st := StateTransition{
+			Sender:     msg.From,
+			Recipient:  msg.To,
+			Payload:    msg.Payload,
+			Amount:     msg.Amount.Amount,
+			StateDB:    stateDB,
+			AccessList: msg.AccessList,
+		}
+
+		// each msg is metered on its own
+		receipt, _, err := st.TransitionCSDB(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), k)
+		if receipt == nil {
+			return nil, nil, err
+		}
+		if _, err := stateDB.WithContext(ctx).Commit(true); err != nil {
+			return nil, nil, err
+		}
+		stateDB.ClearStateObjects()
+
+		results = append(results, types.SimulateCallResult{
+			Gas:             receipt.GasUsed,
+			Res:             hex.EncodeToString(receipt.Ret),
+			Failed:          receipt.Status == types.ReceiptStatusFailed,
+			Error:           receipt.Error,
+			RevertReason:    receipt.RevertReason,
+			ContractAddress: receipt.ContractAddress,
+			Logs:            receipt.Logs,
+		})
+	}
+
+	return results, stateDB.WithContext(ctx).StateDiff(), nil
+}
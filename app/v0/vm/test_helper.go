@@ -27,5 +27,5 @@ func newEVM() *EVM {
 	ms.MountStoreWithDB(authKey, sdk.StoreTypeDB, db)
 	ms.LoadLatestVersion()
 
-	return NewEVM(Context{}, NewCommitStateDB(accountKeeper, authKey).WithContext(sdk.NewContext(ms, abci.Header{}, false, logger)), Config{})
+	return NewEVM(Context{}, NewCommitStateDB(accountKeeper, authKey).WithContext(sdk.NewContext(ms, abci.Header{}, false, logger)), ChainConfig{}, Config{})
 }
@@ -0,0 +1,148 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// AccessTuple is an address and the storage keys of it a tx accesses (EIP-2930)
+type AccessTuple struct {
+	Address     sdk.AccAddress `json:"address" yaml:"address"`
+	StorageKeys []sdk.Hash     `json:"storage_keys" yaml:"storage_keys"`
+}
+
+// AccessList is the list of the addresses and storage keys a tx pre-warms (EIP-2930)
+type AccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al AccessList) StorageKeys() (n int) {
+	for _, tuple := range al {
+		n += len(tuple.StorageKeys)
+	}
+	return n
+}
+
+// Validate checks the addresses of the access list
+func (al AccessList) Validate() error {
+	for _, tuple := range al {
+		if len(tuple.Address) != sdk.AddrLen {
+			return fmt.Errorf("invalid access list address: %X", []byte(tuple.Address))
+		}
+	}
+	return nil
+}
+
+// accessList is the set of the addresses and storage slots accessed by the current tx (EIP-2929)
+type accessList struct {
+	addresses map[string]int
+	slots     []map[sdk.Hash]struct{}
+}
+
+func newAccessList() *accessList {
+	return &accessList{
+		addresses: make(map[string]int),
+	}
+}
+
+// ContainsAddress returns true if the address is in the access list.
+func (al *accessList) ContainsAddress(address sdk.AccAddress) bool {
+	_, ok := al.addresses[string(address)]
+	return ok
+}
+
+// Contains checks if a slot within an account is present in the access list, returning
+// separate flags for the presence of the account and the slot respectively.
+func (al *accessList) Contains(address sdk.AccAddress, slot sdk.Hash) (addressPresent bool, slotPresent bool) {
+	idx, ok := al.addresses[string(address)]
+	if !ok {
+		// no such address (and hence zero slots)
+		return false, false
+	}
+	if idx == -1 {
+		// address yes, but no slots
+		return true, false
+	}
+	_, slotPresent = al.slots[idx][slot]
+	return true, slotPresent
+}
+
+// Copy creates an independent copy of an accessList.
+func (al *accessList) Copy() *accessList {
+	cp := newAccessList()
+	for k, v := range al.addresses {
+		cp.addresses[k] = v
+	}
+	cp.slots = make([]map[sdk.Hash]struct{}, len(al.slots))
+	for i, slotMap := range al.slots {
+		newSlotmap := make(map[sdk.Hash]struct{}, len(slotMap))
+		for k := range slotMap {
+			newSlotmap[k] = struct{}{}
+		}
+		cp.slots[i] = newSlotmap
+	}
+	return cp
+}
+
+// AddAddress adds an address to the access list, and returns 'true' if the operation
+// caused a change (addr was not previously in the list).
+func (al *accessList) AddAddress(address sdk.AccAddress) bool {
+	if _, present := al.addresses[string(address)]; present {
+		return false
+	}
+	al.addresses[string(address)] = -1
+	return true
+}
+
+// AddSlot adds the specified (addr, slot) combo to the access list.
+// Return values are:
+// - address added
+// - slot added
+// For any 'true' value returned, a corresponding journal entry must be made.
+func (al *accessList) AddSlot(address sdk.AccAddress, slot sdk.Hash) (addrChange bool, slotChange bool) {
+	idx, addrPresent := al.addresses[string(address)]
+	if !addrPresent || idx == -1 {
+		// Address not present, or addr present but no slots there
+		al.addresses[string(address)] = len(al.slots)
+		slotmap := map[sdk.Hash]struct{}{slot: {}}
+		al.slots = append(al.slots, slotmap)
+		return !addrPresent, true
+	}
+	// There is already an (address,slot) mapping
+	slotmap := al.slots[idx]
+	if _, ok := slotmap[slot]; !ok {
+		slotmap[slot] = struct{}{}
+		// Journal add slot change
+		return false, true
+	}
+	// No changes required
+	return false, false
+}
+
+// DeleteSlot removes an (address, slot)-tuple from the access list.
+// This operation needs to be performed in the same order as the addition happened.
+// This method is meant to be used  by the journal, which maintains ordering of
+// operations.
+func (al *accessList) DeleteSlot(address sdk.AccAddress, slot sdk.Hash) {
+	idx, addrOk := al.addresses[string(address)]
+	if !addrOk {
+		panic("reverting slot change, address not present in list")
+	}
+	slotmap := al.slots[idx]
+	delete(slotmap, slot)
+	// If that was the last (first) slot, remove it
+	// Since additions and rollbacks are always performed in order,
+	// we can delete the item last added, which is also the last item in the slice
+	if len(slotmap) == 0 {
+		al.slots = al.slots[:idx]
+		al.addresses[string(address)] = -1
+	}
+}
+
+// DeleteAddress removes an address from the access list. This operation
+// needs to be performed in the same order as the addition happened.
+// This method is meant to be used  by the journal, which maintains ordering of
+// operations.
+func (al *accessList) DeleteAddress(address sdk.AccAddress) {
+	delete(al.addresses, string(address))
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -34,6 +35,7 @@ type (
 	storageChange struct {
 		account        *sdk.AccAddress
 		key, prevValue sdk.Hash
+		slot           sdk.Hash // the key before it is prefixed, for the state diff
 	}
 
 	codeChange struct {
@@ -57,6 +59,28 @@ type (
 	touchChange struct {
 		account *sdk.AccAddress
 	}
+
+	// changes done by the native modules
+	nativeChange struct{}
+
+	accountChange struct {
+		account *sdk.AccAddress
+		prev    *types.BaseAccount
+	}
+
+	loadObjectChange struct {
+		account *sdk.AccAddress
+	}
+
+	// changes to the access list
+	accessListAddAccountChange struct {
+		address *sdk.AccAddress
+	}
+
+	accessListAddSlotChange struct {
+		address *sdk.AccAddress
+		slot    *sdk.Hash
+	}
 )
 
 // createObjectChange
@@ -169,3 +193,61 @@ func (ch addPreimageChange) revert(s *CommitStateDB) {
 func (ch addPreimageChange) dirtied() *sdk.AccAddress {
 	return nil
 }
+
+// nativeChange
+func (ch nativeChange) revert(s *CommitStateDB) {
+	last := len(s.nativeBranches) - 1
+	s.ctx = s.nativeBranches[last].ctx
+	s.nativeBranches = s.nativeBranches[:last]
+}
+
+func (ch nativeChange) dirtied() *sdk.AccAddress {
+	return nil
+}
+
+// accountChange
+func (ch accountChange) revert(s *CommitStateDB) {
+	if so := s.stateObjects[ch.account.String()]; so != nil {
+		so.account = ch.prev
+	}
+}
+
+func (ch accountChange) dirtied() *sdk.AccAddress {
+	return nil
+}
+
+// loadObjectChange
+func (ch loadObjectChange) revert(s *CommitStateDB) {
+	delete(s.stateObjects, ch.account.String())
+}
+
+func (ch loadObjectChange) dirtied() *sdk.AccAddress {
+	return nil
+}
+
+// accessListAddAccountChange
+func (ch accessListAddAccountChange) revert(s *CommitStateDB) {
+	/*
+		One important invariant here, is that whenever a (addr, slot) is added, if the
+		addr is not already present, the add causes two journal entries:
+		- one for the address,
+		- one for the (address,slot)
+		Therefore, when unrolling the change, we can always blindly delete the
+		(addr) at this point, since no storage adds can remain when come upon
+		a single (addr) change.
+	*/
+	s.accessList.DeleteAddress(*ch.address)
+}
+
+func (ch accessListAddAccountChange) dirtied() *sdk.AccAddress {
+	return nil
+}
+
+// accessListAddSlotChange
+func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
+	s.accessList.DeleteSlot(*ch.address, *ch.slot)
+}
+
+func (ch accessListAddSlotChange) dirtied() *sdk.AccAddress {
+	return nil
+}
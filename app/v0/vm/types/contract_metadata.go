@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+const (
+	MaxContractNameLength    = 64
+	MaxCompilerVersionLength = 64
+	MaxContractABISize       = 64 * 1024
+)
+
+// ContractMetadata is the description of a deployed contract attached by its deployer, so that
+// the contract can be called and verified without requiring the abi from the user
+type ContractMetadata struct {
+	Contract        sdk.AccAddress `json:"contract" yaml:"contract"`
+	Deployer        sdk.AccAddress `json:"deployer" yaml:"deployer"`
+	Name            string         `json:"name" yaml:"name"`
+	ABI             string         `json:"abi" yaml:"abi"`
+	CompilerVersion string         `json:"compiler_version" yaml:"compiler_version"`
+	SourceHash      sdk.Hash       `json:"source_hash" yaml:"source_hash"`
+}
+
+func NewContractMetadata(contract, deployer sdk.AccAddress, name, abiJSON, compilerVersion string, sourceHash sdk.Hash) ContractMetadata {
+	return ContractMetadata{
+		Contract:        contract,
+		Deployer:        deployer,
+		Name:            name,
+		ABI:             abiJSON,
+		CompilerVersion: compilerVersion,
+		SourceHash:      sourceHash,
+	}
+}
+
+// Validate checks the addresses, the lengths of the fields and that the abi can be parsed
+func (m ContractMetadata) Validate() error {
+	if m.Contract.Empty() {
+		return fmt.Errorf("contract address is empty")
+	}
+	if m.Deployer.Empty() {
+		return fmt.Errorf("deployer address is empty")
+	}
+	if len(strings.TrimSpace(m.Name)) == 0 {
+		return fmt.Errorf("contract name is empty")
+	}
+	if len(m.Name) > MaxContractNameLength {
+		return fmt.Errorf("contract name is longer than %d bytes", MaxContractNameLength)
+	}
+	if len(m.CompilerVersion) > MaxCompilerVersionLength {
+		return fmt.Errorf("compiler version is longer than %d bytes", MaxCompilerVersionLength)
+	}
+	if len(m.ABI) > MaxContractABISize {
+		return fmt.Errorf("contract abi is larger than %d bytes", MaxContractABISize)
+	}
+	if _, err := m.ParseABI(); err != nil {
+		return fmt.Errorf("invalid contract abi: %v", err)
+	}
+
+	return nil
+}
+
+// ParseABI returns the parsed abi of the contract
+func (m ContractMetadata) ParseABI() (abi.ABI, error) {
+	if len(strings.TrimSpace(m.ABI)) == 0 {
+		return abi.ABI{}, fmt.Errorf("abi is empty")
+	}
+	return abi.JSON(strings.NewReader(m.ABI))
+}
+
+func (m ContractMetadata) String() string {
+	j, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
@@ -22,4 +22,10 @@ var (
 	ErrInvalidJump              = sdkerrors.New(ModuleName, 15, "evm: invalid jump destination")
 	ErrGasUintOverflow          = sdkerrors.New(ModuleName, 16, "gas uint64 overflow")
 	ErrWrongCtx                 = sdkerrors.New(ModuleName, 17, "must be simulate mode when gas limit is 0")
+	ErrNativeUnavailable        = sdkerrors.New(ModuleName, 18, "native module not available")
+	ErrInvalidNativeCall        = sdkerrors.New(ModuleName, 19, "invalid native contract call")
+	ErrInvalidCode              = sdkerrors.New(ModuleName, 20, "invalid code: must not begin with 0xef")
+	ErrAccessListNotActive      = sdkerrors.New(ModuleName, 21, "access lists are not active before the berlin fork")
+	ErrNotContractDeployer      = sdkerrors.New(ModuleName, 22, "not the deployer of the contract")
+	ErrInvalidValue             = sdkerrors.New(ModuleName, 23, "invalid value")
 )
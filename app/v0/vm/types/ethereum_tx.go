@@ -0,0 +1,326 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	authtypes "github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+	sdkerrors "github.com/netcloth/netcloth-chain/types/errors"
+)
+
+const (
+	TypeMsgEthereumTx = "ethereum_tx"
+
+	// maxValueBitLen is the max bit length of the amounts of coins
+	maxValueBitLen = 255
+)
+
+var (
+	_ sdk.Msg = MsgEthereumTx{}
+	_ sdk.Tx  = MsgEthereumTx{}
+
+	chainIDSuffix = regexp.MustCompile(`[-_](\d+)$`)
+)
+
+// MsgEthereumTx encapsulates an RLP-encoded, EIP-155 signed Ethereum transaction,
+// it is both the tx and its single msg.
+type MsgEthereumTx struct {
+	Data TxData
+}
+
+// TxData implements the Ethereum transaction data structure, its RLP encoding
+// is the one produced by standard Ethereum wallets.
+type TxData struct {
+	AccountNonce uint64          `json:"nonce"`
+	Price        *big.Int        `json:"gasPrice"`
+	GasLimit     uint64          `json:"gas"`
+	Recipient    *ethcmn.Address `json:"to" rlp:"nil"` // nil means contract creation
+	Amount       *big.Int        `json:"value"`
+	Payload      []byte          `json:"input"`
+
+	// signature values
+	V *big.Int `json:"v"`
+	R *big.Int `json:"r"`
+	S *big.Int `json:"s"`
+}
+
+// NewMsgEthereumTx returns an unsigned ethereum tx, a nil recipient creates a contract
+func NewMsgEthereumTx(nonce uint64, to *ethcmn.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, payload []byte) MsgEthereumTx {
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+
+	return MsgEthereumTx{
+		Data: TxData{
+			AccountNonce: nonce,
+			Price:        gasPrice,
+			GasLimit:     gasLimit,
+			Recipient:    to,
+			Amount:       amount,
+			Payload:      payload,
+			V:            new(big.Int),
+			R:            new(big.Int),
+			S:            new(big.Int),
+		},
+	}
+}
+
+// Route returns the route of the msg
+func (msg MsgEthereumTx) Route() string { return RouterKey }
+
+// Type returns the type of the msg
+func (msg MsgEthereumTx) Type() string { return TypeMsgEthereumTx }
+
+// ValidateBasic checks the tx values and that a sender can be recovered from the signature, the
+// amount and the max fee of the tx must fit in coins
+func (msg MsgEthereumTx) ValidateBasic() error {
+	if msg.Data.Price == nil || msg.Data.Price.Sign() < 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "gas price must not be negative")
+	}
+	if msg.Data.Amount == nil || msg.Data.Amount.Sign() < 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount must not be negative")
+	}
+	if msg.Data.Price.BitLen() > maxValueBitLen {
+		return sdkerrors.Wrapf(ErrInvalidValue, "gas price is longer than %d bits", maxValueBitLen)
+	}
+	if msg.Data.Amount.BitLen() > maxValueBitLen {
+		return sdkerrors.Wrapf(ErrInvalidValue, "amount is longer than %d bits", maxValueBitLen)
+	}
+	if fee := new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit)); fee.BitLen() > maxValueBitLen {
+		return sdkerrors.Wrapf(ErrInvalidValue, "gas price * gas limit is longer than %d bits", maxValueBitLen)
+	}
+	if msg.Data.Recipient == nil && len(msg.Data.Payload) == 0 {
+		return ErrNoPayload
+	}
+	if msg.Data.GasLimit == 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrGasLimitError, "%d", msg.Data.GasLimit)
+	}
+	if _, err := msg.From(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
+	}
+
+	return nil
+}
+
+// GetSignBytes returns the RLP encoding of the unsigned tx
+func (msg MsgEthereumTx) GetSignBytes() []byte {
+	bz, err := rlp.EncodeToBytes(msg.unsignedFields(msg.ChainID()))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// GetSigners returns the sender recovered from the signature
+func (msg MsgEthereumTx) GetSigners() []sdk.AccAddress {
+	from, err := msg.From()
+	if err != nil {
+		return nil
+	}
+	return []sdk.AccAddress{from}
+}
+
+// GetMsgs returns the tx itself as its single msg
+func (msg MsgEthereumTx) GetMsgs() []sdk.Msg {
+	return []sdk.Msg{msg}
+}
+
+// GetGas returns the gas limit of the tx
+func (msg MsgEthereumTx) GetGas() uint64 {
+	return msg.Data.GasLimit
+}
+
+// GetFee returns the max fee of the tx, gasPrice * gasLimit in native token
+func (msg MsgEthereumTx) GetFee() sdk.Coins {
+	fee := new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
+	return sdk.NewCoins(sdk.NewCoin(sdk.NativeTokenName, sdk.NewIntFromBigInt(fee)))
+}
+
+// FeePayer returns the sender of the tx
+func (msg MsgEthereumTx) FeePayer() sdk.AccAddress {
+	from, _ := msg.From()
+	return from
+}
+
+// To returns the recipient of the tx, empty for contract creation
+func (msg MsgEthereumTx) To() sdk.AccAddress {
+	if msg.Data.Recipient == nil {
+		return nil
+	}
+	return sdk.AccAddress(msg.Data.Recipient.Bytes())
+}
+
+// ToMsgContract returns the MsgContract executed by the vm for the tx
+func (msg MsgEthereumTx) ToMsgContract() (MsgContract, error) {
+	from, err := msg.From()
+	if err != nil {
+		return MsgContract{}, err
+	}
+
+	amount := sdk.NewCoin(sdk.NativeTokenName, sdk.NewIntFromBigInt(msg.Data.Amount))
+	return NewMsgContract(from, msg.To(), msg.Data.Payload, amount), nil
+}
+
+// ChainID returns the chain id the tx was signed for, nil if the tx is not EIP-155 protected
+func (msg MsgEthereumTx) ChainID() *big.Int {
+	v := msg.Data.V
+	if v == nil || v.BitLen() <= 8 && (v.Uint64() == 27 || v.Uint64() == 28) {
+		return nil
+	}
+
+	chainID := new(big.Int).Sub(v, big.NewInt(35))
+	return chainID.Div(chainID, big.NewInt(2))
+}
+
+// Sign signs the tx for the given chain id with the given ethereum private key
+func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv interface{}) error {
+	key, err := toECDSA(priv)
+	if err != nil {
+		return err
+	}
+
+	hash, err := rlpHash(msg.unsignedFields(chainID))
+	if err != nil {
+		return err
+	}
+
+	sig, err := ethcrypto.Sign(hash, key)
+	if err != nil {
+		return err
+	}
+
+	msg.Data.R = new(big.Int).SetBytes(sig[:32])
+	msg.Data.S = new(big.Int).SetBytes(sig[32:64])
+	msg.Data.V = new(big.Int).SetUint64(uint64(sig[64]) + 35)
+	msg.Data.V.Add(msg.Data.V, new(big.Int).Mul(chainID, big.NewInt(2)))
+	return nil
+}
+
+// From recovers the sender of an EIP-155 signed tx, the sender account address is the
+// 20 bytes ethereum address of the signing key
+func (msg MsgEthereumTx) From() (sdk.AccAddress, error) {
+	chainID := msg.ChainID()
+	if chainID == nil {
+		return nil, errors.New("tx is not EIP-155 replay protected")
+	}
+
+	if msg.Data.R == nil || msg.Data.S == nil {
+		return nil, errors.New("missing signature")
+	}
+
+	v := new(big.Int).Sub(msg.Data.V, new(big.Int).Mul(chainID, big.NewInt(2)))
+	v.Sub(v, big.NewInt(35))
+	if v.BitLen() > 8 {
+		return nil, errors.New("invalid signature V value")
+	}
+	recID := byte(v.Uint64())
+	if !ethcrypto.ValidateSignatureValues(recID, msg.Data.R, msg.Data.S, true) {
+		return nil, errors.New("invalid signature values")
+	}
+
+	sig := make([]byte, 65)
+	rb, sb := msg.Data.R.Bytes(), msg.Data.S.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):64], sb)
+	sig[64] = recID
+
+	hash, err := rlpHash(msg.unsignedFields(chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := ethcrypto.Ecrecover(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(pub) == 0 || pub[0] != 4 {
+		return nil, errors.New("invalid public key")
+	}
+
+	return sdk.AccAddress(ethcrypto.Keccak256(pub[1:])[12:]), nil
+}
+
+// EncodeRLP implements rlp.Encoder
+func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, &msg.Data)
+}
+
+// DecodeRLP implements rlp.Decoder
+func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
+	return s.Decode(&msg.Data)
+}
+
+func (msg MsgEthereumTx) unsignedFields(chainID *big.Int) []interface{} {
+	return []interface{}{
+		msg.Data.AccountNonce,
+		msg.Data.Price,
+		msg.Data.GasLimit,
+		msg.Data.Recipient,
+		msg.Data.Amount,
+		msg.Data.Payload,
+		chainID, uint(0), uint(0),
+	}
+}
+
+func rlpHash(x interface{}) ([]byte, error) {
+	bz, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.Keccak256(bz), nil
+}
+
+// ChainIDFromString returns the EIP-155 chain id of a chain, a numeric suffix of the chain id
+// string (e.g. "nch-1") is used if present, otherwise the bytes of the chain id string.
+func ChainIDFromString(chainID string) *big.Int {
+	if m := chainIDSuffix.FindStringSubmatch(chainID); m != nil {
+		if id, ok := new(big.Int).SetString(m[1], 10); ok {
+			return id
+		}
+	}
+
+	return new(big.Int).SetBytes([]byte(chainID))
+}
+
+// NewTxDecoder returns a decoder accepting amino encoded StdTx and RLP encoded ethereum txs
+func NewTxDecoder(cdc *codec.Codec) sdk.TxDecoder {
+	stdTxDecoder := authtypes.DefaultTxDecoder(cdc)
+
+	return func(txBytes []byte) (sdk.Tx, error) {
+		tx, err := stdTxDecoder(txBytes)
+		if err == nil {
+			return tx, nil
+		}
+
+		var ethTx MsgEthereumTx
+		if rlpErr := rlp.DecodeBytes(txBytes, &ethTx); rlpErr != nil {
+			return nil, err
+		}
+
+		return ethTx, nil
+	}
+}
+
+func toECDSA(priv interface{}) (*ecdsa.PrivateKey, error) {
+	switch key := priv.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case []byte:
+		return ethcrypto.ToECDSA(key)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netcloth/netcloth-chain/codec"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+func TestMsgEthereumTxSignAndRecover(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := sdk.AccAddress(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes())
+
+	to := ethcmn.BytesToAddress([]byte("to"))
+	chainID := big.NewInt(7)
+
+	tx := NewMsgEthereumTx(3, &to, big.NewInt(100), 21000, big.NewInt(1000000), nil)
+	_, err = tx.From()
+	require.Error(t, err)
+
+	require.NoError(t, tx.Sign(chainID, key))
+	require.Equal(t, chainID, tx.ChainID())
+	require.NoError(t, tx.ValidateBasic())
+
+	from, err := tx.From()
+	require.NoError(t, err)
+	require.Equal(t, sender, from)
+	require.Equal(t, []sdk.AccAddress{sender}, tx.GetSigners())
+	require.Equal(t, sender, tx.FeePayer())
+	require.Equal(t, sdk.AccAddress(to.Bytes()), tx.To())
+	require.Equal(t, uint64(21000), tx.GetGas())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.NativeTokenName, 21000*1000000)), tx.GetFee())
+
+	// tampering with the tx changes the recovered sender
+	tx.Data.AccountNonce = 4
+	from, err = tx.From()
+	require.NoError(t, err)
+	require.NotEqual(t, sender, from)
+
+	// pre EIP-155 signatures are rejected
+	tx.Data.V = big.NewInt(27)
+	require.Nil(t, tx.ChainID())
+	require.Error(t, tx.ValidateBasic())
+}
+
+func TestMsgEthereumTxValidateBasic(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	to := ethcmn.BytesToAddress([]byte("to"))
+
+	cases := []struct {
+		valid bool
+		tx    MsgEthereumTx
+	}{
+		{true, NewMsgEthereumTx(0, &to, big.NewInt(1), 21000, big.NewInt(1), nil)},
+		{true, NewMsgEthereumTx(0, nil, nil, 21000, big.NewInt(1), []byte("code"))},
+		{false, NewMsgEthereumTx(0, nil, nil, 21000, big.NewInt(1), nil)},
+		{false, NewMsgEthereumTx(0, &to, big.NewInt(1), 0, big.NewInt(1), nil)},
+	}
+
+	for i, tc := range cases {
+		require.NoError(t, tc.tx.Sign(big.NewInt(1), key))
+		if tc.valid {
+			require.NoError(t, tc.tx.ValidateBasic(), "case %d", i)
+		} else {
+			require.Error(t, tc.tx.ValidateBasic(), "case %d", i)
+		}
+	}
+
+	// the amount and the max fee must fit in coins
+	maxValue := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	over := new(big.Int).Lsh(big.NewInt(1), 255)
+	for i, tx := range []MsgEthereumTx{
+		NewMsgEthereumTx(0, &to, over, 21000, big.NewInt(1), nil),
+		NewMsgEthereumTx(0, &to, big.NewInt(1), 21000, over, nil),
+		NewMsgEthereumTx(0, &to, big.NewInt(1), 21000, new(big.Int).Lsh(big.NewInt(1), 250), nil),
+	} {
+		require.NoError(t, tx.Sign(big.NewInt(1), key))
+		require.True(t, ErrInvalidValue.Is(tx.ValidateBasic()), "case %d", i)
+	}
+	tx := NewMsgEthereumTx(0, &to, maxValue, 1, maxValue, nil)
+	require.NoError(t, tx.Sign(big.NewInt(1), key))
+	require.NoError(t, tx.ValidateBasic())
+	require.NotPanics(t, func() { tx.GetFee() })
+
+	// negative values can't be RLP encoded thus never signed
+	tx = NewMsgEthereumTx(0, &to, big.NewInt(-1), 21000, big.NewInt(1), nil)
+	require.Error(t, tx.Sign(big.NewInt(1), key))
+	require.Error(t, tx.ValidateBasic())
+}
+
+func TestTxDecoder(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	tx := NewMsgEthereumTx(1, nil, nil, 100000, big.NewInt(1), []byte("code"))
+	require.NoError(t, tx.Sign(big.NewInt(1), key))
+
+	bz, err := rlp.EncodeToBytes(&tx)
+	require.NoError(t, err)
+
+	cdc := codec.New()
+	decoded, err := NewTxDecoder(cdc)(bz)
+	require.NoError(t, err)
+	require.Equal(t, tx, decoded)
+
+	_, err = NewTxDecoder(cdc)([]byte("invalid"))
+	require.Error(t, err)
+}
+
+func TestChainIDFromString(t *testing.T) {
+	require.Equal(t, big.NewInt(1), ChainIDFromString("nch-1"))
+	require.Equal(t, big.NewInt(12), ChainIDFromString("nch_chain-12"))
+	require.Equal(t, new(big.Int).SetBytes([]byte("nch")), ChainIDFromString("nch"))
+}
@@ -1,8 +1,10 @@
 package types
 
 const (
-	EventTypeNewContract = "new_contract"
+	EventTypeNewContract         = "new_contract"
+	EventTypeSetContractMetadata = "set_contract_metadata"
 
 	AttributeKeyAddress    = "address"
+	AttributeKeyDeployer   = "deployer"
 	AttributeValueCategory = "vm"
 )
@@ -1,7 +1,12 @@
 package types
 
 import (
+	"time"
+
 	"github.com/netcloth/netcloth-chain/app/v0/auth/exported"
+	cipaltypes "github.com/netcloth/netcloth-chain/app/v0/cipal/types"
+	ipaltypes "github.com/netcloth/netcloth-chain/app/v0/ipal/types"
+	stakingtypes "github.com/netcloth/netcloth-chain/app/v0/staking/types"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
@@ -16,4 +21,37 @@ type AccountKeeper interface {
 // BankKeeper defines the expected bank keeper used for vm
 type BankKeeper interface {
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
+	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	GetSendEnabled(ctx sdk.Context) bool
+	BlacklistedAddr(addr sdk.AccAddress) bool
+}
+
+// StakingKeeper defines the expected staking keeper used for vm
+type StakingKeeper interface {
+	BondDenom(ctx sdk.Context) string
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
+	GetDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (delegation stakingtypes.Delegation, found bool)
+	Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Int, tokenSrc sdk.BondStatus, validator stakingtypes.Validator, subtractAccount bool) (newShares sdk.Dec, err error)
+	ValidateUnbondAmount(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amt sdk.Int) (shares sdk.Dec, err error)
+	Undelegate(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) (time.Time, error)
+}
+
+// IPALKeeper defines the expected ipal keeper used for vm
+type IPALKeeper interface {
+	GetIPALNode(ctx sdk.Context, operator sdk.AccAddress) (obj ipaltypes.IPALNode, found bool)
+	GetIPALNodeAddByMoniker(ctx sdk.Context, moniker string) (acc sdk.AccAddress, exist bool)
+}
+
+// CIPALKeeper defines the expected cipal keeper used for vm
+type CIPALKeeper interface {
+	GetCIPALObject(ctx sdk.Context, userAddress string) (obj cipaltypes.CIPALObject, found bool)
+}
+
+// NativeKeepers holds the keepers of the native modules reachable from contracts through the
+// native precompiled contracts, a nil keeper disables the corresponding contract
+type NativeKeepers struct {
+	BankKeeper    BankKeeper
+	StakingKeeper StakingKeeper
+	IPALKeeper    IPALKeeper
+	CIPALKeeper   CIPALKeeper
 }
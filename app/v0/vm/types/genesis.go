@@ -2,18 +2,20 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/netcloth/netcloth-chain/hexutil"
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
 
 type (
-	// GenesisState vm genesis state, include params, vm storage, vm codes, vm logs
+	// GenesisState vm genesis state, include params, vm storage, vm codes, vm logs, contract metadata
 	GenesisState struct {
-		Params  Params              `json:"params"`
-		Storage []Storage           `json:"storage"`
-		Codes   map[string]sdk.Code `json:"codes"`
-		VMLogs  VMLogs              `json:"vm_logs"`
+		Params           Params              `json:"params"`
+		Storage          []Storage           `json:"storage"`
+		Codes            map[string]sdk.Code `json:"codes"`
+		VMLogs           VMLogs              `json:"vm_logs"`
+		ContractMetadata []ContractMetadata  `json:"contract_metadata,omitempty"`
 	}
 
 	// Storage vm storage of k, v pairs
@@ -54,7 +56,26 @@ func ValidateGenesis(data GenesisState) error {
 		return err
 	}
 
-	return validateVMCommonGasParams(data.Params.VMContractCreationGasParams)
+	if err := validateVMCommonGasParams(data.Params.VMContractCreationGasParams); err != nil {
+		return err
+	}
+
+	if err := data.Params.ForkHeights.Validate(); err != nil {
+		return err
+	}
+
+	contracts := make(map[string]bool, len(data.ContractMetadata))
+	for _, m := range data.ContractMetadata {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("invalid metadata of contract %s: %v", m.Contract, err)
+		}
+		if contracts[m.Contract.String()] {
+			return fmt.Errorf("duplicate metadata of contract %s", m.Contract)
+		}
+		contracts[m.Contract.String()] = true
+	}
+
+	return nil
 }
 
 // Equal judge GenesisState equal
@@ -12,19 +12,73 @@ const (
 	RouterKey    = ModuleName
 )
 
+// BlockHashWindow is the number of most recent block hashes kept for the BLOCKHASH opcode
+const BlockHashWindow = 256
+
 var (
 	LogIndexKey = []byte("logIndexKey")
 )
 
 // KVStore key prefixes
 var (
-	KeyPrefixLogs      = []byte{0x01}
-	KeyPrefixLogsIndex = []byte{0x02}
-	KeyPrefixCode      = []byte{0x03}
-	KeyPrefixStorage   = []byte{0x04}
+	KeyPrefixLogs       = []byte{0x01}
+	KeyPrefixLogsIndex  = []byte{0x02}
+	KeyPrefixCode       = []byte{0x03}
+	KeyPrefixStorage    = []byte{0x04}
+	KeyPrefixBlockHash  = []byte{0x05}
+	KeyPrefixReceipt    = []byte{0x06}
+	KeyPrefixBlockBloom = []byte{0x07}
+	KeyPrefixBlockLogs  = []byte{0x08}
+
+	KeyPrefixStateDiff       = []byte{0x09}
+	KeyPrefixStateDiffHeight = []byte{0x0a}
+
+	KeyPrefixContractMetadata = []byte{0x0b}
 )
 
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
 func AddressStoragePrefix(address sdk.Address) []byte {
 	return append(KeyPrefixStorage, address.Bytes()...)
 }
+
+// BlockHashKey returns the key of the hash of the block at the given height
+func BlockHashKey(height uint64) []byte {
+	return append(KeyPrefixBlockHash, sdk.Uint64ToBigEndian(height)...)
+}
+
+// BlockBloomKey returns the key of the logs bloom of the block at the given height
+func BlockBloomKey(height uint64) []byte {
+	return append(KeyPrefixBlockBloom, sdk.Uint64ToBigEndian(height)...)
+}
+
+// BlockLogsPrefix returns a prefix to iterate over the txs with logs of the block at the given height
+func BlockLogsPrefix(height uint64) []byte {
+	return append(KeyPrefixBlockLogs, sdk.Uint64ToBigEndian(height)...)
+}
+
+// BlockLogsKey returns the key of a tx with logs of the block at the given height, ordered by
+// the index of the first log of the tx
+func BlockLogsKey(height, logIndex uint64) []byte {
+	return append(BlockLogsPrefix(height), sdk.Uint64ToBigEndian(logIndex)...)
+}
+
+// StateDiffKey returns the key of the state diff of the tx with the given hash
+func StateDiffKey(txHash sdk.Hash) []byte {
+	return append(KeyPrefixStateDiff, txHash.Bytes()...)
+}
+
+// StateDiffHeightPrefix returns a prefix to iterate over the txs with a state diff of the block at
+// the given height, and over the blocks before it
+func StateDiffHeightPrefix(height uint64) []byte {
+	return append(KeyPrefixStateDiffHeight, sdk.Uint64ToBigEndian(height)...)
+}
+
+// StateDiffHeightKey returns the key indexing the state diff of a tx by the height of its block
+func StateDiffHeightKey(height uint64, txHash sdk.Hash) []byte {
+	return append(StateDiffHeightPrefix(height), txHash.Bytes()...)
+}
+
+// ContractMetadataKey returns the key of the metadata of the given contract
+func ContractMetadataKey(contract sdk.AccAddress) []byte {
+	return append(KeyPrefixContractMetadata, contract.Bytes()...)
+}
@@ -9,10 +9,13 @@ import (
 const (
 	TypeMsgContractCreate = "contract_create"
 	TypeMsgContractCall   = "contract_call"
+
+	TypeMsgSetContractMetadata = "set_contract_metadata"
 )
 
 var (
 	_ sdk.Msg = &MsgContract{}
+	_ sdk.Msg = &MsgSetContractMetadata{}
 )
 
 type MsgContract struct {
@@ -20,6 +23,8 @@ type MsgContract struct {
 	To      sdk.AccAddress `json:"to" yaml:"to"`
 	Payload hexutil.Bytes  `json:"payload" yaml:"payload"`
 	Amount  sdk.Coin       `json:"amount" yaml:"amount"`
+	// AccessList is the optional EIP-2930 access list, accepted from the berlin fork
+	AccessList AccessList `json:"access_list,omitempty" yaml:"access_list"`
 }
 
 func (msg MsgContract) Route() string {
@@ -46,6 +51,9 @@ func (msg MsgContract) ValidateBasic() error {
 	if len(msg.Payload) == 0 {
 		return ErrNoPayload
 	}
+	if err := msg.AccessList.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
 
 	return nil
 }
@@ -78,3 +86,63 @@ func NewMsgContractQuery(from, to sdk.AccAddress, payload []byte, amount sdk.Coi
 		Amount:  amount,
 	}
 }
+
+// MsgSetContractMetadata attaches metadata to a contract deployed by From, the contract must be the
+// one created by From with the given nonce
+type MsgSetContractMetadata struct {
+	From            sdk.AccAddress `json:"from" yaml:"from"`
+	Contract        sdk.AccAddress `json:"contract" yaml:"contract"`
+	Nonce           uint64         `json:"nonce" yaml:"nonce"`
+	Name            string         `json:"name" yaml:"name"`
+	ABI             string         `json:"abi" yaml:"abi"`
+	CompilerVersion string         `json:"compiler_version" yaml:"compiler_version"`
+	SourceHash      sdk.Hash       `json:"source_hash" yaml:"source_hash"`
+}
+
+func NewMsgSetContractMetadata(from, contract sdk.AccAddress, nonce uint64, name, abiJSON, compilerVersion string, sourceHash sdk.Hash) MsgSetContractMetadata {
+	return MsgSetContractMetadata{
+		From:            from,
+		Contract:        contract,
+		Nonce:           nonce,
+		Name:            name,
+		ABI:             abiJSON,
+		CompilerVersion: compilerVersion,
+		SourceHash:      sourceHash,
+	}
+}
+
+func (msg MsgSetContractMetadata) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractMetadata) Type() string {
+	return TypeMsgSetContractMetadata
+}
+
+func (msg MsgSetContractMetadata) ValidateBasic() error {
+	if msg.From.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "msg missing from address")
+	}
+	if msg.Contract.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "msg missing contract address")
+	}
+	if err := msg.Metadata().Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+func (msg MsgSetContractMetadata) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgSetContractMetadata) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// Metadata returns the metadata set by the msg
+func (msg MsgSetContractMetadata) Metadata() ContractMetadata {
+	return NewContractMetadata(msg.Contract, msg.From, msg.Name, msg.ABI, msg.CompilerVersion, msg.SourceHash)
+}
@@ -21,6 +21,8 @@ var (
 	KeyMaxCallCreateDepth          = []byte("MaxCallCreateDepth")
 	KeyVMOpGasParams               = []byte("VMOpGasParams")
 	KeyVMContractCreationGasParams = []byte("VMContractCreationGasParams")
+	KeyForkHeights                 = []byte("ForkHeights")
+	KeyStateDiffRetention          = []byte("StateDiffRetention")
 
 	DefaultVMOpGasParams = [256]uint64{
 		0, 3, 5, 3, 5, 5, 5, 5, 8, 8, 0, 5, 0, 0, 0, 0, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 0, 0, //0-31
@@ -42,22 +44,82 @@ type VMContractCreationGasParams struct {
 	GasPerByte uint64 `json:"gas_per_byte" yaml:"gas_per_byte"`
 }
 
+// ForkHeights are the heights from which the EVM hard forks are active, 0 when a fork is not scheduled.
+// A fork is scheduled by a param change proposal, the heights of the active forks can not be changed.
+type ForkHeights struct {
+	Berlin   int64 `json:"berlin" yaml:"berlin"`     // EIP-2929 access list gas and EIP-2930 access lists
+	London   int64 `json:"london" yaml:"london"`     // BASEFEE, EIP-3529 refunds and EIP-3541
+	Shanghai int64 `json:"shanghai" yaml:"shanghai"` // PUSH0 and EIP-3651 warm coinbase
+}
+
+type forkHeight struct {
+	name   string
+	height int64
+}
+
+func (f ForkHeights) heights() []forkHeight {
+	return []forkHeight{{"berlin", f.Berlin}, {"london", f.London}, {"shanghai", f.Shanghai}}
+}
+
+// Validate checks that the forks are scheduled in order
+func (f ForkHeights) Validate() error {
+	heights := f.heights()
+	for i, h := range heights {
+		if h.height < 0 {
+			return fmt.Errorf("%s fork height must not be negative: %d", h.name, h.height)
+		}
+		if i == 0 || h.height == 0 {
+			continue
+		}
+		if prev := heights[i-1]; prev.height == 0 || prev.height > h.height {
+			return fmt.Errorf("%s fork at height %d must not precede the %s fork at height %d", h.name, h.height, prev.name, prev.height)
+		}
+	}
+
+	return nil
+}
+
+// ValidateChange checks that changing the fork heights to next at the given block height neither
+// moves an active fork nor activates a fork at a past or the current height
+func (f ForkHeights) ValidateChange(next ForkHeights, height int64) error {
+	nextHeights := next.heights()
+	for i, h := range f.heights() {
+		n := nextHeights[i]
+		if n.height == h.height {
+			continue
+		}
+		if h.height != 0 && h.height <= height {
+			return fmt.Errorf("%s fork is active since height %d", h.name, h.height)
+		}
+		if n.height != 0 && n.height <= height {
+			return fmt.Errorf("%s fork at height %d must be scheduled after the current height %d", n.name, n.height, height)
+		}
+	}
+
+	return nil
+}
+
 type Params struct {
 	MaxCodeSize                 uint64                      `json:"max_code_size" yaml:"max_code_size"`
 	MaxCallCreateDepth          uint64                      `json:"max_call_create_depth" yaml:"max_call_create_depth"`
 	VMOpGasParams               [256]uint64                 `json:"vm_op_gas_params" yaml:"vm_op_gas_params"`
 	VMContractCreationGasParams VMContractCreationGasParams `json:"vm_contract_creation_gas_params" yaml:"vm_contract_creation_gas_params"`
+	ForkHeights                 ForkHeights                 `json:"fork_heights" yaml:"fork_heights"`
+	StateDiffRetention          uint64                      `json:"state_diff_retention" yaml:"state_diff_retention"` // number of recent blocks whose tx state diffs are kept, 0 disables the recording
 }
 
 var _ params.ParamSet = (*Params)(nil)
 
 // NewParams return Params
-func NewParams(maxCodeSize, callCreateDepth uint64, vmOpGasParams [256]uint64, vmContractCreationGasParams VMContractCreationGasParams) Params {
+func NewParams(maxCodeSize, callCreateDepth uint64, vmOpGasParams [256]uint64, vmContractCreationGasParams VMContractCreationGasParams,
+	forkHeights ForkHeights, stateDiffRetention uint64) Params {
 	return Params{
 		MaxCodeSize:                 maxCodeSize,
 		MaxCallCreateDepth:          callCreateDepth,
 		VMOpGasParams:               vmOpGasParams,
 		VMContractCreationGasParams: vmContractCreationGasParams,
+		ForkHeights:                 forkHeights,
+		StateDiffRetention:          stateDiffRetention,
 	}
 }
 
@@ -67,6 +129,8 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyMaxCallCreateDepth, &p.MaxCallCreateDepth, validateMaxCallCreateDepth),
 		params.NewParamSetPair(KeyVMOpGasParams, &p.VMOpGasParams, validateVMOpGasParams),
 		params.NewParamSetPair(KeyVMContractCreationGasParams, &p.VMContractCreationGasParams, validateVMCommonGasParams),
+		params.NewParamSetPair(KeyForkHeights, &p.ForkHeights, validateForkHeights),
+		params.NewParamSetPair(KeyStateDiffRetention, &p.StateDiffRetention, validateStateDiffRetention),
 	}
 }
 
@@ -76,6 +140,8 @@ func DefaultParams() Params {
 		defaultCallCreateDepth,
 		DefaultVMOpGasParams,
 		vmContractCreationGasParams,
+		ForkHeights{},
+		0,
 	)
 }
 
@@ -126,3 +192,20 @@ func validateVMCommonGasParams(i interface{}) error {
 
 	return nil
 }
+
+func validateForkHeights(i interface{}) error {
+	v, ok := i.(ForkHeights)
+	if !ok {
+		return fmt.Errorf("invalid type: %T", i)
+	}
+
+	return v.Validate()
+}
+
+func validateStateDiffRetention(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("StateDiffRetention'type must be uint64: %T", i)
+	}
+
+	return nil
+}
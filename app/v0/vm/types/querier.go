@@ -2,7 +2,11 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	sdk "github.com/netcloth/netcloth-chain/types"
 )
@@ -15,6 +19,14 @@ const (
 	QueryTxLogs     = "logs"
 	EstimateGas     = "estimate_gas"
 	QueryCall       = "call"
+	QueryTrace      = "trace"
+	QueryReceipt    = "receipt"
+	QueryFilterLogs = "filter_logs"
+	QueryBlockBloom = "block_bloom"
+	QuerySimulate   = "simulate"
+	QueryStateDiff  = "state_diff"
+
+	QueryContractMetadata = "contract_metadata"
 )
 
 // QueryLogsResult - for query logs
@@ -64,3 +76,193 @@ type QueryStateParams struct {
 	ShowCode     bool `json:"show_code" yaml:"show_code"`
 	ContractOnly bool `json:"contract_only" yaml:"contract_only"`
 }
+
+// DefaultMaxLogsBlockRange is the default max number of blocks a logs filter query may scan
+const DefaultMaxLogsBlockRange = 1000
+
+// QueryFilterLogsParams - for query the logs of a range of blocks, with the semantics of
+// eth_getLogs: a log matches when it is emitted by one of the addresses, and for each topic
+// position, its topic is one of the topics of the position. An empty address list or topic
+// position matches any address or topic. A zero block number means the latest block.
+type QueryFilterLogsParams struct {
+	FromBlock uint64           `json:"from_block" yaml:"from_block"`
+	ToBlock   uint64           `json:"to_block" yaml:"to_block"`
+	Addresses []sdk.AccAddress `json:"addresses" yaml:"addresses"`
+	Topics    [][]sdk.Hash     `json:"topics" yaml:"topics"`
+}
+
+// NewQueryFilterLogsParams creates a new QueryFilterLogsParams instance
+func NewQueryFilterLogsParams(fromBlock, toBlock uint64, addresses []sdk.AccAddress, topics [][]sdk.Hash) QueryFilterLogsParams {
+	return QueryFilterLogsParams{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: addresses,
+		Topics:    topics,
+	}
+}
+
+// ValidateBasic checks the block range of the filter
+func (p QueryFilterLogsParams) ValidateBasic() error {
+	if p.ToBlock != 0 && p.FromBlock > p.ToBlock {
+		return fmt.Errorf("invalid block range: from block %d is greater than to block %d", p.FromBlock, p.ToBlock)
+	}
+	if len(p.Topics) > 4 {
+		return errors.New("too many topic positions, max 4")
+	}
+	return nil
+}
+
+// BloomMatches returns false when the logs of a block with the given bloom can not match the filter
+func (p QueryFilterLogsParams) BloomMatches(bloom ethtypes.Bloom) bool {
+	if len(p.Addresses) > 0 {
+		var included bool
+		for _, addr := range p.Addresses {
+			if bloom.Test(new(big.Int).SetBytes(addr.Bytes())) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, sub := range p.Topics {
+		included := len(sub) == 0 // empty rule set == wildcard
+		for _, topic := range sub {
+			if bloom.Test(new(big.Int).SetBytes(topic.Bytes())) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the logs matching the addresses and topics of the filter
+func (p QueryFilterLogsParams) Filter(logs []*Log) []*Log {
+	ret := make([]*Log, 0, len(logs))
+Logs:
+	for _, log := range logs {
+		if len(p.Addresses) > 0 && !includesAddress(p.Addresses, log.Address) {
+			continue
+		}
+		// If the to filtered topics is greater than the amount of topics in logs, skip.
+		if len(p.Topics) > len(log.Topics) {
+			continue
+		}
+		for i, sub := range p.Topics {
+			match := len(sub) == 0 // empty rule set == wildcard
+			for _, topic := range sub {
+				if log.Topics[i] == topic {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue Logs
+			}
+		}
+		ret = append(ret, log)
+	}
+	return ret
+}
+
+func includesAddress(addresses []sdk.AccAddress, a sdk.AccAddress) bool {
+	for _, addr := range addresses {
+		if addr.Equals(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// StorageOverride sets a storage slot of an account before a simulation
+type StorageOverride struct {
+	Key   sdk.Hash `json:"key" yaml:"key"`
+	Value sdk.Hash `json:"value" yaml:"value"`
+}
+
+// AccountOverride replaces the fields of an account that are set before a simulation, the
+// account is created when it does not exist
+type AccountOverride struct {
+	Address sdk.AccAddress    `json:"address" yaml:"address"`
+	Balance *sdk.Int          `json:"balance,omitempty" yaml:"balance"`
+	Nonce   *uint64           `json:"nonce,omitempty" yaml:"nonce"`
+	Code    *sdk.Code         `json:"code,omitempty" yaml:"code"`
+	Storage []StorageOverride `json:"storage,omitempty" yaml:"storage"`
+}
+
+// QuerySimulateParams - for query simulate, Msgs are executed one after another on the state
+// with the overrides applied, nothing is committed
+type QuerySimulateParams struct {
+	Msgs      []MsgContract     `json:"msgs" yaml:"msgs"`
+	Overrides []AccountOverride `json:"overrides" yaml:"overrides"`
+}
+
+// NewQuerySimulateParams creates a new instance of QuerySimulateParams
+func NewQuerySimulateParams(msgs []MsgContract, overrides []AccountOverride) QuerySimulateParams {
+	return QuerySimulateParams{
+		Msgs:      msgs,
+		Overrides: overrides,
+	}
+}
+
+// ValidateBasic checks the msgs and the overrides of the simulation
+func (p QuerySimulateParams) ValidateBasic() error {
+	if len(p.Msgs) == 0 {
+		return errors.New("no msg to simulate")
+	}
+	for i, msg := range p.Msgs {
+		if msg.From.Empty() {
+			return fmt.Errorf("msg %d: missing sender", i)
+		}
+		if err := msg.AccessList.Validate(); err != nil {
+			return fmt.Errorf("msg %d: %s", i, err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, o := range p.Overrides {
+		if o.Address.Empty() {
+			return errors.New("override with empty address")
+		}
+		if seen[o.Address.String()] {
+			return fmt.Errorf("duplicated override of %s", o.Address)
+		}
+		seen[o.Address.String()] = true
+
+		if o.Balance != nil && o.Balance.IsNegative() {
+			return fmt.Errorf("invalid balance override of %s", o.Address)
+		}
+	}
+	return nil
+}
+
+// SimulateCallResult - the result of a msg of query simulate
+type SimulateCallResult struct {
+	Gas             uint64         `json:"gas"`
+	Res             string         `json:"res"`
+	Failed          bool           `json:"failed"`
+	Error           string         `json:"error,omitempty"`
+	RevertReason    string         `json:"revert_reason,omitempty"`
+	ContractAddress sdk.AccAddress `json:"contract_address,omitempty"`
+	Logs            []*Log         `json:"logs"`
+}
+
+// SimulateResult - for query simulate
+type SimulateResult struct {
+	Results   []SimulateCallResult `json:"results"`
+	StateDiff StateDiff            `json:"state_diff"`
+}
+
+func (r SimulateResult) String() string {
+	j, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// Receipt statuses
+const (
+	ReceiptStatusFailed     = uint64(0)
+	ReceiptStatusSuccessful = uint64(1)
+)
+
+// revertSelector is the selector of Error(string), the ABI encoding of a revert reason
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// Receipt is the result of the execution of a MsgContract in a tx
+type Receipt struct {
+	TxHash            sdk.Hash       `json:"transactionHash" yaml:"transactionHash"`
+	BlockNumber       uint64         `json:"blockNumber" yaml:"blockNumber"`
+	From              sdk.AccAddress `json:"from" yaml:"from"`
+	To                sdk.AccAddress `json:"to" yaml:"to"`
+	Status            uint64         `json:"status" yaml:"status"`
+	GasUsed           uint64         `json:"gasUsed" yaml:"gasUsed"`
+	CumulativeGasUsed uint64         `json:"cumulativeGasUsed" yaml:"cumulativeGasUsed"`
+	ContractAddress   sdk.AccAddress `json:"contractAddress" yaml:"contractAddress"`
+	Logs              []*Log         `json:"logs" yaml:"logs"`
+	LogsBloom         ethtypes.Bloom `json:"logsBloom" yaml:"logsBloom"`
+	Ret               hexutil.Bytes  `json:"ret" yaml:"ret"`
+	Error             string         `json:"error,omitempty" yaml:"error,omitempty"`
+	RevertReason      string         `json:"revertReason,omitempty" yaml:"revertReason,omitempty"`
+}
+
+func (r Receipt) String() string {
+	return fmt.Sprintf(`Receipt:
+  TxHash:            %s
+  BlockNumber:       %d
+  From:              %s
+  To:                %s
+  Status:            %d
+  GasUsed:           %d
+  CumulativeGasUsed: %d
+  ContractAddress:   %s
+  Logs:              %v
+  LogsBloom:         %x
+  Ret:               %s
+  Error:             %s
+  RevertReason:      %s`,
+		r.TxHash.String(), r.BlockNumber, r.From, r.To, r.Status, r.GasUsed, r.CumulativeGasUsed,
+		r.ContractAddress, r.Logs, r.LogsBloom.Bytes(), r.Ret, r.Error, r.RevertReason)
+}
+
+// LogsBloom returns the bloom filter of the addresses and topics of the given logs
+func LogsBloom(logs []*Log) ethtypes.Bloom {
+	var bloom ethtypes.Bloom
+	for _, log := range logs {
+		bloom.Add(new(big.Int).SetBytes(log.Address.Bytes()))
+		for _, topic := range log.Topics {
+			bloom.Add(new(big.Int).SetBytes(topic.Bytes()))
+		}
+	}
+	return bloom
+}
+
+// UnpackRevertReason returns the reason of a revert from its ABI encoded Error(string) output,
+// or an empty string when the output is not a revert reason
+func UnpackRevertReason(ret []byte) string {
+	if len(ret) < len(revertSelector) || !bytes.Equal(ret[:len(revertSelector)], revertSelector) {
+		return ""
+	}
+
+	typ, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return ""
+	}
+
+	values, err := abi.Arguments{{Type: typ}}.UnpackValues(ret[len(revertSelector):])
+	if err != nil || len(values) != 1 {
+		return ""
+	}
+
+	reason, _ := values[0].(string)
+	return reason
+}
@@ -0,0 +1,239 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// StorageDiff is the change of a storage slot of an account
+type StorageDiff struct {
+	Key  sdk.Hash `json:"key" yaml:"key"`
+	Pre  sdk.Hash `json:"pre" yaml:"pre"`
+	Post sdk.Hash `json:"post" yaml:"post"`
+}
+
+// AccountDiff holds the pre and post values of an account changed by the vm, the code is only set
+// when it changed and only the changed storage slots are listed
+type AccountDiff struct {
+	Address     sdk.AccAddress `json:"address" yaml:"address"`
+	PreBalance  sdk.Int        `json:"pre_balance" yaml:"pre_balance"`
+	PostBalance sdk.Int        `json:"post_balance" yaml:"post_balance"`
+	PreNonce    uint64         `json:"pre_nonce" yaml:"pre_nonce"`
+	PostNonce   uint64         `json:"post_nonce" yaml:"post_nonce"`
+	PreCode     sdk.Code       `json:"pre_code,omitempty" yaml:"pre_code"`
+	PostCode    sdk.Code       `json:"post_code,omitempty" yaml:"post_code"`
+	Storage     []StorageDiff  `json:"storage,omitempty" yaml:"storage"`
+}
+
+// StateDiff is the list of the accounts changed by the vm, sorted by address
+type StateDiff []AccountDiff
+
+func (d StateDiff) String() string {
+	j, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
+
+// TxStateDiff is the state diff recorded for a delivered tx
+type TxStateDiff struct {
+	TxHash    sdk.Hash  `json:"tx_hash" yaml:"tx_hash"`
+	Height    uint64    `json:"height" yaml:"height"`
+	StateDiff StateDiff `json:"state_diff" yaml:"state_diff"`
+}
+
+func (d TxStateDiff) String() string {
+	j, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
+
+// accountPre holds the values of an account before the first change of each of them, unset
+// values have not been changed
+type accountPre struct {
+	address sdk.AccAddress
+	balance *sdk.Int
+	nonce   *uint64
+	code    *[]byte
+	storage map[sdk.Hash]sdk.Hash
+}
+
+// stateDiffRecorder collects the pre values of the accounts from the journal entries before the
+// journal is cleared
+type stateDiffRecorder struct {
+	accounts map[string]*accountPre
+	skip     int // number of entries of the current journal made before the recording
+}
+
+func newStateDiffRecorder() *stateDiffRecorder {
+	return &stateDiffRecorder{
+		accounts: make(map[string]*accountPre),
+	}
+}
+
+func (r *stateDiffRecorder) account(addr sdk.AccAddress) *accountPre {
+	pre, ok := r.accounts[addr.String()]
+	if !ok {
+		pre = &accountPre{address: addr, storage: make(map[sdk.Hash]sdk.Hash)}
+		r.accounts[addr.String()] = pre
+	}
+	return pre
+}
+
+// record keeps the first previous value of each account field and storage slot of the entries
+func (r *stateDiffRecorder) record(entries []journalEntry) {
+	if r.skip > len(entries) {
+		r.skip = len(entries)
+	}
+	entries, r.skip = entries[r.skip:], 0
+
+	for _, entry := range entries {
+		switch ch := entry.(type) {
+		case createObjectChange:
+			pre := r.account(*ch.account)
+			pre.setBalance(sdk.ZeroInt())
+			pre.setNonce(0)
+			pre.setCode(nil)
+		case resetObjectChange:
+			if ch.prev == nil {
+				continue
+			}
+			pre := r.account(ch.prev.address)
+			pre.setBalance(ch.prev.account.Balance())
+			pre.setNonce(ch.prev.account.Sequence)
+			pre.setCode(ch.prev.Code())
+		case balanceChange:
+			r.account(*ch.account).setBalance(ch.prev)
+		case suicideChange:
+			r.account(*ch.account).setBalance(ch.prevBalance)
+		case nonceChange:
+			r.account(*ch.account).setNonce(ch.prev)
+		case codeChange:
+			r.account(*ch.account).setCode(ch.prevCode)
+		case storageChange:
+			pre := r.account(*ch.account)
+			if _, ok := pre.storage[ch.slot]; !ok {
+				pre.storage[ch.slot] = ch.prevValue
+			}
+		case accountChange:
+			pre := r.account(*ch.account)
+			pre.setBalance(ch.prev.Balance())
+			pre.setNonce(ch.prev.Sequence)
+		}
+	}
+}
+
+func (pre *accountPre) setBalance(balance sdk.Int) {
+	if pre.balance == nil {
+		pre.balance = &balance
+	}
+}
+
+func (pre *accountPre) setNonce(nonce uint64) {
+	if pre.nonce == nil {
+		pre.nonce = &nonce
+	}
+}
+
+func (pre *accountPre) setCode(code []byte) {
+	if pre.code == nil {
+		code = append([]byte{}, code...)
+		pre.code = &code
+	}
+}
+
+// RecordStateDiff starts recording the changes of the accounts, they are returned by StateDiff.
+// The changes already in the journal, left by a failed tx, are not recorded.
+func (csdb *CommitStateDB) RecordStateDiff() {
+	csdb.diff = newStateDiffRecorder()
+	csdb.diff.skip = len(csdb.journal.entries)
+}
+
+// StateDiff stops recording and returns the accounts changed since RecordStateDiff, the post values
+// are read from the state db, so that the changes must have been finalised or committed
+func (csdb *CommitStateDB) StateDiff() StateDiff {
+	if csdb.diff == nil {
+		return nil
+	}
+	csdb.diff.record(csdb.journal.entries)
+	recorder := csdb.diff
+	csdb.diff = nil
+
+	addrs := make([]string, 0, len(recorder.accounts))
+	for addr := range recorder.accounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	diff := StateDiff{}
+	for _, addr := range addrs {
+		pre := recorder.accounts[addr]
+		so := csdb.diffStateObject(pre.address)
+
+		d := AccountDiff{
+			Address:     pre.address,
+			PostBalance: sdk.ZeroInt(),
+		}
+		var postCode []byte
+		if so != nil {
+			d.PostBalance = so.account.Balance()
+			d.PostNonce = so.account.Sequence
+			postCode = so.Code()
+		}
+
+		d.PreBalance, d.PreNonce = d.PostBalance, d.PostNonce
+		if pre.balance != nil {
+			d.PreBalance = *pre.balance
+		}
+		if pre.nonce != nil {
+			d.PreNonce = *pre.nonce
+		}
+		if pre.code != nil && !bytes.Equal(*pre.code, postCode) {
+			d.PreCode, d.PostCode = *pre.code, postCode
+		}
+
+		for slot, value := range pre.storage {
+			var post sdk.Hash
+			if so != nil {
+				post = so.GetState(slot)
+			}
+			if post != value {
+				d.Storage = append(d.Storage, StorageDiff{Key: slot, Pre: value, Post: post})
+			}
+		}
+		sort.Slice(d.Storage, func(i, j int) bool {
+			return bytes.Compare(d.Storage[i].Key.Bytes(), d.Storage[j].Key.Bytes()) < 0
+		})
+
+		if d.PreBalance.Equal(d.PostBalance) && d.PreNonce == d.PostNonce && d.PostCode == nil && d.PreCode == nil && len(d.Storage) == 0 {
+			continue
+		}
+		diff = append(diff, d)
+	}
+
+	return diff
+}
+
+// diffStateObject returns the live state object of the address or loads it from the context store,
+// without recording an error when the account does not exist
+func (csdb *CommitStateDB) diffStateObject(addr sdk.AccAddress) *stateObject {
+	if so, ok := csdb.stateObjects[addr.String()]; ok {
+		if so.deleted {
+			return nil
+		}
+		return so
+	}
+
+	acc, ok := csdb.ak.GetAccount(csdb.ctx, addr).(*types.BaseAccount)
+	if !ok {
+		return nil
+	}
+	return newObject(csdb, acc)
+}
@@ -106,6 +106,7 @@ func (so *stateObject) SetState(key, value sdk.Hash) {
 		account:   &so.address,
 		key:       prefixKey,
 		prevValue: prev,
+		slot:      key,
 	})
 
 	so.setState(prefixKey, value)
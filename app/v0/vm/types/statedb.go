@@ -7,9 +7,11 @@ import (
 	"sort"
 	"sync"
 
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/tendermint/tendermint/crypto"
 
 	"github.com/netcloth/netcloth-chain/app/v0/auth"
+	authexported "github.com/netcloth/netcloth-chain/app/v0/auth/exported"
 	"github.com/netcloth/netcloth-chain/app/v0/auth/types"
 	"github.com/netcloth/netcloth-chain/app/v0/vm/common/math"
 	"github.com/netcloth/netcloth-chain/hexutil"
@@ -26,6 +28,14 @@ type revision struct {
 	journalIndex int
 }
 
+// nativeBranch is a branch of the context of the StateDB holding the state changes done by
+// a native module, ctx is the context it was branched from
+type nativeBranch struct {
+	ctx    sdk.Context
+	write  func()
+	events sdk.Events
+}
+
 type CommitStateDB struct {
 	// TODO: We need to store the context as part of the structure itself opposed
 	// to being passed as a parameter (as it should be) in order to implement the
@@ -44,6 +54,9 @@ type CommitStateDB struct {
 	// The refund counter, also used by state transitioning.
 	refund uint64
 
+	// Per-transaction access list
+	accessList *accessList
+
 	thash, bhash sdk.Hash
 	txIndex      int
 	logs         map[sdk.Hash][]*Log
@@ -65,6 +78,17 @@ type CommitStateDB struct {
 	validRevisions []revision
 	nextRevisionID int
 
+	// receipts of the txs failed within the current block, they are written by the EndBlocker
+	// as the writes of a failed tx are discarded
+	failedReceipts []*Receipt
+
+	// branches of the context holding the pending state changes of the native
+	// modules, see RunNative
+	nativeBranches []nativeBranch
+
+	// recorder of the state diff, set by RecordStateDiff
+	diff *stateDiffRecorder
+
 	// mutex for state deep copying
 	lock sync.Mutex
 }
@@ -84,6 +108,7 @@ func NewCommitStateDB(ak auth.AccountKeeper, storageKey sdk.StoreKey) *CommitSta
 		logs:              make(map[sdk.Hash][]*Log),
 		preimages:         make(map[sdk.Hash][]byte),
 		journal:           newJournal(),
+		accessList:        newAccessList(),
 	}
 }
 
@@ -96,12 +121,14 @@ func NewStateDB(db *CommitStateDB) *CommitStateDB {
 		logs:              make(map[sdk.Hash][]*Log),
 		preimages:         make(map[sdk.Hash][]byte),
 		journal:           newJournal(),
+		accessList:        newAccessList(),
 	}
 }
 
 // WithContext returns a Database with an updated sdk context
 func (csdb *CommitStateDB) WithContext(ctx sdk.Context) *CommitStateDB {
 	csdb.ctx = ctx
+	csdb.nativeBranches = nil
 	return csdb
 }
 
@@ -173,6 +200,26 @@ func (csdb *CommitStateDB) SetCode(addr sdk.AccAddress, code []byte) {
 	}
 }
 
+// ApplyOverrides sets the overridden fields of the accounts, creating the accounts which do not
+// exist. It is used to simulate msgs on a modified state.
+func (csdb *CommitStateDB) ApplyOverrides(overrides []AccountOverride) {
+	for _, o := range overrides {
+		csdb.GetOrNewStateObject(o.Address)
+		if o.Balance != nil {
+			csdb.SetBalance(o.Address, o.Balance.BigInt())
+		}
+		if o.Nonce != nil {
+			csdb.SetNonce(o.Address, *o.Nonce)
+		}
+		if o.Code != nil {
+			csdb.SetCode(o.Address, *o.Code)
+		}
+		for _, s := range o.Storage {
+			csdb.SetState(o.Address, s.Key, s.Value)
+		}
+	}
+}
+
 // AddLog adds a new log to the state and sets the log metadata from the state.
 func (csdb *CommitStateDB) AddLog(log *Log) {
 	csdb.journal.append(addLogChange{txhash: csdb.thash})
@@ -242,6 +289,11 @@ func (csdb *CommitStateDB) GetNonce(addr sdk.AccAddress) uint64 {
 	return 0
 }
 
+// TxHash returns the hash of the current transaction the logs are recorded under.
+func (csdb *CommitStateDB) TxHash() sdk.Hash {
+	return csdb.thash
+}
+
 // TxIndex returns the current transaction index set by Prepare.
 func (csdb *CommitStateDB) TxIndex() int {
 	return csdb.txIndex
@@ -327,6 +379,125 @@ func (csdb *CommitStateDB) GetLogs(hash sdk.Hash) (logs []*Log) {
 	return
 }
 
+// GetReceipt returns the receipt of the first vm msg of the tx with the given hash, or nil when it
+// is not stored
+func (csdb *CommitStateDB) GetReceipt(hash sdk.Hash) *Receipt {
+	receipts := csdb.GetReceipts(hash)
+	if len(receipts) == 0 {
+		return nil
+	}
+	return receipts[0]
+}
+
+// GetReceipts returns the receipts of the vm msgs of the tx with the given hash in the order they
+// were executed
+func (csdb *CommitStateDB) GetReceipts(hash sdk.Hash) []*Receipt {
+	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storageKey), KeyPrefixReceipt)
+	bz := store.Get(hash.Bytes())
+	if bz == nil {
+		return nil
+	}
+
+	var receipts []*Receipt
+	if err := json.Unmarshal(bz, &receipts); err != nil {
+		// the receipts stored before the receipt lists held a single receipt
+		var receipt Receipt
+		if err := json.Unmarshal(bz, &receipt); err != nil {
+			csdb.ctx.Logger().Error(err.Error())
+			return nil
+		}
+		receipts = []*Receipt{&receipt}
+	}
+
+	return receipts
+}
+
+// SetReceipt appends the receipt of a vm msg to the receipts of its tx
+func (csdb *CommitStateDB) SetReceipt(receipt *Receipt) {
+	prev := csdb.GetReceipts(receipt.TxHash)
+	bz, err := json.Marshal(append(prev, receipt))
+	if err != nil {
+		csdb.ctx.Logger().Error(err.Error())
+		return
+	}
+
+	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storageKey), KeyPrefixReceipt)
+	store.Set(receipt.TxHash.Bytes(), bz)
+
+	if !hasLogs(receipt) {
+		return
+	}
+	// the logs index points to the tx, whose logs are all returned at once, so it is added only by
+	// the first receipt of the tx with logs
+	indexTx := true
+	for _, r := range prev {
+		if hasLogs(r) {
+			indexTx = false
+			break
+		}
+	}
+	csdb.indexBlockLogs(receipt, indexTx)
+}
+
+func hasLogs(receipt *Receipt) bool {
+	return receipt.Status == ReceiptStatusSuccessful && len(receipt.Logs) > 0
+}
+
+// indexBlockLogs adds the logs of the receipt to the logs bloom of its block, and its tx to the
+// logs index of the block when indexTx is set
+func (csdb *CommitStateDB) indexBlockLogs(receipt *Receipt, indexTx bool) {
+	store := csdb.ctx.KVStore(csdb.storageKey)
+
+	bloom := csdb.GetBlockBloom(receipt.BlockNumber)
+	for i := range bloom {
+		bloom[i] |= receipt.LogsBloom[i]
+	}
+	store.Set(BlockBloomKey(receipt.BlockNumber), bloom.Bytes())
+
+	if !indexTx {
+		return
+	}
+	store.Set(BlockLogsKey(receipt.BlockNumber, receipt.Logs[0].Index), receipt.TxHash.Bytes())
+}
+
+// GetBlockBloom returns the bloom of the logs of the block at the given height, an empty bloom
+// when the block has no logs
+func (csdb *CommitStateDB) GetBlockBloom(height uint64) (bloom ethtypes.Bloom) {
+	bz := csdb.ctx.KVStore(csdb.storageKey).Get(BlockBloomKey(height))
+	if bz != nil {
+		bloom.SetBytes(bz)
+	}
+	return
+}
+
+// GetBlockLogs returns the logs of the block at the given height in the order they were emitted
+func (csdb *CommitStateDB) GetBlockLogs(height uint64) (logs []*Log) {
+	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storageKey), BlockLogsPrefix(height))
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		for _, log := range csdb.GetLogs(sdk.BytesToHash(iter.Value())) {
+			log.BlockNumber = height
+			logs = append(logs, log)
+		}
+	}
+	return
+}
+
+// AddFailedReceipt keeps the receipt of a failed tx to be written by CommitFailedReceipts
+func (csdb *CommitStateDB) AddFailedReceipt(receipt *Receipt) {
+	csdb.failedReceipts = append(csdb.failedReceipts, receipt)
+}
+
+// CommitFailedReceipts writes the receipts of the failed txs of the block
+func (csdb *CommitStateDB) CommitFailedReceipts() {
+	for _, receipt := range csdb.failedReceipts {
+		csdb.SetReceipt(receipt)
+	}
+	csdb.failedReceipts = nil
+}
+
 // Logs returns all the current logs in the state.
 func (csdb *CommitStateDB) Logs() []*Log { // todo: is should get all logs from store?
 	logs := make([]*Log, 0, len(csdb.logs))
@@ -343,6 +514,11 @@ func (csdb *CommitStateDB) ClearLogs() {
 	}
 }
 
+// ResetRefund clears the refund counter left by a previous tx which was not finalised
+func (csdb *CommitStateDB) ResetRefund() {
+	csdb.refund = 0
+}
+
 // GetRefund returns the current value of the refund counter.
 func (csdb *CommitStateDB) GetRefund() uint64 {
 	return csdb.refund
@@ -418,7 +594,15 @@ func (csdb *CommitStateDB) commitLogs() {
 
 	for _, h := range hs {
 		hash := sdk.HexToHash(h)
-		d, err := json.Marshal(csdb.logs[hash])
+		// the logs of the previous vm msgs of the tx were committed by their own msg
+		var logs []*Log
+		if bz := store.Get(hash.Bytes()); bz != nil {
+			if err := json.Unmarshal(bz, &logs); err != nil {
+				ctx.Logger().Error(err.Error())
+			}
+		}
+
+		d, err := json.Marshal(append(logs, csdb.logs[hash]...))
 		if err != nil {
 			ctx.Logger().Error(err.Error())
 			continue
@@ -492,6 +676,8 @@ func (csdb *CommitStateDB) Finalise(deleteEmptyObjects bool) {
 	csdb.commitLogs()
 	csdb.ClearLogs()
 
+	csdb.writeNativeBranches()
+
 	// invalidate journal because reverting across transactions is not allowed
 	csdb.clearJournalAndRefund()
 }
@@ -558,6 +744,83 @@ func (csdb *CommitStateDB) RevertToSnapshot(revID int) {
 	csdb.validRevisions = csdb.validRevisions[:idx]
 }
 
+// ----------------------------------------------------------------------------
+// Native state changes
+// ----------------------------------------------------------------------------
+
+// RunNative runs fn, an operation of a native module, on a branch of the current context to
+// which the live accounts are written first. The live accounts are reloaded from the branch
+// afterwards so that the following operations see the changes of fn. The branch is journaled:
+// reverting to a snapshot taken before discards it, Finalise writes it to the context of the
+// StateDB. When fn fails or readOnly is set the branch is discarded right away.
+func (csdb *CommitStateDB) RunNative(readOnly bool, fn func(ctx sdk.Context) error) error {
+	ctx, write := csdb.ctx.CacheContext()
+
+	addrs := csdb.liveAddresses()
+	for _, addr := range addrs {
+		csdb.ak.SetAccount(ctx, csdb.stateObjects[addr].account)
+	}
+
+	if err := fn(ctx); err != nil || readOnly {
+		return err
+	}
+
+	csdb.journal.append(nativeChange{})
+	csdb.nativeBranches = append(csdb.nativeBranches, nativeBranch{
+		ctx:    csdb.ctx,
+		write:  write,
+		events: ctx.EventManager().Events(),
+	})
+	csdb.ctx = ctx.WithEventManager(csdb.ctx.EventManager())
+
+	for _, addr := range addrs {
+		so := csdb.stateObjects[addr]
+		acc, ok := csdb.ak.GetAccount(ctx, so.address).(*types.BaseAccount)
+		if !ok {
+			continue
+		}
+
+		csdb.journal.append(accountChange{
+			account: &so.address,
+			prev:    so.account,
+		})
+		so.account = acc
+	}
+
+	return nil
+}
+
+// liveAddresses returns the sorted addresses of the live state objects which are not deleted
+func (csdb *CommitStateDB) liveAddresses() []string {
+	addrs := make([]string, 0, len(csdb.stateObjects))
+	for addr, so := range csdb.stateObjects {
+		if !so.deleted {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// writeNativeBranches writes the pending native branches down to the context they were taken
+// from, innermost first, and emits their events
+func (csdb *CommitStateDB) writeNativeBranches() {
+	if len(csdb.nativeBranches) == 0 {
+		return
+	}
+
+	base := csdb.nativeBranches[0].ctx
+	for i := len(csdb.nativeBranches) - 1; i >= 0; i-- {
+		csdb.nativeBranches[i].write()
+	}
+	for _, branch := range csdb.nativeBranches {
+		base.EventManager().EmitEvents(branch.events)
+	}
+
+	csdb.ctx = base
+	csdb.nativeBranches = nil
+}
+
 // ----------------------------------------------------------------------------
 // Auxiliary
 // ----------------------------------------------------------------------------
@@ -650,6 +913,9 @@ func (csdb *CommitStateDB) ClearStateObjects() {
 }
 
 func (csdb *CommitStateDB) clearJournalAndRefund() {
+	if csdb.diff != nil {
+		csdb.diff.record(csdb.journal.entries)
+	}
 	csdb.journal = newJournal()
 	csdb.validRevisions = csdb.validRevisions[:0]
 	csdb.refund = 0
@@ -698,6 +964,7 @@ func (csdb *CommitStateDB) Copy() *CommitStateDB {
 		logs:              make(map[sdk.Hash][]*Log, len(csdb.logs)),
 		preimages:         make(map[sdk.Hash][]byte),
 		journal:           newJournal(),
+		accessList:        csdb.accessList.Copy(),
 	}
 
 	// copy the dirty states, logs, and preimages
@@ -828,6 +1095,11 @@ func (csdb *CommitStateDB) getStateObject(addr sdk.AccAddress) (stateObject *sta
 	so := newObject(csdb, acc)
 	csdb.setStateObject(so)
 
+	// the account may hold changes of a pending native branch, drop it when the branch is reverted
+	if len(csdb.nativeBranches) > 0 {
+		csdb.journal.append(loadObjectChange{account: &so.address})
+	}
+
 	return so
 }
 
@@ -835,6 +1107,17 @@ func (csdb *CommitStateDB) setStateObject(so *stateObject) {
 	csdb.stateObjects[so.Address().String()] = so
 }
 
+// LoadStateObjects loads the state objects of all the base accounts of the context store into
+// the live set, so that they are exported with the state of that store
+func (csdb *CommitStateDB) LoadStateObjects() {
+	csdb.ak.IterateAccounts(csdb.ctx, func(acc authexported.Account) bool {
+		if _, ok := acc.(*types.BaseAccount); ok {
+			csdb.getStateObject(acc.GetAddress())
+		}
+		return false
+	})
+}
+
 func (csdb *CommitStateDB) ExportStateObjects(params QueryStateParams) (sos SOs) {
 	var so SO
 
@@ -855,7 +1138,7 @@ func (csdb *CommitStateDB) ExportStateObjects(params QueryStateParams) (sos SOs)
 		if !params.ShowCode {
 			so.Code = nil
 		} else {
-			so.Code = append(so.Code[:], stateObject.code...)
+			so.Code = append(sdk.Code{}, stateObject.Code()...)
 		}
 
 		sos = append(sos, so)
@@ -995,3 +1278,66 @@ func (csdb *CommitStateDB) GetAllHotContractAddrs() (accs []sdk.AccAddress) {
 	}
 	return
 }
+
+// PrepareAccessList clears the access list and adds the addresses warm from the start of a tx
+// (EIP-2929), along with the optional access list of the tx (EIP-2930):
+// - the sender
+// - the destination, if any
+// - the precompiled contracts
+// - the coinbase, when given (EIP-3651)
+func (csdb *CommitStateDB) PrepareAccessList(sender sdk.AccAddress, dst sdk.AccAddress, precompiles []sdk.AccAddress,
+	coinbase sdk.AccAddress, list AccessList) {
+	csdb.accessList = newAccessList()
+
+	csdb.AddAddressToAccessList(sender)
+	if !dst.Empty() {
+		csdb.AddAddressToAccessList(dst)
+	}
+	for _, addr := range precompiles {
+		csdb.AddAddressToAccessList(addr)
+	}
+	if !coinbase.Empty() {
+		csdb.AddAddressToAccessList(coinbase)
+	}
+	for _, el := range list {
+		csdb.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			csdb.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (csdb *CommitStateDB) AddAddressToAccessList(addr sdk.AccAddress) {
+	if csdb.accessList.AddAddress(addr) {
+		csdb.journal.append(accessListAddAccountChange{&addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (csdb *CommitStateDB) AddSlotToAccessList(addr sdk.AccAddress, slot sdk.Hash) {
+	addrMod, slotMod := csdb.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		csdb.journal.append(accessListAddAccountChange{&addr})
+	}
+	if slotMod {
+		csdb.journal.append(accessListAddSlotChange{
+			address: &addr,
+			slot:    &slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (csdb *CommitStateDB) AddressInAccessList(addr sdk.AccAddress) bool {
+	return csdb.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (csdb *CommitStateDB) SlotInAccessList(addr sdk.AccAddress, slot sdk.Hash) (addressPresent bool, slotPresent bool) {
+	return csdb.accessList.Contains(addr, slot)
+}
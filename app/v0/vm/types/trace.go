@@ -0,0 +1,132 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/netcloth/netcloth-chain/hexutil"
+	sdk "github.com/netcloth/netcloth-chain/types"
+)
+
+// tracers supported by the trace query
+const (
+	TracerStruct = "struct"
+	TracerCall   = "call"
+)
+
+// TraceTx is a tx replayed before the traced msg. Its ante handler effects on the vm state are
+// replayed, the fee deduction from the fee payer and the sequence increment of each signer, then its
+// vm msgs unless the tx failed. The msgs which are not executed by the vm can't be replayed, they
+// are only counted in Skipped.
+type TraceTx struct {
+	Signers  []sdk.AccAddress `json:"signers" yaml:"signers"`
+	FeePayer sdk.AccAddress   `json:"fee_payer" yaml:"fee_payer"` // the fee granter when set
+	Fee      sdk.Coins        `json:"fee" yaml:"fee"`
+	Msgs     []MsgContract    `json:"msgs" yaml:"msgs"`
+	Skipped  int              `json:"skipped" yaml:"skipped"`
+	Failed   bool             `json:"failed" yaml:"failed"`
+}
+
+// QueryTraceParams - for query trace, Preceding are the txs executed before the tx of Msg in the same
+// block and Tx is the tx of Msg, holding the vm msgs executed before Msg in it. They are replayed
+// without tracing so that Msg runs on the state it was executed on. Tx is not set for an ad-hoc
+// call, only the sequence of the sender is then incremented before Msg.
+type QueryTraceParams struct {
+	Preceding []TraceTx   `json:"preceding" yaml:"preceding"`
+	Tx        *TraceTx    `json:"tx,omitempty" yaml:"tx"`
+	Msg       MsgContract `json:"msg" yaml:"msg"`
+	Height    int64       `json:"height" yaml:"height"` // height of the block the msg was included in, 0 for an ad-hoc call
+	Time      time.Time   `json:"time" yaml:"time"`
+
+	Tracer         string `json:"tracer" yaml:"tracer"`
+	DisableMemory  bool   `json:"disable_memory" yaml:"disable_memory"`
+	DisableStack   bool   `json:"disable_stack" yaml:"disable_stack"`
+	DisableStorage bool   `json:"disable_storage" yaml:"disable_storage"`
+	Limit          int    `json:"limit" yaml:"limit"`
+}
+
+// NewQueryTraceParams creates a new instance of QueryTraceParams for an ad-hoc call
+func NewQueryTraceParams(msg MsgContract, tracer string) QueryTraceParams {
+	return QueryTraceParams{
+		Msg:    msg,
+		Tracer: tracer,
+	}
+}
+
+// Approximate tells whether some msgs preceding Msg in the block can't be replayed, so that the
+// trace may not run on the exact state Msg was executed on
+func (p QueryTraceParams) Approximate() bool {
+	for _, tx := range p.Preceding {
+		if tx.Skipped > 0 && !tx.Failed {
+			return true
+		}
+	}
+	return p.Tx != nil && p.Tx.Skipped > 0
+}
+
+// ValidateBasic validates the tracer of the params
+func (p QueryTraceParams) ValidateBasic() error {
+	switch p.Tracer {
+	case "", TracerStruct, TracerCall:
+		return nil
+	default:
+		return fmt.Errorf("unknown tracer: %s", p.Tracer)
+	}
+}
+
+// StructLogRes stores a structured log emitted by the vm while replaying a msg in debug mode
+type StructLogRes struct {
+	Pc      uint64             `json:"pc"`
+	Op      string             `json:"op"`
+	Gas     uint64             `json:"gas"`
+	GasCost uint64             `json:"gasCost"`
+	Depth   uint64             `json:"depth"`
+	Error   string             `json:"error,omitempty"`
+	Stack   *[]string          `json:"stack,omitempty"`
+	Memory  *[]string          `json:"memory,omitempty"`
+	Storage *map[string]string `json:"storage,omitempty"`
+}
+
+// TraceResult - for query trace with the struct tracer
+type TraceResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+	// Approximate is set when msgs preceding the traced one in the block could not be replayed
+	Approximate bool `json:"approximate,omitempty"`
+}
+
+func (r TraceResult) String() string {
+	j, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
+
+// CallFrame - for query trace with the call tracer, a call frame and its nested calls
+type CallFrame struct {
+	Type    string         `json:"type"`
+	From    sdk.AccAddress `json:"from"`
+	To      sdk.AccAddress `json:"to,omitempty"`
+	Value   *big.Int       `json:"value,omitempty"`
+	Gas     uint64         `json:"gas"`
+	GasUsed uint64         `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []*CallFrame   `json:"calls,omitempty"`
+	// Approximate is only set on the top frame, see TraceResult
+	Approximate bool `json:"approximate,omitempty"`
+}
+
+func (f CallFrame) String() string {
+	j, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
@@ -415,20 +415,30 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		)
 	}
 
+	if req.Height > app.LastBlockHeight() {
+		return sdkerrors.QueryResult(
+			sdkerrors.Wrapf(
+				sdkerrors.ErrInvalidRequest,
+				"cannot query with height in the future; please provide a valid height (latest height: %d)", app.LastBlockHeight(),
+			),
+		)
+	}
+
 	cacheMS, err := app.cms.CacheMultiStoreWithVersion(req.Height)
 	if err != nil {
 		return sdkerrors.QueryResult(
 			sdkerrors.Wrapf(
 				sdkerrors.ErrInvalidRequest,
-				"failed to load state at height %d; %s (latest height: %d)", req.Height, err, app.LastBlockHeight(),
+				"failed to load state at height %d, it may have been pruned; %s (latest height: %d)", req.Height, err, app.LastBlockHeight(),
 			),
 		)
 	}
 
-	// cache wrap the commit-multistore for safety
+	// cache wrap the commit-multistore for safety, queriers see the block
+	// height the state was loaded at
 	ctx := sdk.NewContext(
 		cacheMS, app.checkState.ctx.BlockHeader(), true, app.logger,
-	).WithMinGasPrices(app.minGasPrices)
+	).WithMinGasPrices(app.minGasPrices).WithBlockHeight(req.Height)
 
 	// Passes the rest of the path as an argument to the querier.
 	//
@@ -436,7 +446,7 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	// []string{"proposal", "test"} as the path.
 	resBytes, queryErr := querier(ctx, path[2:], req)
 	if queryErr != nil {
-		space, code, log := sdkerrors.ABCIInfo(err, false)
+		space, code, log := sdkerrors.ABCIInfo(queryErr, false)
 		return abci.ResponseQuery{
 			Code:      code,
 			Codespace: space,
@@ -464,6 +464,7 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 		events = events.AppendEvents(msgResult.Events)
 
 		idxLog.Success = true
+		idxLog.Log = msgResult.Log
 		idxLogs = append(idxLogs, idxLog)
 	}
 
@@ -26,9 +26,10 @@ import (
 
 // RestServer represents the Light Client Rest server
 type RestServer struct {
-	Mux     *mux.Router
-	CliCtx  context.CLIContext
-	KeyBase keybase.Keybase
+	Mux           *mux.Router
+	CliCtx        context.CLIContext
+	KeyBase       keybase.Keybase
+	Subscriptions *SubscriptionServer
 
 	log      log.Logger
 	listener net.Listener
@@ -41,9 +42,10 @@ func NewRestServer(cdc *codec.Codec) *RestServer {
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "rest-server")
 
 	return &RestServer{
-		Mux:    r,
-		CliCtx: cliCtx,
-		log:    logger,
+		Mux:           r,
+		CliCtx:        cliCtx,
+		Subscriptions: NewSubscriptionServer(cliCtx, logger),
+		log:           logger,
 	}
 }
 
@@ -85,6 +87,7 @@ func ServeCommand(cdc *codec.Codec, registerRoutesFn func(*RestServer)) *cobra.C
 
 			registerRoutesFn(rs)
 			rs.registerSwaggerUI()
+			rs.registerSubscriptions(viper.GetInt(flags.FlagMaxSubscriptions))
 
 			// Start the rest server and return error if one exists
 			err = rs.Start(
@@ -109,3 +112,8 @@ func (rs *RestServer) registerSwaggerUI() {
 	staticServer := http.FileServer(statikFS)
 	rs.Mux.PathPrefix("/swagger-ui/").Handler(http.StripPrefix("/swagger-ui/", staticServer))
 }
+
+func (rs *RestServer) registerSubscriptions(maxSubscriptions int) {
+	rs.Subscriptions.SetMaxSubscriptions(maxSubscriptions)
+	rs.Mux.Handle(WebsocketPath, rs.Subscriptions).Methods("GET")
+}
@@ -0,0 +1,490 @@
+package lcd
+
+import (
+	gocontext "context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+// Subscription kinds served by every rest server
+const (
+	SubscriptionNewHeads   = "newHeads"
+	SubscriptionPendingTxs = "pendingTxs"
+)
+
+const (
+	// WebsocketPath is the path of the subscription endpoint of the rest server
+	WebsocketPath = "/websocket"
+
+	// DefaultMaxSubscriptions is the default max number of subscriptions of a websocket connection
+	DefaultMaxSubscriptions = 16
+
+	// a connection whose queues are full is too slow to follow the chain and is closed
+	wsSendBuffer  = 256
+	wsHeadsBuffer = 16
+
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 64 * 1024
+
+	pendingTxsPollPeriod = time.Second
+	pendingTxsPollLimit  = 100
+
+	subscriber = "rest-server"
+)
+
+// BlockSubscriptionFn creates a subscription from the params sent by the client, it returns the
+// function building the notifications of the subscription for each new block header
+type BlockSubscriptionFn func(cliCtx context.CLIContext, params json.RawMessage) (func(header tmtypes.Header) ([]interface{}, error), error)
+
+// SubscriptionServer pushes new block headers, pending txs and the notifications of the
+// registered block subscriptions to websocket clients. The new block headers come from the event
+// bus of the node, the pending txs from polling its mempool.
+type SubscriptionServer struct {
+	cliCtx           context.CLIContext
+	log              log.Logger
+	maxSubscriptions int
+	kinds            map[string]BlockSubscriptionFn
+	upgrader         websocket.Upgrader
+
+	// startFeeds starts following the node, it is called on the first connection
+	startFeeds func() error
+
+	mtx     sync.Mutex
+	started bool
+	conns   map[*wsConn]struct{}
+}
+
+// NewSubscriptionServer creates a new subscription server serving the new heads and pending txs
+func NewSubscriptionServer(cliCtx context.CLIContext, logger log.Logger) *SubscriptionServer {
+	s := &SubscriptionServer{
+		cliCtx:           cliCtx,
+		log:              logger,
+		maxSubscriptions: DefaultMaxSubscriptions,
+		kinds:            make(map[string]BlockSubscriptionFn),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     func(r *http.Request) bool { return true },
+		},
+		conns: make(map[*wsConn]struct{}),
+	}
+	s.startFeeds = s.followNode
+
+	s.RegisterBlockSubscription(SubscriptionNewHeads, newHeadsSubscription)
+	return s
+}
+
+// SetMaxSubscriptions sets the max number of subscriptions of a websocket connection
+func (s *SubscriptionServer) SetMaxSubscriptions(max int) {
+	s.maxSubscriptions = max
+}
+
+// RegisterBlockSubscription registers a kind of subscription notified on each new block
+func (s *SubscriptionServer) RegisterBlockSubscription(kind string, fn BlockSubscriptionFn) {
+	if _, ok := s.kinds[kind]; ok || kind == SubscriptionPendingTxs {
+		panic(fmt.Sprintf("subscription %s already registered", kind))
+	}
+	s.kinds[kind] = fn
+}
+
+func newHeadsSubscription(_ context.CLIContext, _ json.RawMessage) (func(header tmtypes.Header) ([]interface{}, error), error) {
+	return func(header tmtypes.Header) ([]interface{}, error) {
+		return []interface{}{header}, nil
+	}, nil
+}
+
+// ServeHTTP upgrades the request to a websocket connection and serves its subscriptions
+func (s *SubscriptionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.start(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Error("failed to upgrade websocket connection", "err", err)
+		return
+	}
+
+	c := &wsConn{
+		server: s,
+		conn:   conn,
+		send:   make(chan interface{}, wsSendBuffer),
+		heads:  make(chan tmtypes.Header, wsHeadsBuffer),
+		quit:   make(chan struct{}),
+		subs:   make(map[string]*wsSubscription),
+	}
+
+	s.mtx.Lock()
+	s.conns[c] = struct{}{}
+	s.mtx.Unlock()
+
+	go c.writeLoop()
+	go c.headsLoop()
+	c.readLoop()
+}
+
+func (s *SubscriptionServer) start() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.started {
+		return nil
+	}
+	if err := s.startFeeds(); err != nil {
+		return err
+	}
+	s.started = true
+	return nil
+}
+
+// followNode subscribes to the new block headers of the node and polls its mempool
+func (s *SubscriptionServer) followNode() error {
+	if s.cliCtx.NodeURI == "" {
+		return errors.New("no node to follow, set --node")
+	}
+
+	client := rpcclient.NewHTTP(s.cliCtx.NodeURI, "/websocket")
+	if err := client.Start(); err != nil {
+		return err
+	}
+
+	heads, err := client.Subscribe(gocontext.Background(), subscriber, tmtypes.EventQueryNewBlockHeader.String(), wsHeadsBuffer)
+	if err != nil {
+		_ = client.Stop()
+		return err
+	}
+
+	go func() {
+		for event := range heads {
+			if data, ok := event.Data.(tmtypes.EventDataNewBlockHeader); ok {
+				s.publishHeader(data.Header)
+			}
+		}
+	}()
+
+	go s.pollPendingTxs(client)
+	return nil
+}
+
+// pollPendingTxs publishes the hashes of the txs entering the mempool of the node
+func (s *SubscriptionServer) pollPendingTxs(client rpcclient.Client) {
+	seen := make(map[string]struct{})
+
+	ticker := time.NewTicker(pendingTxsPollPeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !s.hasSubscription(SubscriptionPendingTxs) {
+			continue
+		}
+
+		res, err := client.UnconfirmedTxs(pendingTxsPollLimit)
+		if err != nil {
+			s.log.Error("failed to poll pending txs", "err", err)
+			continue
+		}
+
+		current := make(map[string]struct{}, len(res.Txs))
+		for _, tx := range res.Txs {
+			hash := strings.ToUpper(hex.EncodeToString(tx.Hash()))
+			current[hash] = struct{}{}
+			if _, ok := seen[hash]; !ok {
+				s.publishPendingTx(hash)
+			}
+		}
+		seen = current
+	}
+}
+
+func (s *SubscriptionServer) hasSubscription(kind string) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for c := range s.conns {
+		if c.hasSubscription(kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SubscriptionServer) publishHeader(header tmtypes.Header) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for c := range s.conns {
+		select {
+		case c.heads <- header:
+		default:
+			c.close("too slow to follow new blocks")
+		}
+	}
+}
+
+func (s *SubscriptionServer) publishPendingTx(hash string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for c := range s.conns {
+		for _, id := range c.subscriptionIDs(SubscriptionPendingTxs) {
+			c.push(wsNotification{Subscription: id, Result: hash})
+		}
+	}
+}
+
+func (s *SubscriptionServer) remove(c *wsConn) {
+	s.mtx.Lock()
+	delete(s.conns, c)
+	s.mtx.Unlock()
+}
+
+type wsRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type wsSubscribeParams struct {
+	Kind   string          `json:"kind"`
+	Filter json.RawMessage `json:"filter"`
+}
+
+type wsUnsubscribeParams struct {
+	Subscription string `json:"subscription"`
+}
+
+type wsResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type wsNotification struct {
+	Subscription string      `json:"subscription"`
+	Result       interface{} `json:"result"`
+}
+
+type wsSubscription struct {
+	kind     string
+	onHeader func(header tmtypes.Header) ([]interface{}, error)
+}
+
+// wsConn is a websocket connection and its subscriptions
+type wsConn struct {
+	server *SubscriptionServer
+	conn   *websocket.Conn
+	send   chan interface{}
+	heads  chan tmtypes.Header
+
+	quit      chan struct{}
+	closeOnce sync.Once
+
+	mtx    sync.Mutex
+	subs   map[string]*wsSubscription
+	nextID uint64
+}
+
+// push queues a message to the client, the connection is closed when the client does not keep up
+func (c *wsConn) push(msg interface{}) {
+	select {
+	case c.send <- msg:
+	case <-c.quit:
+	default:
+		c.close("too slow to read notifications")
+	}
+}
+
+func (c *wsConn) close(reason string) {
+	c.closeOnce.Do(func() {
+		c.server.log.Debug("closing websocket connection", "remote", c.conn.RemoteAddr(), "reason", reason)
+		close(c.quit)
+	})
+}
+
+func (c *wsConn) readLoop() {
+	defer func() {
+		c.close("connection closed")
+		c.server.remove(c)
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, bz, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req wsRequest
+		if err := json.Unmarshal(bz, &req); err != nil {
+			c.push(wsResponse{Error: err.Error()})
+			continue
+		}
+
+		result, err := c.handle(req)
+		if err != nil {
+			c.push(wsResponse{ID: req.ID, Error: err.Error()})
+			continue
+		}
+		c.push(wsResponse{ID: req.ID, Result: result})
+	}
+}
+
+func (c *wsConn) handle(req wsRequest) (interface{}, error) {
+	switch req.Method {
+	case "subscribe":
+		var params wsSubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return c.subscribe(params)
+
+	case "unsubscribe":
+		var params wsUnsubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return c.unsubscribe(params.Subscription)
+
+	default:
+		return nil, fmt.Errorf("unknown method: %s", req.Method)
+	}
+}
+
+func (c *wsConn) subscribe(params wsSubscribeParams) (string, error) {
+	sub := &wsSubscription{kind: params.Kind}
+	if params.Kind != SubscriptionPendingTxs {
+		fn, ok := c.server.kinds[params.Kind]
+		if !ok {
+			return "", fmt.Errorf("unknown subscription: %s", params.Kind)
+		}
+
+		onHeader, err := fn(c.server.cliCtx, params.Filter)
+		if err != nil {
+			return "", err
+		}
+		sub.onHeader = onHeader
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.subs) >= c.server.maxSubscriptions {
+		return "", fmt.Errorf("too many subscriptions, max %d per connection", c.server.maxSubscriptions)
+	}
+
+	c.nextID++
+	id := "0x" + strconv.FormatUint(c.nextID, 16)
+	c.subs[id] = sub
+	return id, nil
+}
+
+func (c *wsConn) unsubscribe(id string) (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.subs[id]; !ok {
+		return false, fmt.Errorf("unknown subscription id: %s", id)
+	}
+	delete(c.subs, id)
+	return true, nil
+}
+
+func (c *wsConn) hasSubscription(kind string) bool {
+	return len(c.subscriptionIDs(kind)) > 0
+}
+
+func (c *wsConn) subscriptionIDs(kind string) (ids []string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for id, sub := range c.subs {
+		if sub.kind == kind {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
+
+// headsLoop builds the notifications of the block subscriptions for each new block header
+func (c *wsConn) headsLoop() {
+	for {
+		select {
+		case header := <-c.heads:
+			c.mtx.Lock()
+			subs := make(map[string]*wsSubscription, len(c.subs))
+			for id, sub := range c.subs {
+				if sub.onHeader != nil {
+					subs[id] = sub
+				}
+			}
+			c.mtx.Unlock()
+
+			for id, sub := range subs {
+				results, err := sub.onHeader(header)
+				if err != nil {
+					c.server.log.Error("failed to build notifications", "subscription", sub.kind, "height", header.Height, "err", err)
+					continue
+				}
+				for _, result := range results {
+					c.push(wsNotification{Subscription: id, Result: result})
+				}
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+func (c *wsConn) writeLoop() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.close(err.Error())
+				return
+			}
+
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.close(err.Error())
+				return
+			}
+
+		case <-c.quit:
+			_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+			return
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package lcd
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/netcloth/netcloth-chain/client/context"
+)
+
+type testMessage struct {
+	ID           json.RawMessage `json:"id"`
+	Result       json.RawMessage `json:"result"`
+	Error        string          `json:"error"`
+	Subscription string          `json:"subscription"`
+}
+
+func dialSubscriptionServer(t *testing.T, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	return conn
+}
+
+func call(t *testing.T, conn *websocket.Conn, method string, params interface{}) testMessage {
+	bz, err := json.Marshal(params)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(wsRequest{ID: json.RawMessage("1"), Method: method, Params: bz}))
+	return read(t, conn)
+}
+
+func read(t *testing.T, conn *websocket.Conn) (msg testMessage) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, conn.ReadJSON(&msg))
+	return
+}
+
+func TestSubscriptionServer(t *testing.T) {
+	s := NewSubscriptionServer(context.CLIContext{}, log.NewNopLogger())
+	s.startFeeds = func() error { return nil }
+	s.SetMaxSubscriptions(3)
+	s.RegisterBlockSubscription("odd", func(_ context.CLIContext, _ json.RawMessage) (func(tmtypes.Header) ([]interface{}, error), error) {
+		return func(header tmtypes.Header) ([]interface{}, error) {
+			if header.Height%2 == 0 {
+				return nil, nil
+			}
+			return []interface{}{header.Height}, nil
+		}, nil
+	})
+	require.Panics(t, func() { s.RegisterBlockSubscription(SubscriptionPendingTxs, newHeadsSubscription) })
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+	conn := dialSubscriptionServer(t, server)
+	defer conn.Close()
+
+	heads := call(t, conn, "subscribe", wsSubscribeParams{Kind: SubscriptionNewHeads})
+	require.Empty(t, heads.Error)
+	odd := call(t, conn, "subscribe", wsSubscribeParams{Kind: "odd"})
+	require.Empty(t, odd.Error)
+	require.NotEqual(t, heads.Result, odd.Result)
+
+	res := call(t, conn, "subscribe", wsSubscribeParams{Kind: "unknown"})
+	require.Contains(t, res.Error, "unknown subscription")
+
+	// the new heads are pushed to the subscriptions of the connection
+	require.Eventually(t, func() bool { return s.hasSubscription("odd") }, time.Second, 10*time.Millisecond)
+	s.publishHeader(tmtypes.Header{Height: 2})
+	msg := read(t, conn)
+	require.Equal(t, strings.Trim(string(heads.Result), `"`), msg.Subscription)
+
+	s.publishHeader(tmtypes.Header{Height: 3})
+	notified := make(map[string]string)
+	for i := 0; i < 2; i++ {
+		msg = read(t, conn)
+		notified[msg.Subscription] = string(msg.Result)
+	}
+	require.Equal(t, "3", notified[strings.Trim(string(odd.Result), `"`)])
+
+	// pending txs
+	pending := call(t, conn, "subscribe", wsSubscribeParams{Kind: SubscriptionPendingTxs})
+	require.Empty(t, pending.Error)
+	s.publishPendingTx("AB")
+	msg = read(t, conn)
+	require.Equal(t, strings.Trim(string(pending.Result), `"`), msg.Subscription)
+	require.Equal(t, `"AB"`, string(msg.Result))
+
+	// the number of subscriptions of a connection is bounded
+	res = call(t, conn, "subscribe", wsSubscribeParams{Kind: SubscriptionNewHeads})
+	require.Contains(t, res.Error, "too many subscriptions")
+
+	res = call(t, conn, "unsubscribe", wsUnsubscribeParams{Subscription: strings.Trim(string(heads.Result), `"`)})
+	require.Empty(t, res.Error)
+	require.Equal(t, "true", string(res.Result))
+	res = call(t, conn, "unsubscribe", wsUnsubscribeParams{Subscription: strings.Trim(string(heads.Result), `"`)})
+	require.Contains(t, res.Error, "unknown subscription id")
+	res = call(t, conn, "subscribe", wsSubscribeParams{Kind: SubscriptionNewHeads})
+	require.Empty(t, res.Error)
+
+	res = call(t, conn, "unknown", nil)
+	require.Contains(t, res.Error, "unknown method")
+}
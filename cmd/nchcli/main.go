@@ -20,6 +20,8 @@ import (
 	cipalcli "github.com/netcloth/netcloth-chain/app/v0/cipal/client/cli"
 	ipalcli "github.com/netcloth/netcloth-chain/app/v0/ipal/client/cli"
 	vmcli "github.com/netcloth/netcloth-chain/app/v0/vm/client/cli"
+	vmrest "github.com/netcloth/netcloth-chain/app/v0/vm/client/rest"
+	vmrpc "github.com/netcloth/netcloth-chain/app/v0/vm/client/rpc"
 	"github.com/netcloth/netcloth-chain/client"
 	"github.com/netcloth/netcloth-chain/client/keys"
 	"github.com/netcloth/netcloth-chain/client/lcd"
@@ -76,6 +78,8 @@ func registerRoutes(rs *lcd.RestServer) {
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	authrest.RegisterTxRoutes(rs.CliCtx, rs.Mux)
 	v0.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
+	vmrpc.RegisterRoutes(rs.CliCtx, rs.Mux)
+	rs.Subscriptions.RegisterBlockSubscription(vmrest.SubscriptionLogs, vmrest.LogsSubscription)
 }
 
 func queryCmd(cdc *amino.Codec) *cobra.Command {
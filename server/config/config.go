@@ -9,6 +9,9 @@ import (
 
 const (
 	defaultMinGasPrices = "1.0" + sdk.NativeTokenName
+
+	// DefaultMaxLogsBlockRange is the default max number of blocks a vm logs filter query may scan
+	DefaultMaxLogsBlockRange = 1000
 )
 
 // BaseConfig defines the server's basic configuration
@@ -21,6 +24,10 @@ type BaseConfig struct {
 	// HaltHeight contains a non-zero height at which a node will gracefully halt
 	// and shutdown that can be used to assist upgrades and testing.
 	HaltHeight uint64 `mapstructure:"halt-height"`
+
+	// MaxLogsBlockRange is the max number of blocks a vm logs filter query served by
+	// the node may scan.
+	MaxLogsBlockRange uint64 `mapstructure:"max-logs-block-range"`
 }
 
 // Config defines the server's top level configuration
@@ -59,8 +66,9 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig{
-			MinGasPrices: defaultMinGasPrices,
-			HaltHeight:   0,
+			MinGasPrices:      defaultMinGasPrices,
+			HaltHeight:        0,
+			MaxLogsBlockRange: DefaultMaxLogsBlockRange,
 		},
 	}
 }
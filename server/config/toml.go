@@ -21,6 +21,10 @@ minimum-gas-prices = "{{ .BaseConfig.MinGasPrices }}"
 # HaltHeight contains a non-zero height at which a node will gracefully halt
 # and shutdown that can be used to assist upgrades and testing.
 halt-height = {{ .BaseConfig.HaltHeight }}
+
+# MaxLogsBlockRange is the max number of blocks a vm logs filter query served by
+# the node may scan.
+max-logs-block-range = {{ .BaseConfig.MaxLogsBlockRange }}
 `
 
 var configTemplate *template.Template
@@ -14,16 +14,19 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/netcloth/netcloth-chain/server/config"
 )
 
 // Tendermint full-node start flags
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
-	flagTraceStore     = "trace-store"
-	flagPruning        = "pruning"
-	FlagMinGasPrices   = "minimum-gas-prices"
-	FlagHaltHeight     = "halt-height"
+	flagWithTendermint    = "with-tendermint"
+	flagAddress           = "address"
+	flagTraceStore        = "trace-store"
+	flagPruning           = "pruning"
+	FlagMinGasPrices      = "minimum-gas-prices"
+	FlagHaltHeight        = "halt-height"
+	FlagMaxLogsBlockRange = "max-logs-block-range"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -55,6 +58,7 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 		"Minimum gas prices to accept for transactions; Any fee in a tx must meet this minimum (e.g. 0.01photino;0.0001stake)",
 	)
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Height at which to gracefully halt the chain and shutdown the node")
+	cmd.Flags().Uint64(FlagMaxLogsBlockRange, config.DefaultMaxLogsBlockRange, "Max number of blocks a vm logs filter query may scan")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)